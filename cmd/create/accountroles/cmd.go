@@ -150,8 +150,8 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	// Validate AWS quota
-	// Call `verify quota` as part of init
-	err = quota.Cmd.RunE(cmd, argv)
+	// Check the minimum quota needed to install clusters
+	err = quota.ValidateMinimumQuota(r, r.AWSClient)
 	if err != nil {
 		r.Reporter.Warnf("Insufficient AWS quotas. Cluster installation might fail.")
 	}
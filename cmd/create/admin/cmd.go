@@ -17,14 +17,15 @@ limitations under the License.
 package admin
 
 import (
-	"crypto/rand"
-	"math/big"
 	"os"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/admin"
+	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/object"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
@@ -36,12 +37,21 @@ var Cmd = &cobra.Command{
 	Short: "Creates an admin user to login to the cluster",
 	Long:  "Creates a cluster-admin user with an auto-generated password to login to the cluster",
 	Example: `  # Create an admin user to login to the cluster
-  rosa create admin -c mycluster -p MasterKey123`,
+  rosa create admin -c mycluster -p MasterKey123
+
+  # Create a break-glass admin that is deleted by 'rosa schedule run' after 8 hours, and store its
+  # password in a password manager
+  rosa create admin -c mycluster --name breakglass --expire-in 8h \
+  --password-command "pass insert -m rosa/breakglass"`,
 	Run: run,
 }
 
 var args struct {
-	passwordArg string
+	passwordArg     string
+	name            string
+	expireIn        string
+	passwordFile    string
+	passwordCommand string
 }
 
 func init() {
@@ -54,6 +64,32 @@ func init() {
 		"",
 		"Choice of password for admin user.",
 	)
+	flags.StringVar(
+		&args.name,
+		"name",
+		idp.ClusterAdminUsername,
+		"Name of the admin user. Clusters can have several admin users with different names.",
+	)
+	flags.StringVar(
+		&args.expireIn,
+		"expire-in",
+		"",
+		"Duration after which the admin user expires, for example '8h' or '2d'. Expired admin users are "+
+			"deleted by 'rosa schedule run'.",
+	)
+	flags.StringVar(
+		&args.passwordFile,
+		"password-file",
+		"",
+		"Write the password to this file, readable only by the current user, instead of printing it.",
+	)
+	flags.StringVar(
+		&args.passwordCommand,
+		"password-command",
+		"",
+		"Pass the password to the standard input of this command, for example a password manager, "+
+			"instead of printing it.",
+	)
 	output.AddFlag(Cmd)
 }
 
@@ -69,21 +105,40 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	username := args.name
+	err := admin.ValidateUsername(username)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	var expiration time.Time
+	if args.expireIn != "" {
+		duration, err := helper.ParseDuration(args.expireIn)
+		if err != nil || duration <= 0 {
+			r.Reporter.Errorf("Expected a positive duration for '--expire-in', got '%s'", args.expireIn)
+			os.Exit(1)
+		}
+		expiration = time.Now().Add(duration)
+	}
+
 	// Try to find an existing htpasswd identity provider and
-	// check if cluster-admin user already exists
+	// check if the admin user already exists
 	existingHTPasswdIDP, existingUserList := idp.FindExistingHTPasswdIDP(cluster, r)
-	if idp.HasClusterAdmin(existingUserList) {
-		r.Reporter.Errorf("Cluster '%s' already has an admin", clusterKey)
+	if admin.HasUser(existingUserList, username) {
+		if username == idp.ClusterAdminUsername {
+			r.Reporter.Errorf("Cluster '%s' already has an admin", clusterKey)
+		} else {
+			r.Reporter.Errorf("Cluster '%s' already has a user named '%s'", clusterKey, username)
+		}
 		os.Exit(1)
 	}
 
 	// No cluster admin yet: proceed to create it.
 	var password string
-	var err error
 	passwordArg := args.passwordArg
 	if len(passwordArg) == 0 {
 		r.Reporter.Debugf("Generating random password")
-		password, err = generateRandomPassword(23)
+		password, err = admin.GeneratePassword(23)
 		if err != nil {
 			r.Reporter.Errorf("Failed to generate a random password")
 			os.Exit(1)
@@ -94,17 +149,17 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	// Add admin user to the cluster-admins group:
-	r.Reporter.Debugf("Adding '%s' user to cluster '%s'", idp.ClusterAdminUsername, clusterKey)
-	user, err := cmv1.NewUser().ID(idp.ClusterAdminUsername).Build()
+	r.Reporter.Debugf("Adding '%s' user to cluster '%s'", username, clusterKey)
+	user, err := cmv1.NewUser().ID(username).Build()
 	if err != nil {
-		r.Reporter.Errorf("Failed to create user '%s' for cluster '%s'", idp.ClusterAdminUsername, clusterKey)
+		r.Reporter.Errorf("Failed to create user '%s' for cluster '%s'", username, clusterKey)
 		os.Exit(1)
 	}
 
-	_, err = r.OCMClient.CreateUser(cluster.ID(), "cluster-admins", user)
+	_, err = r.OCMClient.CreateUser(cluster.ID(), ocm.ClusterAdminsGroup, user)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add user '%s' to cluster '%s': %s",
-			idp.ClusterAdminUsername, clusterKey, err)
+			username, clusterKey, err)
 		os.Exit(1)
 	}
 
@@ -112,7 +167,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if existingHTPasswdIDP == nil {
 		r.Reporter.Debugf("Adding '%s' idp to cluster '%s'", idp.HTPasswdIDPName, clusterKey)
 		htpasswdIDP := cmv1.NewHTPasswdIdentityProvider().Users(cmv1.NewHTPasswdUserList().Items(
-			idp.CreateHTPasswdUser(idp.ClusterAdminUsername, password),
+			idp.CreateHTPasswdUser(username, password),
 		))
 		newIDP, err := cmv1.NewIdentityProvider().
 			Type("HTPasswdIdentityProvider").
@@ -135,7 +190,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Reporter.Errorf("Failed to add '%s' identity provider to cluster '%s' as part of admin flow. "+
 				"Please try again: %s", idp.HTPasswdIDPName, clusterKey, err)
 
-			err = r.OCMClient.DeleteUser(cluster.ID(), "cluster-admins", user.ID())
+			err = r.OCMClient.DeleteUser(cluster.ID(), ocm.ClusterAdminsGroup, user.ID())
 			if err != nil {
 				r.Reporter.Errorf("Failed to revert the admin user for cluster '%s'. Please try again: %s",
 					clusterKey, err)
@@ -145,30 +200,52 @@ func run(cmd *cobra.Command, _ []string) {
 	} else {
 		// HTPasswd IDP exists - add new cluster-admin user to it.
 		r.Reporter.Debugf("Cluster has an HTPasswd IDP, will add cluster-admin to it")
-		err = r.OCMClient.AddHTPasswdUser(idp.ClusterAdminUsername, password, cluster.ID(), existingHTPasswdIDP.ID())
+		err = r.OCMClient.AddHTPasswdUser(username, password, cluster.ID(), existingHTPasswdIDP.ID())
 		if err != nil {
 			r.Reporter.Errorf("Failed to add user '%s' to the HTPasswd IDP of cluster '%s': %s",
-				idp.ClusterAdminUsername, clusterKey, err)
+				username, clusterKey, err)
 
 			//since we could not add the cluster-admin user ot the HTPasswd IDP, roll back and remove the cluster admin
-			err = r.OCMClient.DeleteUser(cluster.ID(), "cluster-admins", user.ID())
+			err = r.OCMClient.DeleteUser(cluster.ID(), ocm.ClusterAdminsGroup, user.ID())
 			if err != nil {
 				r.Reporter.Errorf("Failed to remove user '%s' to cluster '%s': %s",
-					idp.ClusterAdminUsername, clusterKey, err)
+					username, clusterKey, err)
 			}
 
 			os.Exit(1)
 		}
 	}
 
+	if !expiration.IsZero() {
+		err = r.OCMClient.UpdateClusterProperties(cluster.ID(), func(props map[string]string) map[string]string {
+			return admin.Properties(props, username, expiration)
+		})
+		if err != nil {
+			r.Reporter.Errorf("Failed to set the expiration of admin user '%s' of cluster '%s', "+
+				"delete it with 'rosa delete admin -c %s --name %s': %v",
+				username, clusterKey, clusterKey, username, err)
+			os.Exit(1)
+		}
+	}
+
+	savePassword := args.passwordFile != "" || args.passwordCommand != ""
+	err = admin.SavePassword(password, args.passwordFile, args.passwordCommand)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
 	outputObject := object.Object{
 		"api_url":  cluster.API().URL(),
-		"username": idp.ClusterAdminUsername,
+		"username": username,
 		"password": password,
 	}
+	if !expiration.IsZero() {
+		outputObject["expiration_timestamp"] = expiration.UTC().Format(time.RFC3339)
+	}
 
 	if output.HasFlag() {
-		if len(passwordArg) != 0 {
+		if len(passwordArg) != 0 || savePassword {
 			delete(outputObject, "password")
 		}
 		err = output.Print(outputObject)
@@ -180,45 +257,20 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	r.Reporter.Infof("Admin account has been added to cluster '%s'.", clusterKey)
-	r.Reporter.Infof("Please securely store this generated password. " +
-		"If you lose this password you can delete and recreate the cluster admin user.")
-	r.Reporter.Infof("To login, run the following command:\n\n"+
-		"   oc login %s --username %s --password %s\n",
-		outputObject["api_url"], outputObject["username"], outputObject["password"])
-	r.Reporter.Infof("It may take several minutes for this access to become active.")
-}
-
-func generateRandomPassword(length int) (string, error) {
-	const (
-		lowerLetters = "abcdefghijkmnopqrstuvwxyz"
-		upperLetters = "ABCDEFGHIJKLMNPQRSTUVWXYZ"
-		digits       = "23456789"
-		all          = lowerLetters + upperLetters + digits
-	)
-	var password string
-	for i := 0; i < length; i++ {
-		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(all))))
-		if err != nil {
-			return "", err
-		}
-		newchar := string(all[n.Int64()])
-		if password == "" {
-			password = newchar
-		}
-		if i < length-1 {
-			n, err = rand.Int(rand.Reader, big.NewInt(int64(len(password)+1)))
-			if err != nil {
-				return "", err
-			}
-			j := n.Int64()
-			password = password[0:j] + newchar + password[j:]
-		}
+	if savePassword {
+		r.Reporter.Infof("The password has been saved. To login, run the following command:\n\n"+
+			"   oc login %s --username %s\n",
+			outputObject["api_url"], outputObject["username"])
+	} else {
+		r.Reporter.Infof("Please securely store this generated password. " +
+			"If you lose this password you can delete and recreate the cluster admin user.")
+		r.Reporter.Infof("To login, run the following command:\n\n"+
+			"   oc login %s --username %s --password %s\n",
+			outputObject["api_url"], outputObject["username"], outputObject["password"])
 	}
-
-	pw := []rune(password)
-	for _, replace := range []int{5, 11, 17} {
-		pw[replace] = '-'
+	if !expiration.IsZero() {
+		r.Reporter.Infof("Admin user '%s' expires on %s, and is deleted by the next 'rosa schedule run'.",
+			username, expiration.UTC().Format("2006-01-02 15:04 MST"))
 	}
-
-	return string(pw), nil
+	r.Reporter.Infof("It may take several minutes for this access to become active.")
 }
@@ -38,6 +38,7 @@ import (
 	installLogs "github.com/openshift/rosa/cmd/logs/install"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cost"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
@@ -45,6 +46,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/properties"
+	"github.com/openshift/rosa/pkg/proxy"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -106,6 +108,9 @@ var args struct {
 	// unless using PrivateLink, in which case it should only be one private per availability zone
 	subnetIDs []string
 
+	// Tag-based filter applied to the subnets offered when installing into an existing VPC
+	subnetSelector string
+
 	// Selecting availability zones for a non-BYOVPC cluster
 	availabilityZones []string
 
@@ -353,9 +358,6 @@ func init() {
 		0,
 		"Expire cluster after a relative duration like 2h, 8h, 72h. Only one of expiration-time / expiration may be used.",
 	)
-	// Cluster expiration is not supported in production
-	flags.MarkHidden("expiration-time")
-	flags.MarkHidden("expiration")
 
 	flags.BoolVar(
 		&args.privateLink,
@@ -374,6 +376,15 @@ func init() {
 			"Leave empty for installer provisioned subnet IDs.",
 	)
 
+	flags.StringVar(
+		&args.subnetSelector,
+		"subnet-selector",
+		"",
+		"Filter the subnets offered when installing into an existing VPC by their tags. "+
+			"Format should be a comma-separated list of 'key=value', where the value may contain '*' "+
+			"and '?' wildcards, for example 'Name=prod-*'.",
+	)
+
 	flags.StringSliceVar(
 		&args.availabilityZones,
 		"availability-zones",
@@ -1354,6 +1365,21 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	subnetSelectors, err := aws.ParseSubnetSelector(args.subnetSelector)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if len(subnetSelectors) > 0 && !subnetsProvided {
+		// The selector only narrows the subnets offered by the subnet prompt, so without it there would be
+		// nothing to select
+		if !interactive.Enabled() && !privateLink {
+			r.Reporter.Errorf("Expected --subnet-ids or interactive mode when using --subnet-selector")
+			os.Exit(1)
+		}
+		useExistingVPC = true
+	}
+
 	var availabilityZones []string
 	if useExistingVPC || subnetsProvided {
 		subnets, err := awsClient.GetSubnetIDs()
@@ -1364,11 +1390,10 @@ func run(cmd *cobra.Command, _ []string) {
 		if subnetsProvided {
 			useExistingVPC = true
 		}
+		subnets = aws.FilterSubnetsBySelector(subnets, subnetSelectors)
 
 		mapSubnetToAZ := make(map[string]string)
 		mapAZCreated := make(map[string]bool)
-		options := make([]string, len(subnets))
-		defaultOptions := make([]string, len(subnetIDs))
 
 		// Verify subnets provided exist.
 		if subnetsProvided {
@@ -1380,28 +1405,43 @@ func run(cmd *cobra.Command, _ []string) {
 					}
 				}
 				if !verifiedSubnet {
-					r.Reporter.Errorf("Could not find the following subnet provided: %s", subnetArg)
+					if len(subnetSelectors) > 0 {
+						r.Reporter.Errorf("Could not find the following subnet provided matching selector '%s': %s",
+							args.subnetSelector, subnetArg)
+					} else {
+						r.Reporter.Errorf("Could not find the following subnet provided: %s", subnetArg)
+					}
 					os.Exit(1)
 				}
 			}
 		}
 
-		for i, subnet := range subnets {
+		for _, subnet := range subnets {
 			subnetID := awssdk.StringValue(subnet.SubnetId)
 			availabilityZone := awssdk.StringValue(subnet.AvailabilityZone)
 
-			// Create the options to prompt the user.
-			options[i] = aws.SetSubnetOption(subnetID, availabilityZone)
-			if subnetsProvided {
-				for _, subnetArg := range subnetIDs {
-					defaultOptions = append(defaultOptions, aws.SetSubnetOption(subnetArg, availabilityZone))
-				}
-			}
 			mapSubnetToAZ[subnetID] = availabilityZone
 			mapAZCreated[availabilityZone] = false
 		}
 		if ((privateLink && !subnetsProvided) || interactive.Enabled()) &&
-			len(options) > 0 && (!multiAZ || len(mapAZCreated) >= 3) {
+			len(subnets) > 0 && (!multiAZ || len(mapAZCreated) >= 3) {
+			// Create the options to prompt the user.
+			subnetDetails, err := awsClient.GetSubnetDetails(subnets)
+			if err != nil {
+				r.Reporter.Errorf("Failed to get the details of the subnets: %s", err)
+				os.Exit(1)
+			}
+			header, options := aws.SetSubnetDetailOptions(subnetDetails)
+			defaultOptions := []string{}
+			if subnetsProvided {
+				for _, option := range options {
+					if helper.Contains(subnetIDs, aws.ParseSubnet(option)) {
+						defaultOptions = append(defaultOptions, option)
+					}
+				}
+			}
+			// Align the header with the options of the selection prompt
+			fmt.Printf("  %s\n", header)
 			subnetIDs, err = interactive.GetMultipleOptions(interactive.Input{
 				Question: "Subnet IDs",
 				Help:     cmd.Flags().Lookup("subnet-ids").Usage,
@@ -1521,6 +1561,17 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Expected a valid value for kms-key-arn matching %s", kmsArnRE)
 		os.Exit(1)
 	}
+	if kmsKeyARN != "" {
+		err = awsClient.ValidateKMSKey(kmsKeyARN, region)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if isSTS {
+			validateKMSKeyPolicy(r, awsClient, kmsKeyARN,
+				[]string{roleARN, controlPlaneRoleARN, workerRoleARN}, operatorIAMRoleList)
+		}
+	}
 	dMachinecidr, dPodcidr, dServicecidr, dhostPrefix, defaultComputeMachineType := r.OCMClient.
 		GetDefaultClusterFlavors(args.flavour)
 	if dMachinecidr == nil || dPodcidr == nil || dServicecidr == nil {
@@ -2024,6 +2075,11 @@ func run(cmd *cobra.Command, _ []string) {
 			clusterConfig.CustomProperties[prop] = "true"
 		}
 	}
+	if additionalTrustBundle != nil {
+		// OCM doesn't return the additional trust bundle, so its expiration is kept to warn about it
+		clusterConfig.CustomProperties = proxy.TrustBundleProperties(clusterConfig.CustomProperties,
+			*additionalTrustBundle)
+	}
 
 	if !output.HasFlag() || r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Creating cluster '%s'", clusterName)
@@ -2046,6 +2102,7 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	if args.dryRun {
+		printCostEstimate(r, clusterConfig)
 		r.Reporter.Infof(
 			"Creating cluster '%s' should succeed. Run without the '--dry-run' flag to create the cluster.",
 			clusterName)
@@ -2465,3 +2522,140 @@ func buildCommand(spec ocm.Spec, operatorRolesPrefix string,
 func getRolePrefix(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, helper.RandomLabel(4))
 }
+
+// validateKMSKeyPolicy checks that the key policy allows the account roles to use the key. Actions that the
+// key policy delegates to IAM are checked by simulating the IAM policies of the roles. Operator roles don't
+// exist yet when the cluster is created, so missing grants for them are only reported.
+func validateKMSKeyPolicy(r *rosa.Runtime, awsClient aws.Client, kmsKeyARN string, accountRoleARNs []string,
+	operatorIAMRoles []ocm.OperatorIAMRole) {
+	policy, err := awsClient.GetKMSKeyPolicy(kmsKeyARN)
+	if err != nil {
+		r.Reporter.Warnf("Unable to verify the policy of KMS key '%s': %v", kmsKeyARN, err)
+		return
+	}
+
+	var accountPrincipals []aws.KMSKeyPrincipal
+	for _, roleARN := range accountRoleARNs {
+		if roleARN != "" {
+			accountPrincipals = append(accountPrincipals, aws.KMSKeyPrincipal{RoleARN: roleARN})
+		}
+	}
+	checks := aws.CheckKMSKeyActions(policy, accountPrincipals)
+	var missing, unverified, conditional []string
+	for _, principal := range accountPrincipals {
+		check, ok := checks[principal.RoleARN]
+		if !ok {
+			continue
+		}
+		missingActions := check.Missing
+		if len(check.Delegated) > 0 {
+			denied, err := awsClient.GetKMSKeyActionsDeniedByIAM(principal.RoleARN, kmsKeyARN, check.Delegated)
+			if err != nil {
+				r.Reporter.Debugf("%v", err)
+				unverified = append(unverified,
+					fmt.Sprintf("- %s: %s", principal.RoleARN, strings.Join(check.Delegated, ", ")))
+			}
+			missingActions = append(missingActions, denied...)
+		}
+		if len(missingActions) > 0 {
+			missing = append(missing, fmt.Sprintf("- %s: %s", principal.RoleARN, strings.Join(missingActions, ", ")))
+		}
+		if len(check.Conditional) > 0 {
+			conditional = append(conditional,
+				fmt.Sprintf("- %s: %s", principal.RoleARN, strings.Join(check.Conditional, ", ")))
+		}
+	}
+	if len(missing) > 0 {
+		r.Reporter.Errorf("The policy of KMS key '%s' doesn't allow the following actions:\n%s\n"+
+			"To print a key policy that grants them, run 'rosa create kms-key --mode manual'",
+			kmsKeyARN, strings.Join(missing, "\n"))
+		os.Exit(1)
+	}
+	if len(unverified) > 0 {
+		r.Reporter.Warnf("The policy of KMS key '%s' delegates the following actions to IAM, but the IAM "+
+			"policies of the roles couldn't be verified:\n%s", kmsKeyARN, strings.Join(unverified, "\n"))
+	}
+	if len(conditional) > 0 {
+		r.Reporter.Warnf("The policy of KMS key '%s' only allows the following actions under conditions "+
+			"that can't be verified:\n%s", kmsKeyARN, strings.Join(conditional, "\n"))
+	}
+
+	var operatorPrincipals []aws.KMSKeyPrincipal
+	for _, role := range operatorIAMRoles {
+		if helper.Contains(aws.KMSKeyOperatorNamespaces, role.Namespace) {
+			operatorPrincipals = append(operatorPrincipals, aws.KMSKeyPrincipal{RoleARN: role.RoleARN})
+		}
+	}
+	checks = aws.CheckKMSKeyActions(policy, operatorPrincipals)
+	var roleARNs []string
+	for _, principal := range operatorPrincipals {
+		if _, ok := checks[principal.RoleARN]; ok {
+			roleARNs = append(roleARNs, principal.RoleARN)
+		}
+	}
+	if len(roleARNs) > 0 {
+		r.Reporter.Warnf("Once the operator roles are created, add the following roles to the policy of "+
+			"KMS key '%s':\n- %s", kmsKeyARN, strings.Join(roleARNs, "\n- "))
+	}
+}
+
+// printCostEstimate prints the estimated AWS infrastructure cost of the cluster. Failing to estimate the
+// cost, for example because the region isn't in the pricing table, doesn't fail the dry run.
+func printCostEstimate(r *rosa.Runtime, spec ocm.Spec) {
+	pricing, err := cost.LoadPricing("")
+	if err != nil {
+		r.Reporter.Debugf("Failed to load pricing table: %v", err)
+		return
+	}
+
+	computeNodes := spec.ComputeNodes
+	if spec.Autoscaling {
+		computeNodes = spec.MaxReplicas
+	}
+	clusterSpec := &cost.ClusterSpec{
+		Region:  spec.Region,
+		MultiAZ: spec.MultiAZ,
+		Private: spec.Private != nil && *spec.Private,
+		BYOVPC:  len(spec.SubnetIds) > 0,
+		MachinePools: []cost.MachinePool{
+			{
+				Name:          "compute",
+				InstanceType:  spec.ComputeMachineType,
+				Replicas:      computeNodes,
+				VolumeSizeGiB: ocm.DefaultComputeVolumeSizeGiB,
+			},
+		},
+	}
+	// The control plane and infra nodes of hosted clusters don't run in the customer account
+	if !spec.Hypershift.Enabled {
+		clusterSpec.MachinePools = append(clusterSpec.MachinePools,
+			cost.MachinePool{
+				Name:          "control-plane",
+				InstanceType:  ocm.DefaultControlPlaneMachineType,
+				Replicas:      ocm.ControlPlaneReplicas,
+				VolumeSizeGiB: ocm.DefaultControlPlaneVolumeSizeGiB,
+			},
+			cost.MachinePool{
+				Name:          "infra",
+				InstanceType:  ocm.DefaultInfraMachineType,
+				Replicas:      ocm.GetDefaultNodes(spec.MultiAZ),
+				VolumeSizeGiB: ocm.DefaultInfraVolumeSizeGiB,
+			},
+		)
+	}
+	if clusterSpec.MachinePools[0].InstanceType == "" {
+		clusterSpec.MachinePools[0].InstanceType = ocm.DefaultComputeMachineType
+	}
+	if clusterSpec.MachinePools[0].Replicas == 0 {
+		clusterSpec.MachinePools[0].Replicas = ocm.GetDefaultNodes(spec.MultiAZ)
+	}
+
+	estimate, err := pricing.Estimate(clusterSpec)
+	if err != nil {
+		r.Reporter.Warnf("Unable to estimate the cost of the cluster: %v", err)
+		return
+	}
+	r.Reporter.Infof("Estimated AWS infrastructure cost: %.2f %s/hour, %.2f %s/month. "+
+		"Run 'rosa estimate cluster' for details.",
+		estimate.Hourly, estimate.Currency, estimate.Monthly, estimate.Currency)
+}
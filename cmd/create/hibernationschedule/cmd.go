@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	hibernate string
+	resume    string
+	timezone  string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "hibernation-schedule",
+	Aliases: []string{"hibernationschedule"},
+	Short:   "Schedule the hibernation of a cluster",
+	Long: "Hibernate and resume a cluster on a recurring schedule. The schedule is stored in the cluster, " +
+		"and applied by 'rosa schedule run', which should run periodically, for example from a cron job.",
+	Example: `  # Hibernate the cluster "mycluster" on weekday nights and weekends
+  rosa create hibernation-schedule -c mycluster --hibernate "0 20 * * 1-5" --resume "0 7 * * 1-5" \
+  --timezone America/Chicago`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.hibernate,
+		"hibernate",
+		"",
+		"Cron expression that schedules the hibernation of the cluster, for example '0 20 * * 1-5'.",
+	)
+	flags.StringVar(
+		&args.resume,
+		"resume",
+		"",
+		"Cron expression that schedules the resumption of the cluster, for example '0 7 * * 1-5'.",
+	)
+	flags.StringVar(
+		&args.timezone,
+		"timezone",
+		"UTC",
+		"Time zone of the schedules, for example 'America/Chicago'.",
+	)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	enabled, err := r.OCMClient.IsCapabilityEnabled(ocm.HibernateCapability)
+	if err != nil {
+		r.Reporter.Errorf("Failed to check the capabilities of the organization: %v", err)
+		os.Exit(1)
+	}
+	if !enabled {
+		r.Reporter.Errorf("The '%s' capability is not set for current org", ocm.HibernateCapability)
+		os.Exit(1)
+	}
+
+	if args.hibernate == "" || args.resume == "" {
+		interactive.Enable()
+	}
+	hibernate := args.hibernate
+	resume := args.resume
+	timezone := args.timezone
+	if interactive.Enabled() {
+		hibernate = getSchedule(r, cmd, "hibernate", "Hibernate schedule", hibernate)
+		resume = getSchedule(r, cmd, "resume", "Resume schedule", resume)
+		timezone, err = interactive.GetString(interactive.Input{
+			Question: "Time zone",
+			Help:     cmd.Flags().Lookup("timezone").Usage,
+			Default:  timezone,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid time zone: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	schedule, err := hibernation.NewSchedule(hibernate, resume, timezone)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	action, nextRun := schedule.Next(time.Now())
+	if action == hibernation.ActionNone {
+		r.Reporter.Errorf("The schedules never run")
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Cluster '%s' will %s on %s", clusterKey, action, nextRun.Format("2006-01-02 15:04 MST"))
+
+	if !confirm.Confirm("schedule the hibernation of cluster '%s'", clusterKey) {
+		os.Exit(0)
+	}
+	err = r.OCMClient.UpdateClusterProperties(cluster.ID(), schedule.Properties)
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule the hibernation of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Scheduled the hibernation of cluster '%s'. Run 'rosa schedule run' periodically, "+
+		"for example every 5 minutes from a cron job, to hibernate and resume the cluster", clusterKey)
+}
+
+func getSchedule(r *rosa.Runtime, cmd *cobra.Command, flag string, question string, value string) string {
+	value, err := interactive.GetString(interactive.Input{
+		Question: question,
+		Help:     cmd.Flags().Lookup(flag).Usage,
+		Default:  value,
+		Required: true,
+		Validators: []interactive.Validator{
+			ocm.ValidateUpgradeSchedule,
+		},
+	})
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid %s schedule: %s", flag, err)
+		os.Exit(1)
+	}
+	return value
+}
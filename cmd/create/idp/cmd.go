@@ -78,10 +78,11 @@ var args struct {
 	// HTPasswd
 	htpasswdUsername string
 	htpasswdPassword string
+	htpasswdFile     string
 }
 
 var validIdps []string = []string{"github", "gitlab", "google", "htpasswd", "ldap", "openid"}
-var validMappingMethods []string = []string{"add", "claim", "generate", "lookup"}
+var ValidMappingMethods []string = []string{"add", "claim", "generate", "lookup"}
 
 var idRE = regexp.MustCompile(`(?i)^[0-9a-z]+([-_][0-9a-z]+)*$`)
 
@@ -127,7 +128,7 @@ func init() {
 		"claim",
 		fmt.Sprintf(
 			"Specifies how new identities are mapped to users when they log in. Options are %s",
-			validMappingMethods,
+			ValidMappingMethods,
 		),
 	)
 	flags.StringVar(
@@ -292,6 +293,13 @@ func init() {
 			"- Be at least 14 characters (ASCII-standard) without whitespaces\n"+
 			"- Include uppercase letters, lowercase letters, and numbers or symbols (ASCII-standard characters only)",
 	)
+	flags.StringVar(
+		&args.htpasswdFile,
+		"from-file",
+		"",
+		"HTPasswd: File with the users to log into the cluster's console with. Each line contains either a\n"+
+			"username and a bcrypt hash, as generated by 'htpasswd -B', or a CSV username and password.",
+	)
 
 	interactive.AddFlag(flags)
 }
@@ -476,19 +484,19 @@ func getMappingMethod(cmd *cobra.Command, mappingMethod string) (string, error)
 		mappingMethod, err = interactive.GetOption(interactive.Input{
 			Question: "Mapping method",
 			Help:     usage,
-			Options:  validMappingMethods,
+			Options:  ValidMappingMethods,
 			Default:  mappingMethod,
 			Required: true,
 		})
 	}
 	isValidMappingMethod := false
-	for _, validMappingMethod := range validMappingMethods {
+	for _, validMappingMethod := range ValidMappingMethods {
 		if mappingMethod == validMappingMethod {
 			isValidMappingMethod = true
 		}
 	}
 	if !isValidMappingMethod {
-		err = fmt.Errorf("Expected a valid mapping method. Options are %s", validMappingMethods)
+		err = fmt.Errorf("Expected a valid mapping method. Options are %s", ValidMappingMethods)
 	}
 	return mappingMethod, err
 }
@@ -48,14 +48,14 @@ func buildGitlabIdp(cmd *cobra.Command,
 			Required: true,
 			Validators: []interactive.Validator{
 				interactive.IsURL,
-				validateGitlabHostURL,
+				ValidateGitlabHostURL,
 			},
 		})
 		if err != nil {
 			return idpBuilder, fmt.Errorf("Expected a valid GitLab provider URL: %s", err)
 		}
 	}
-	err = validateGitlabHostURL(gitlabURL)
+	err = ValidateGitlabHostURL(gitlabURL)
 	if err != nil {
 		return idpBuilder, err
 	}
@@ -152,7 +152,7 @@ func buildGitlabIdp(cmd *cobra.Command,
 	return
 }
 
-func validateGitlabHostURL(val interface{}) error {
+func ValidateGitlabHostURL(val interface{}) error {
 	gitlabURL := fmt.Sprintf("%v", val)
 	parsedIssuerURL, err := url.ParseRequestURI(gitlabURL)
 	if err != nil {
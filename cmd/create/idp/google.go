@@ -102,7 +102,7 @@ func buildGoogleIdp(cmd *cobra.Command,
 			Default:  hostedDomain,
 			Required: mappingMethod != "lookup",
 			Validators: []interactive.Validator{
-				validateGoogleHostedDomain,
+				ValidateGoogleHostedDomain,
 			},
 		})
 		if err != nil {
@@ -111,7 +111,7 @@ func buildGoogleIdp(cmd *cobra.Command,
 	}
 
 	if hostedDomain != "" {
-		err = validateGoogleHostedDomain(hostedDomain)
+		err = ValidateGoogleHostedDomain(hostedDomain)
 		if err != nil {
 			return idpBuilder, err
 		}
@@ -129,7 +129,7 @@ func buildGoogleIdp(cmd *cobra.Command,
 	return
 }
 
-func validateGoogleHostedDomain(val interface{}) error {
+func ValidateGoogleHostedDomain(val interface{}) error {
 	hostedDomain := fmt.Sprintf("%v", val)
 	isValidHostedDomain := validator.IsValidDomain(hostedDomain)
 	if !isValidHostedDomain {
@@ -19,12 +19,11 @@ package idp
 import (
 	"fmt"
 	"os"
-	"regexp"
-	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/htpasswd"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -37,6 +36,11 @@ func createHTPasswdIDP(cmd *cobra.Command,
 	clusterKey string,
 	idpName string,
 	r *rosa.Runtime) {
+	if args.htpasswdFile != "" {
+		createHTPasswdIDPFromFile(cluster, clusterKey, idpName, r)
+		return
+	}
+
 	var err error
 	username := args.htpasswdUsername
 	password := args.htpasswdPassword
@@ -113,6 +117,70 @@ func createHTPasswdIDP(cmd *cobra.Command,
 	}
 }
 
+// createHTPasswdIDPFromFile creates the htpasswd identity provider with the users of a file, or adds them
+// to the identity provider that contains only the admin
+func createHTPasswdIDPFromFile(cluster *cmv1.Cluster, clusterKey string, idpName string, r *rosa.Runtime) {
+	if args.htpasswdUsername != "" || args.htpasswdPassword != "" {
+		r.Reporter.Errorf("Flag '--from-file' can't be used with '--username' or '--password'")
+		os.Exit(1)
+	}
+	users, err := htpasswd.ParseFile(args.htpasswdFile)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	for _, user := range users {
+		err = usernameValidator(user.Username)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+
+	htpasswdIDP, userList := FindExistingHTPasswdIDP(cluster, r)
+	if htpasswdIDP == nil {
+		r.Reporter.Infof("Configuring IDP for cluster '%s'", clusterKey)
+		_, err = r.OCMClient.CreateHTPasswdIDP(cluster.ID(), idpName, users)
+		if err != nil {
+			r.Reporter.Errorf("Failed to add IDP to cluster '%s': %s", clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof(
+			"Identity Provider '%s' has been created with %d users.\n"+
+				"   It may take several minutes for this access to become active.\n"+
+				"   To add cluster administrators, see 'rosa grant user --help'.\n"+
+				"   To login into the console, open %s and click on %s.",
+			idpName, len(users), cluster.Console().URL(), idpName,
+		)
+		return
+	}
+
+	containsAdminOnly := HasClusterAdmin(userList) && userList.Len() == 1
+	if !containsAdminOnly {
+		r.Reporter.Errorf(
+			"Cluster '%s' already has an HTPasswd IDP named '%s'. Clusters may only have 1 HTPasswd IDP. "+
+				"To replace its users, run 'rosa edit idp -c %s %s --sync %s'",
+			clusterKey, htpasswdIDP.Name(), clusterKey, htpasswdIDP.Name(), args.htpasswdFile)
+		os.Exit(1)
+	}
+	if htpasswdIDP.Htpasswd().Username() != "" {
+		r.Reporter.Errorf("Users can't be added to a single user HTPasswd IDP. Delete the IDP and recreate " +
+			"it as a multi user HTPasswd IDP")
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Cluster already has an HTPasswd IDP named '%s', new users will be added to it.",
+		htpasswdIDP.Name())
+	for _, user := range users {
+		err = r.OCMClient.AddHTPasswdUserSpec(cluster.ID(), htpasswdIDP.ID(), user)
+		if err != nil {
+			r.Reporter.Errorf(
+				"Failed to add user '%s' to the HTPasswd IDP of cluster '%s': %v", user.Username, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("User '%s' added", user.Username)
+	}
+}
+
 func getUserDetails(cmd *cobra.Command, r *rosa.Runtime) (string, string) {
 	username, err := interactive.GetString(interactive.Input{
 		Question: "Username",
@@ -176,33 +244,14 @@ func usernameValidator(val interface{}) error {
 		if username == ClusterAdminUsername {
 			return fmt.Errorf("username '%s' is not allowed", username)
 		}
-		if strings.ContainsAny(username, "/:%") {
-			return fmt.Errorf("invalid username '%s': "+
-				"username must not contain /, :, or %%", username)
-		}
-		return nil
+		return htpasswd.ValidateUsername(username)
 	}
 	return fmt.Errorf("can only validate strings, got '%v'", val)
 }
 
 func passwordValidator(val interface{}) error {
 	if password, ok := val.(string); ok {
-		notAsciiOnly, _ := regexp.MatchString(`[^\x20-\x7E]`, password)
-		containsSpace := strings.Contains(password, " ")
-		tooShort := len(password) < 14
-		if notAsciiOnly || containsSpace || tooShort {
-			return fmt.Errorf(
-				"password must be at least 14 characters (ASCII-standard) without whitespaces")
-		}
-		hasUppercase, _ := regexp.MatchString(`[A-Z]`, password)
-		hasLowercase, _ := regexp.MatchString(`[a-z]`, password)
-		hasNumberOrSymbol, _ := regexp.MatchString(`[^a-zA-Z]`, password)
-		if !hasUppercase || !hasLowercase || !hasNumberOrSymbol {
-			return fmt.Errorf(
-				"password must include uppercase letters, lowercase letters, and numbers " +
-					"or symbols (ASCII-standard characters only)")
-		}
-		return nil
+		return htpasswd.ValidatePassword(password)
 	}
 	return fmt.Errorf("can only validate strings, got '%v'", val)
 }
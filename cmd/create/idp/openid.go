@@ -95,7 +95,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 			Required: true,
 			Validators: []interactive.Validator{
 				interactive.IsURL,
-				validateOpenidIssuerURL,
+				ValidateOpenidIssuerURL,
 			},
 		})
 		if err != nil {
@@ -103,7 +103,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 		}
 	}
 
-	err = validateOpenidIssuerURL(issuerURL)
+	err = ValidateOpenidIssuerURL(issuerURL)
 	if err != nil {
 		return idpBuilder, err
 	}
@@ -236,7 +236,7 @@ separated by commas.`,
 	return
 }
 
-func validateOpenidIssuerURL(val interface{}) error {
+func ValidateOpenidIssuerURL(val interface{}) error {
 	issuerURL := fmt.Sprintf("%v", val)
 	parsedIssuerURL, err := url.ParseRequestURI(issuerURL)
 	if err != nil {
@@ -0,0 +1,297 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmskey
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	kmsKeyDescription = "Customer managed key for the EBS volumes and etcd data of ROSA clusters"
+	kmsKeyPolicyFile  = "kms_key_policy.json"
+)
+
+var args struct {
+	name                string
+	prefix              string
+	path                string
+	operatorRolesPrefix string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "kms-key",
+	Aliases: []string{"kmskey"},
+	Short:   "Create a KMS key for customer managed encryption",
+	Long: "Create a symmetric KMS key whose policy allows the account roles and the operator roles to " +
+		"encrypt the EBS volumes and the etcd data of clusters. Use the ARN of the key in the " +
+		"'--kms-key-arn' flag of 'rosa create cluster'.",
+	Example: `  # Create a KMS key for the default account roles
+  rosa create kms-key --name=rosa-key
+
+  # Create a KMS key that can also be used by the operator roles with prefix "mycluster"
+  rosa create kms-key --name=rosa-key --prefix=ManagedOpenShift --operator-roles-prefix=mycluster
+
+  # Print the key policy and the commands to create the key manually
+  rosa create kms-key --name=rosa-key --mode=manual`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the alias of the KMS key.",
+	)
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"Prefix of the account roles that use the KMS key.",
+	)
+	flags.StringVar(
+		&args.path,
+		"path",
+		"",
+		"The arn path of the account and operator roles that use the KMS key.",
+	)
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles that use the KMS key. When not set, only the account roles are allowed "+
+			"to use the key and the operator roles have to be added to the key policy once created.",
+	)
+
+	aws.AddModeFlag(Cmd)
+	confirm.AddFlag(flags)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	mode, err := aws.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if args.path != "" && !aws.ARNPath.MatchString(args.path) {
+		r.Reporter.Errorf("The specified value for path is invalid. " +
+			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
+		os.Exit(1)
+	}
+
+	if interactive.Enabled() || mode == "" {
+		mode, err = interactive.GetOption(interactive.Input{
+			Question: "KMS key creation mode",
+			Help:     cmd.Flags().Lookup("mode").Usage,
+			Default:  aws.ModeAuto,
+			Options:  aws.Modes,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid KMS key creation mode: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	accountPrincipals, operatorPrincipals, err := getPrincipals(r)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	switch mode {
+	case aws.ModeAuto:
+		principals := accountPrincipals
+		for _, principal := range accountPrincipals {
+			exists, err := roleExists(r, principal.RoleARN)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			if !exists {
+				r.Reporter.Errorf("Account role '%s' doesn't exist. To create the account roles, run "+
+					"'rosa create account-roles --prefix %s'", principal.RoleARN, args.prefix)
+				os.Exit(1)
+			}
+		}
+		// The key policy can only reference roles that exist
+		var pendingRoleARNs []string
+		for _, principal := range operatorPrincipals {
+			exists, err := roleExists(r, principal.RoleARN)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			if exists {
+				principals = append(principals, principal)
+			} else {
+				pendingRoleARNs = append(pendingRoleARNs, principal.RoleARN)
+			}
+		}
+
+		if !confirm.Prompt(true, "Create the KMS key in region '%s'?", r.AWSClient.GetRegion()) {
+			os.Exit(0)
+		}
+		policy := aws.GenerateKMSKeyPolicy(r.Creator.AccountID, principals)
+		keyARN, err := r.AWSClient.CreateKMSKey(args.name, kmsKeyDescription, policy.String(),
+			map[string]string{
+				tags.RedHatManaged: "true",
+			})
+		if err != nil {
+			r.Reporter.Errorf("Failed to create KMS key: %s", err)
+			r.OCMClient.LogEvent("ROSACreateKMSKeyModeAuto", map[string]string{
+				ocm.Response: ocm.Failure,
+			})
+			os.Exit(1)
+		}
+		r.OCMClient.LogEvent("ROSACreateKMSKeyModeAuto", map[string]string{
+			ocm.Response: ocm.Success,
+		})
+		r.Reporter.Infof("Created KMS key '%s'", keyARN)
+		if len(pendingRoleARNs) > 0 {
+			r.Reporter.Warnf("The following operator roles don't exist yet. Once created, add them to the "+
+				"key policy:\n- %s", helper.SliceToString(pendingRoleARNs))
+		}
+		r.Reporter.Infof("To create a cluster that uses this key, run the following command:\n"+
+			"rosa create cluster --sts --enable-customer-managed-key --kms-key-arn %s", keyARN)
+	case aws.ModeManual:
+		principals := append(accountPrincipals, operatorPrincipals...)
+		policy := aws.GenerateKMSKeyPolicy(r.Creator.AccountID, principals)
+		policyJSON, err := json.MarshalIndent(policy, "", "  ")
+		if err != nil {
+			r.Reporter.Errorf("Failed to generate the key policy: %s", err)
+			os.Exit(1)
+		}
+		err = helper.SaveDocument(string(policyJSON), kmsKeyPolicyFile)
+		if err != nil {
+			r.Reporter.Errorf("Failed to save the key policy: %s", err)
+			os.Exit(1)
+		}
+		r.OCMClient.LogEvent("ROSACreateKMSKeyModeManual", map[string]string{})
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Key policy saved to '%s' in the current directory", kmsKeyPolicyFile)
+			r.Reporter.Infof("Run the following commands to create the KMS key:\n")
+		}
+		fmt.Println(buildCommands(args.name))
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("To apply the policy to an existing key instead, run the following command:\n")
+		}
+		fmt.Println(awscb.NewKMSCommandBuilder().
+			SetCommand(awscb.PutKeyPolicy).
+			AddParam(awscb.KeyId, "<key-id>").
+			AddParam(awscb.PolicyName, aws.KMSKeyDefaultPolicyName).
+			AddParam(awscb.Policy, fmt.Sprintf("file://%s", kmsKeyPolicyFile)).
+			Build())
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", aws.Modes)
+		os.Exit(1)
+	}
+}
+
+// getPrincipals returns the account roles and the operator roles that use the key
+func getPrincipals(r *rosa.Runtime) ([]aws.KMSKeyPrincipal, []aws.KMSKeyPrincipal, error) {
+	var accountPrincipals []aws.KMSKeyPrincipal
+	for _, roleType := range []string{aws.InstallerAccountRole, aws.ControlPlaneAccountRole, aws.WorkerAccountRole} {
+		role := aws.AccountRoles[roleType]
+		accountPrincipals = append(accountPrincipals, aws.KMSKeyPrincipal{
+			Name:    role.Name,
+			RoleARN: aws.GetRoleARN(r.Creator.AccountID, aws.GetRoleName(args.prefix, role.Name), args.path),
+		})
+	}
+
+	var operatorPrincipals []aws.KMSKeyPrincipal
+	if args.operatorRolesPrefix == "" {
+		return accountPrincipals, operatorPrincipals, nil
+	}
+	credRequests, err := r.OCMClient.GetCredRequests(false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error getting operator credential request from OCM %s", err)
+	}
+	// Sort the operators to generate the same policy every time
+	var names []string
+	for name := range credRequests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		operator := credRequests[name]
+		if !helper.Contains(aws.KMSKeyOperatorNamespaces, operator.Namespace()) {
+			continue
+		}
+		roleName := fmt.Sprintf("%s-%s-%s", args.operatorRolesPrefix, operator.Namespace(), operator.Name())
+		if len(roleName) > 64 {
+			roleName = roleName[0:64]
+		}
+		operatorPrincipals = append(operatorPrincipals, aws.KMSKeyPrincipal{
+			Name:    operator.Name(),
+			RoleARN: aws.GetRoleARN(r.Creator.AccountID, roleName, args.path),
+		})
+	}
+	return accountPrincipals, operatorPrincipals, nil
+}
+
+func roleExists(r *rosa.Runtime, roleARN string) (bool, error) {
+	roleName, err := aws.GetResourceIdFromARN(roleARN)
+	if err != nil {
+		return false, err
+	}
+	exists, _, err := r.AWSClient.CheckRoleExists(roleName)
+	if err != nil {
+		return false, fmt.Errorf("Failed to check if role '%s' exists: %v", roleARN, err)
+	}
+	return exists, nil
+}
+
+func buildCommands(name string) string {
+	commands := []string{
+		awscb.NewKMSCommandBuilder().
+			SetCommand(awscb.CreateKey).
+			AddParam(awscb.Description, fmt.Sprintf("\"%s\"", kmsKeyDescription)).
+			AddParam(awscb.KeyUsage, "ENCRYPT_DECRYPT").
+			AddParam(awscb.Policy, fmt.Sprintf("file://%s", kmsKeyPolicyFile)).
+			Build(),
+	}
+	if name != "" {
+		commands = append(commands, awscb.NewKMSCommandBuilder().
+			SetCommand(awscb.CreateAlias).
+			AddParam(awscb.AliasName, aws.GetKMSKeyAlias(name)).
+			AddParam(awscb.TargetKeyId, "<key-id>").
+			Build())
+	}
+	return awscb.JoinCommands(commands)
+}
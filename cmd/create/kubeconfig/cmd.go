@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/kubeconfig"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// loginTimeout is how long to wait for the user to log in with the browser
+const loginTimeout = 5 * time.Minute
+
+var args struct {
+	admin      bool
+	idp        string
+	username   string
+	password   string
+	kubeconfig string
+	context    string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "kubeconfig",
+	Short: "Create a kubeconfig context to access a cluster",
+	Long: "Log in to a cluster and add a context with the obtained token to the kubeconfig file. " +
+		"Users of htpasswd and LDAP identity providers log in with their password, and users of other " +
+		"identity providers log in with the browser.",
+	Example: `  # Add a context for the admin user of the cluster named "mycluster"
+  rosa create kubeconfig -c mycluster --admin
+
+  # Add a context for a user of the identity provider named "github"
+  rosa create kubeconfig -c mycluster --idp github`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.BoolVar(
+		&args.admin,
+		"admin",
+		false,
+		"Log in as the admin user created with 'rosa create admin'.",
+	)
+	flags.StringVar(
+		&args.idp,
+		"idp",
+		"",
+		"Name of the identity provider to log in with.",
+	)
+	flags.StringVar(
+		&args.username,
+		"username",
+		"",
+		"Username for htpasswd and LDAP identity providers.",
+	)
+	flags.StringVar(
+		&args.password,
+		"password",
+		"",
+		"Password for htpasswd and LDAP identity providers. Prompted for when not set.",
+	)
+	flags.StringVar(
+		&args.kubeconfig,
+		"kubeconfig",
+		"",
+		"Kubeconfig file to write. Defaults to the first file of KUBECONFIG, or '~/.kube/config'.",
+	)
+	flags.StringVar(
+		&args.context,
+		"context",
+		"",
+		"Name of the context. Defaults to the name of the cluster.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	if args.admin == (args.idp != "") {
+		r.Reporter.Errorf("Expected exactly one of '--admin' or '--idp'")
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	// Find the identity provider and whether users log in with a password
+	var idpName string
+	passwordLogin := true
+	username := args.username
+	if args.admin {
+		htpasswdIDP, userList := idp.FindExistingHTPasswdIDP(cluster, r)
+		if !idp.HasClusterAdmin(userList) {
+			r.Reporter.Errorf("Cluster '%s' doesn't have an admin. To create it, run 'rosa create admin -c %s'",
+				clusterKey, clusterKey)
+			os.Exit(1)
+		}
+		idpName = htpasswdIDP.Name()
+		username = idp.ClusterAdminUsername
+	} else {
+		idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		var found *cmv1.IdentityProvider
+		for _, item := range idps {
+			if item.Name() == args.idp {
+				found = item
+			}
+		}
+		if found == nil {
+			r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", args.idp, clusterKey)
+			os.Exit(1)
+		}
+		idpName = found.Name()
+		idpType := ocm.IdentityProviderType(found)
+		passwordLogin = idpType == ocm.HTPasswdIDPType || idpType == ocm.LDAPIDPType
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	r.Reporter.Debugf("Discovering the OAuth server of cluster '%s'", clusterKey)
+	metadata, err := kubeconfig.Discover(client, cluster.API().URL())
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	var token string
+	if passwordLogin {
+		if username == "" {
+			username, err = interactive.GetString(interactive.Input{
+				Question: "Username",
+				Required: true,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid username: %v", err)
+				os.Exit(1)
+			}
+		}
+		password := args.password
+		if password == "" {
+			password, err = interactive.GetPassword(interactive.Input{
+				Question: "Password",
+				Required: true,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid password: %v", err)
+				os.Exit(1)
+			}
+		}
+		token, err = kubeconfig.RequestTokenWithPassword(client, metadata, idpName, username, password)
+	} else {
+		token, err = kubeconfig.RequestTokenWithBrowser(client, metadata, idpName, openBrowser(r), loginTimeout)
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to log in to cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	path := args.kubeconfig
+	if path == "" {
+		path, err = kubeconfig.Path()
+		if err != nil {
+			r.Reporter.Errorf("Failed to find the kubeconfig file: %v", err)
+			os.Exit(1)
+		}
+	}
+	context := args.context
+	if context == "" {
+		context = cluster.Name()
+	}
+	err = kubeconfig.Merge(path, &kubeconfig.Entry{
+		Name:   context,
+		Server: cluster.API().URL(),
+		Token:  token,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to write kubeconfig file '%s': %v", path, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Added context '%s' to '%s' and made it the current context", context, path)
+}
+
+// openBrowser returns a function that opens a URL in the browser. The URL is also printed, in case the
+// browser can't be opened.
+func openBrowser(r *rosa.Runtime) func(string) error {
+	return func(url string) error {
+		r.Reporter.Infof("Log in with the browser. If it doesn't open, visit:\n\n   %s\n", url)
+		var command *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			command = exec.Command("open", url)
+		case "windows":
+			command = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			command = exec.Command("xdg-open", url)
+		}
+		err := command.Start()
+		if err != nil {
+			r.Reporter.Debugf("Failed to open the browser: %v", err)
+		}
+		return nil
+	}
+}
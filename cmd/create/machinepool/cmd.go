@@ -47,6 +47,8 @@ var args struct {
 	multiAvailabilityZone bool
 	availabilityZone      string
 	subnet                string
+	subnetSelector        string
+	localZone             string
 }
 
 var Cmd = &cobra.Command{
@@ -69,7 +71,15 @@ var Cmd = &cobra.Command{
 
   # Add a machine pool with spot instances to a cluster
   rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --use-spot-instances \
-    --spot-max-price=0.5`,
+    --spot-max-price=0.5
+
+  # Add a machine pool with spot instances and a max price picked from the recent spot price history
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --use-spot-instances \
+    --spot-max-price=auto
+
+  # Add a machine pool in an AWS Local Zone to a BYOVPC cluster
+  rosa create machinepool -c mycluster --name=edge-1 --replicas=1 --instance-type=c5d.2xlarge \
+    --local-zone=us-east-1-nyc-1a`,
 	Run: run,
 }
 
@@ -147,7 +157,8 @@ func init() {
 		&args.spotMaxPrice,
 		"spot-max-price",
 		"on-demand",
-		"Max price for spot instance. If empty use the on-demand price.",
+		"Max price for spot instance. If empty use the on-demand price. Use 'auto' to pick the 90th "+
+			"percentile of the spot price history of the last 7 days in the availability zones of the machine pool.",
 	)
 
 	flags.BoolVar(
@@ -168,6 +179,21 @@ func init() {
 		"",
 		"Select subnet to create a single AZ machine pool for BYOVPC cluster")
 
+	flags.StringVar(
+		&args.subnetSelector,
+		"subnet-selector",
+		"",
+		"Filter the subnets offered when selecting a subnet interactively by their tags. "+
+			"Format should be a comma-separated list of 'key=value', where the value may contain '*' "+
+			"and '?' wildcards, for example 'Name=prod-*'.")
+
+	flags.StringVar(
+		&args.localZone,
+		"local-zone",
+		"",
+		"Select an AWS Local Zone or Wavelength Zone to create an edge machine pool for a BYOVPC cluster. "+
+			"The zone must be opted in and have a subnet in the cluster VPC.")
+
 	interactive.AddFlag(flags)
 }
 
@@ -0,0 +1,147 @@
+package machinepool
+
+import (
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	edgeNodeRoleKey = "node-role.kubernetes.io/edge"
+	zoneTypeLabel   = "machine.openshift.io/zone-type"
+	zoneGroupLabel  = "machine.openshift.io/zone-group"
+)
+
+// localZone contains the details of the Local Zone or Wavelength Zone a machine pool is placed in
+type localZone struct {
+	Name      string
+	ZoneType  string
+	GroupName string
+	Subnet    string
+}
+
+// getLocalZone validates that the given zone is an opted-in Local Zone or Wavelength Zone and discovers
+// the subnet of the cluster VPC that belongs to it. When the zone contains several subnets, private
+// subnets are preferred and the user must disambiguate by providing the subnet.
+func getLocalZone(r *rosa.Runtime, cluster *cmv1.Cluster, zoneName string, subnetID string) (*localZone, error) {
+	availabilityZones, err := r.AWSClient.GetAvailabilityZones([]string{zoneName})
+	if err != nil {
+		return nil, err
+	}
+	if len(availabilityZones) < 1 {
+		return nil, fmt.Errorf("Failed to find zone '%s' in region '%s'", zoneName, cluster.Region().ID())
+	}
+	zone := availabilityZones[0]
+
+	zoneType := awssdk.StringValue(zone.ZoneType)
+	if zoneType != aws.LocalZoneType && zoneType != aws.WavelengthZoneType {
+		return nil, fmt.Errorf("Zone '%s' is of type '%s', expected a Local Zone or a Wavelength Zone",
+			zoneName, zoneType)
+	}
+	if awssdk.StringValue(zone.OptInStatus) == ec2.AvailabilityZoneOptInStatusNotOptedIn {
+		return nil, fmt.Errorf("The account is not opted in to zone group '%s'. To opt in, run "+
+			"'aws ec2 modify-availability-zone-group --group-name %s --opt-in-status opted-in'",
+			awssdk.StringValue(zone.GroupName), awssdk.StringValue(zone.GroupName))
+	}
+
+	// Find the subnets of the cluster VPC in the zone
+	vpcSubnets, err := r.AWSClient.GetVPCSubnets(cluster.AWS().SubnetIDs()[0])
+	if err != nil {
+		return nil, err
+	}
+	var zoneSubnets []*ec2.Subnet
+	for _, subnet := range vpcSubnets {
+		if awssdk.StringValue(subnet.AvailabilityZone) == zoneName {
+			zoneSubnets = append(zoneSubnets, subnet)
+		}
+	}
+	if len(zoneSubnets) < 1 {
+		return nil, fmt.Errorf("Failed to find a subnet in zone '%s' in the VPC of cluster '%s'",
+			zoneName, cluster.Name())
+	}
+
+	subnetDetails, err := r.AWSClient.GetSubnetDetails(zoneSubnets)
+	if err != nil {
+		return nil, err
+	}
+	var subnetIDs []string
+	var privateSubnetIDs []string
+	for _, detail := range subnetDetails {
+		subnetIDs = append(subnetIDs, detail.SubnetID)
+		if !detail.Public {
+			privateSubnetIDs = append(privateSubnetIDs, detail.SubnetID)
+		}
+	}
+
+	switch {
+	case subnetID != "":
+		if !helper.Contains(subnetIDs, subnetID) {
+			return nil, fmt.Errorf("Subnet '%s' doesn't belong to zone '%s' in the VPC of cluster '%s'",
+				subnetID, zoneName, cluster.Name())
+		}
+	case len(privateSubnetIDs) == 1:
+		subnetID = privateSubnetIDs[0]
+	case len(privateSubnetIDs) == 0 && len(subnetIDs) == 1:
+		subnetID = subnetIDs[0]
+	default:
+		return nil, fmt.Errorf("Found several subnets in zone '%s': %s. Use the `subnet` flag to select one",
+			zoneName, helper.SliceToString(subnetIDs))
+	}
+
+	return &localZone{
+		Name:      zoneName,
+		ZoneType:  zoneType,
+		GroupName: awssdk.StringValue(zone.GroupName),
+		Subnet:    subnetID,
+	}, nil
+}
+
+// filterLocalZoneInstanceTypes keeps the instance types that are offered in the Local Zone or Wavelength Zone
+func filterLocalZoneInstanceTypes(r *rosa.Runtime, zone *localZone,
+	instanceTypeList ocm.MachineTypeList) (ocm.MachineTypeList, error) {
+	offeredInstanceTypes, err := r.AWSClient.GetInstanceTypesInAvailabilityZone(zone.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the instance types offered in zone '%s': %s", zone.Name, err)
+	}
+	instanceTypeList = instanceTypeList.Filter(func(machineType *ocm.MachineType) bool {
+		return helper.Contains(offeredInstanceTypes, machineType.MachineType.ID())
+	})
+	if len(instanceTypeList) == 0 {
+		return nil, fmt.Errorf("None of the supported instance types is offered in zone '%s'", zone.Name)
+	}
+	return instanceTypeList, nil
+}
+
+// addLocalZoneLabels adds the labels that identify edge nodes unless the user already set them
+func addLocalZoneLabels(labelMap map[string]string, zone *localZone) map[string]string {
+	edgeLabels := map[string]string{
+		edgeNodeRoleKey: "",
+		zoneTypeLabel:   zone.ZoneType,
+		zoneGroupLabel:  zone.GroupName,
+	}
+	for key, value := range edgeLabels {
+		if _, ok := labelMap[key]; !ok {
+			labelMap[key] = value
+		}
+	}
+	return labelMap
+}
+
+// addLocalZoneTaints keeps regular workloads off the edge nodes unless the user already set the taint
+func addLocalZoneTaints(taintBuilders []*cmv1.TaintBuilder, taints string) []*cmv1.TaintBuilder {
+	if taints != "" {
+		for _, taint := range strings.Split(taints, ",") {
+			if strings.FieldsFunc(taint, Split)[0] == edgeNodeRoleKey {
+				return taintBuilders
+			}
+		}
+	}
+	return append(taintBuilders, cmv1.NewTaint().Key(edgeNodeRoleKey).Value("").Effect("NoSchedule"))
+}
@@ -0,0 +1,251 @@
+package machinepool
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	zoneName  = "us-east-1-nyc-1a"
+	zoneGroup = "us-east-1-nyc-1"
+)
+
+func newSubnet(id string, zone string) *ec2.Subnet {
+	return &ec2.Subnet{
+		SubnetId:         awssdk.String(id),
+		VpcId:            awssdk.String("vpc-1"),
+		AvailabilityZone: awssdk.String(zone),
+	}
+}
+
+var _ = Describe("Local zones", func() {
+	var (
+		r          *rosa.Runtime
+		cluster    *cmv1.Cluster
+		mockCtrl   *gomock.Controller
+		mockEC2API *mocks.MockEC2API
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockEC2API = mocks.NewMockEC2API(mockCtrl)
+		r = &rosa.Runtime{
+			AWSClient: aws.New(
+				logrus.New(),
+				mocks.NewMockIAMAPI(mockCtrl),
+				mockEC2API,
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mocks.NewMockServiceQuotasAPI(mockCtrl),
+				mocks.NewMockKMSAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			),
+		}
+		var err error
+		cluster, err = cmv1.NewCluster().
+			Name("mycluster").
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			AWS(cmv1.NewAWS().SubnetIDs("subnet-a")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("getLocalZone", func() {
+		expectZone := func(zoneType string, optInStatus string) {
+			mockEC2API.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []*ec2.AvailabilityZone{
+					{
+						ZoneName:    awssdk.String(zoneName),
+						ZoneType:    awssdk.String(zoneType),
+						GroupName:   awssdk.String(zoneGroup),
+						OptInStatus: awssdk.String(optInStatus),
+					},
+				},
+			}, nil)
+		}
+
+		// expectSubnets returns the subnets of the cluster VPC. The subnets in the local zone whose ID
+		// contains 'public' are routed through an internet gateway.
+		expectSubnets := func(subnets ...*ec2.Subnet) {
+			mockEC2API.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{newSubnet("subnet-a", "us-east-1a")},
+			}, nil)
+			mockEC2API.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: append([]*ec2.Subnet{newSubnet("subnet-a", "us-east-1a")}, subnets...),
+			}, nil)
+			var publicAssociations []*ec2.RouteTableAssociation
+			for _, subnet := range subnets {
+				if awssdk.StringValue(subnet.SubnetId) == "subnet-public" {
+					publicAssociations = append(publicAssociations,
+						&ec2.RouteTableAssociation{SubnetId: subnet.SubnetId})
+				}
+			}
+			mockEC2API.EXPECT().DescribeRouteTables(gomock.Any()).Return(&ec2.DescribeRouteTablesOutput{
+				RouteTables: []*ec2.RouteTable{
+					{
+						Associations: publicAssociations,
+						Routes:       []*ec2.Route{{GatewayId: awssdk.String("igw-1")}},
+					},
+					{
+						Associations: []*ec2.RouteTableAssociation{{Main: awssdk.Bool(true)}},
+						Routes:       []*ec2.Route{{NatGatewayId: awssdk.String("nat-1")}},
+					},
+				},
+			}, nil)
+			mockEC2API.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []*ec2.AvailabilityZone{
+					{ZoneName: awssdk.String(zoneName), ZoneType: awssdk.String(aws.LocalZoneType)},
+				},
+			}, nil)
+		}
+
+		It("Prefers the private subnet of the zone", func() {
+			expectZone(aws.LocalZoneType, ec2.AvailabilityZoneOptInStatusOptedIn)
+			expectSubnets(newSubnet("subnet-public", zoneName), newSubnet("subnet-private", zoneName))
+			zone, err := getLocalZone(r, cluster, zoneName, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*zone).To(Equal(localZone{
+				Name:      zoneName,
+				ZoneType:  aws.LocalZoneType,
+				GroupName: zoneGroup,
+				Subnet:    "subnet-private",
+			}))
+		})
+
+		It("Uses the given subnet", func() {
+			expectZone(aws.LocalZoneType, ec2.AvailabilityZoneOptInStatusOptedIn)
+			expectSubnets(newSubnet("subnet-public", zoneName), newSubnet("subnet-private", zoneName))
+			zone, err := getLocalZone(r, cluster, zoneName, "subnet-public")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone.Subnet).To(Equal("subnet-public"))
+		})
+
+		It("Fails when the given subnet is in another zone", func() {
+			expectZone(aws.LocalZoneType, ec2.AvailabilityZoneOptInStatusOptedIn)
+			expectSubnets(newSubnet("subnet-private", zoneName))
+			_, err := getLocalZone(r, cluster, zoneName, "subnet-a")
+			Expect(err).To(MatchError(ContainSubstring("Subnet 'subnet-a' doesn't belong to zone")))
+		})
+
+		It("Asks to select one of several private subnets", func() {
+			expectZone(aws.WavelengthZoneType, ec2.AvailabilityZoneOptInStatusOptedIn)
+			expectSubnets(newSubnet("subnet-private", zoneName), newSubnet("subnet-other", zoneName))
+			_, err := getLocalZone(r, cluster, zoneName, "")
+			Expect(err).To(MatchError(ContainSubstring("Found several subnets in zone")))
+		})
+
+		It("Fails when the VPC has no subnet in the zone", func() {
+			expectZone(aws.LocalZoneType, ec2.AvailabilityZoneOptInStatusOptedIn)
+			mockEC2API.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{newSubnet("subnet-a", "us-east-1a")},
+			}, nil).Times(2)
+			_, err := getLocalZone(r, cluster, zoneName, "")
+			Expect(err).To(MatchError(ContainSubstring("Failed to find a subnet in zone")))
+		})
+
+		It("Rejects regular availability zones", func() {
+			expectZone(aws.AvailabilityZoneType, ec2.AvailabilityZoneOptInStatusOptInNotRequired)
+			_, err := getLocalZone(r, cluster, zoneName, "")
+			Expect(err).To(MatchError(ContainSubstring("expected a Local Zone or a Wavelength Zone")))
+		})
+
+		It("Rejects zones the account isn't opted in to", func() {
+			expectZone(aws.LocalZoneType, ec2.AvailabilityZoneOptInStatusNotOptedIn)
+			_, err := getLocalZone(r, cluster, zoneName, "")
+			Expect(err).To(MatchError(ContainSubstring("not opted in to zone group '" + zoneGroup + "'")))
+		})
+	})
+
+	Context("filterLocalZoneInstanceTypes", func() {
+		zone := &localZone{Name: zoneName}
+		newMachineTypeList := func(ids ...string) ocm.MachineTypeList {
+			var list ocm.MachineTypeList
+			for _, id := range ids {
+				machineType, err := cmv1.NewMachineType().ID(id).Build()
+				Expect(err).NotTo(HaveOccurred())
+				list = append(list, &ocm.MachineType{MachineType: machineType, Available: true})
+			}
+			return list
+		}
+		expectOfferings := func(instanceTypes ...string) {
+			mockEC2API.EXPECT().DescribeInstanceTypeOfferingsPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *ec2.DescribeInstanceTypeOfferingsInput,
+					fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool) error {
+					page := &ec2.DescribeInstanceTypeOfferingsOutput{}
+					for _, instanceType := range instanceTypes {
+						page.InstanceTypeOfferings = append(page.InstanceTypeOfferings,
+							&ec2.InstanceTypeOffering{InstanceType: awssdk.String(instanceType)})
+					}
+					fn(page, true)
+					return nil
+				})
+		}
+
+		It("Keeps the instance types offered in the zone", func() {
+			expectOfferings("m5.xlarge", "r5.2xlarge")
+			list, err := filterLocalZoneInstanceTypes(r, zone, newMachineTypeList("m5.xlarge", "m6i.xlarge"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.IDs()).To(Equal([]string{"m5.xlarge"}))
+		})
+
+		It("Fails when none of the instance types is offered", func() {
+			expectOfferings("r5.2xlarge")
+			_, err := filterLocalZoneInstanceTypes(r, zone, newMachineTypeList("m5.xlarge"))
+			Expect(err).To(MatchError("None of the supported instance types is offered in zone '" + zoneName + "'"))
+		})
+	})
+
+	Context("addLocalZoneLabels", func() {
+		zone := &localZone{Name: zoneName, ZoneType: aws.LocalZoneType, GroupName: zoneGroup}
+
+		It("Adds the edge labels", func() {
+			labels := addLocalZoneLabels(map[string]string{"app": "edge"}, zone)
+			Expect(labels).To(Equal(map[string]string{
+				"app":           "edge",
+				edgeNodeRoleKey: "",
+				zoneTypeLabel:   aws.LocalZoneType,
+				zoneGroupLabel:  zoneGroup,
+			}))
+		})
+
+		It("Keeps the labels set by the user", func() {
+			labels := addLocalZoneLabels(map[string]string{zoneGroupLabel: "custom"}, zone)
+			Expect(labels).To(HaveKeyWithValue(zoneGroupLabel, "custom"))
+			Expect(labels).To(HaveKeyWithValue(zoneTypeLabel, aws.LocalZoneType))
+		})
+	})
+
+	Context("addLocalZoneTaints", func() {
+		It("Adds the edge taint", func() {
+			taints := addLocalZoneTaints(nil, "")
+			Expect(taints).To(HaveLen(1))
+			taint, err := taints[0].Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taint.Key()).To(Equal(edgeNodeRoleKey))
+			Expect(taint.Effect()).To(Equal("NoSchedule"))
+		})
+
+		It("Keeps the edge taint set by the user", func() {
+			userTaint := cmv1.NewTaint().Key(edgeNodeRoleKey).Value("").Effect("NoExecute")
+			taints := addLocalZoneTaints([]*cmv1.TaintBuilder{userTaint}, edgeNodeRoleKey+"=:NoExecute")
+			Expect(taints).To(Equal([]*cmv1.TaintBuilder{userTaint}))
+		})
+	})
+})
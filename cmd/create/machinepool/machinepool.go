@@ -18,6 +18,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// Number of days of spot price history used to pick the spot max price automatically
+const spotPriceHistoryDays = 7
+
 var labelRE = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
 
 func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r *rosa.Runtime) {
@@ -42,6 +45,11 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
+	if !isBYOVPC(cluster) && cmd.Flags().Changed("subnet-selector") {
+		r.Reporter.Errorf("Setting the `subnet-selector` flag is only allowed for BYOVPC clusters")
+		os.Exit(1)
+	}
+
 	if isSubnetSet && isAvailabilityZoneSet {
 		r.Reporter.Errorf("Setting both `subnet` and `availability-zone` flag is not supported." +
 			" Please select `subnet` or `availability-zone` to create a single availability zone machine pool")
@@ -59,6 +67,21 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
+	// Validate flags that are only allowed for Local Zone and Wavelength Zone machine pools
+	isLocalZoneSet := cmd.Flags().Changed("local-zone")
+	if isLocalZoneSet && !isBYOVPC(cluster) {
+		r.Reporter.Errorf("Setting the `local-zone` flag is only allowed for BYOVPC clusters")
+		os.Exit(1)
+	}
+	if isLocalZoneSet && isAvailabilityZoneSet {
+		r.Reporter.Errorf("Setting both `local-zone` and `availability-zone` flag is not supported")
+		os.Exit(1)
+	}
+	if isLocalZoneSet && isMultiAvailabilityZoneSet && args.multiAvailabilityZone {
+		r.Reporter.Errorf("Setting the `local-zone` flag is only supported for creating a single AZ machine pool")
+		os.Exit(1)
+	}
+
 	// Machine pool name:
 	name := strings.Trim(args.name, " \t")
 	if name == "" && !interactive.Enabled() {
@@ -85,6 +108,18 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
+	// Discover the subnet of the Local Zone or Wavelength Zone in the cluster VPC
+	var zone *localZone
+	if isLocalZoneSet {
+		zone, err = getLocalZone(r, cluster, args.localZone, args.subnet)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		args.subnet = zone.Subnet
+		isSubnetSet = true
+	}
+
 	// Allow the user to select subnet for a single AZ BYOVPC cluster
 	var subnet string
 	if !cluster.MultiAZ() && isBYOVPC(cluster) {
@@ -275,6 +310,15 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
+	// Only offer the instance types available in the Local Zone or Wavelength Zone
+	if zone != nil {
+		instanceTypeList, err = filterLocalZoneInstanceTypes(r, zone, instanceTypeList)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
 	if spin != nil {
 		spin.Stop()
 	}
@@ -325,6 +369,9 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
+	if zone != nil {
+		labelMap = addLocalZoneLabels(labelMap, zone)
+	}
 
 	taints := args.taints
 	if interactive.Enabled() {
@@ -346,6 +393,9 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
+	if zone != nil {
+		taintBuilders = addLocalZoneTaints(taintBuilders, taints)
+	}
 
 	// Spot instances
 	isSpotSet := cmd.Flags().Changed("use-spot-instances")
@@ -359,8 +409,8 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	}
 
 	// Validate spot instance are supported
-	var isLocalZone bool
-	if subnet != "" {
+	isLocalZone := zone != nil
+	if subnet != "" && !isLocalZone {
 		isLocalZone, err = r.AWSClient.IsLocalAvailabilityZone(availabilityZonesFilter[0])
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
@@ -368,7 +418,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		}
 	}
 	if isLocalZone && useSpotInstances {
-		r.Reporter.Errorf("Spot instances are not supported for local zones")
+		r.Reporter.Errorf("Spot instances are not supported for local zones and wavelength zones")
 		os.Exit(1)
 	}
 
@@ -408,7 +458,9 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
-	if spotMaxPrice != "on-demand" {
+	if spotMaxPrice == "auto" && useSpotInstances {
+		maxPrice = getRecommendedSpotMaxPrice(r, cluster, availabilityZonesFilter, instanceType)
+	} else if spotMaxPrice != "on-demand" && spotMaxPrice != "auto" {
 		price, _ := strconv.ParseFloat(spotMaxPrice, 64)
 		maxPrice = &price
 	}
@@ -521,9 +573,30 @@ func maxReplicaValidator(minReplicas int, multiAZMachinePool bool) interactive.V
 	}
 }
 
+// getRecommendedSpotMaxPrice picks the spot max price from the recent price history of the instance type
+// in the availability zones of the machine pool. The on-demand price is used when the history isn't
+// available or when the spot price reaches the on-demand price.
+func getRecommendedSpotMaxPrice(r *rosa.Runtime, cluster *cmv1.Cluster, availabilityZones []string,
+	instanceType string) *float64 {
+	summaries, err := r.AWSClient.GetSpotPriceSummaries(cluster.Region().ID(), availabilityZones,
+		instanceType, spotPriceHistoryDays)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get spot prices of instance type '%s': %v", instanceType, err)
+		os.Exit(1)
+	}
+	price, ok := aws.RecommendSpotMaxPrice(summaries)
+	if !ok {
+		r.Reporter.Infof("Using the on-demand price as spot max price for instance type '%s'", instanceType)
+		return nil
+	}
+	r.Reporter.Infof("Using spot max price %.4f for instance type '%s', the p%d of the last %d days",
+		price, instanceType, aws.SpotMaxPricePercentile, spotPriceHistoryDays)
+	return &price
+}
+
 func spotMaxPriceValidator(val interface{}) error {
 	spotMaxPrice := fmt.Sprintf("%v", val)
-	if spotMaxPrice == "on-demand" {
+	if spotMaxPrice == "on-demand" || spotMaxPrice == "auto" {
 		return nil
 	}
 	price, err := strconv.ParseFloat(spotMaxPrice, 64)
@@ -621,12 +694,14 @@ func getSubnetFromUser(cmd *cobra.Command, r *rosa.Runtime, isSubnetSet bool,
 	}
 
 	if selectSubnet {
-		subnetOptions, err := getSubnetOptions(r, cluster)
+		header, subnetOptions, err := getSubnetOptions(r, cluster)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
 		}
 
+		// Align the header with the options of the selection prompt
+		fmt.Printf("  %s\n", header)
 		subnetOption, err := interactive.GetOption(interactive.Input{
 			Question: "Subnet ID",
 			Help:     cmd.Flags().Lookup("subnet").Usage,
@@ -644,19 +719,30 @@ func getSubnetFromUser(cmd *cobra.Command, r *rosa.Runtime, isSubnetSet bool,
 	return subnet
 }
 
-// getSubnetOptions gets one of the cluster subnets and returns a slice of formatted VPC's private subnets.
-func getSubnetOptions(r *rosa.Runtime, cluster *cmv1.Cluster) ([]string, error) {
+// getSubnetOptions gets one of the cluster subnets and returns the header and the formatted rows of a table
+// describing the VPC's private subnets that match the subnet selector.
+func getSubnetOptions(r *rosa.Runtime, cluster *cmv1.Cluster) (string, []string, error) {
+	subnetSelectors, err := aws.ParseSubnetSelector(args.subnetSelector)
+	if err != nil {
+		return "", nil, err
+	}
+
 	// Fetch VPC's subnets
 	privateSubnets, err := r.AWSClient.GetVPCPrivateSubnets(cluster.AWS().SubnetIDs()[0])
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+	privateSubnets = aws.FilterSubnetsBySelector(privateSubnets, subnetSelectors)
+	if len(privateSubnets) == 0 {
+		return "", nil, fmt.Errorf("Failed to find private subnets matching selector '%s'", args.subnetSelector)
 	}
 
 	// Format subnet options
-	var subnetOptions []string
-	for _, subnet := range privateSubnets {
-		subnetOptions = append(subnetOptions, aws.SetSubnetOption(*subnet.SubnetId, *subnet.AvailabilityZone))
+	subnetDetails, err := r.AWSClient.GetSubnetDetails(privateSubnets)
+	if err != nil {
+		return "", nil, err
 	}
+	header, subnetOptions := aws.SetSubnetDetailOptions(subnetDetails)
 
-	return subnetOptions, nil
+	return header, subnetOptions, nil
 }
@@ -28,6 +28,16 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		os.Exit(1)
 	}
 
+	if cmd.Flags().Changed("subnet-selector") {
+		r.Reporter.Errorf("Setting the `subnet-selector` flag is not yet supported for hosted clusters")
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed("local-zone") {
+		r.Reporter.Errorf("Setting the `local-zone` flag is not yet supported for hosted clusters")
+		os.Exit(1)
+	}
+
 	// Hosted clusters create identifiers for NodePools, users don't interact directly with these resources
 	if cmd.Flags().Changed("name") {
 		r.Reporter.Errorf("Setting the `name` is not supported for hosted clusters")
@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicelog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	summary      string
+	description  string
+	severity     string
+	internalOnly bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "service-log",
+	Aliases: []string{"servicelog"},
+	Short:   "Add an entry to the service log of a cluster",
+	Long: "Add an entry to the service log of a cluster. Entries are internal notes by default, " +
+		"visible only to Red Hat.",
+	Example: `  # Add a note to the service log of a cluster named "mycluster"
+  rosa create service-log --cluster=mycluster --summary="Maintenance" \
+  --description="Rotated the credentials of the cluster"`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.summary,
+		"summary",
+		"",
+		"Short summary of the entry.",
+	)
+	Cmd.MarkFlagRequired("summary")
+	flags.StringVar(
+		&args.description,
+		"description",
+		"",
+		"Description of the entry.",
+	)
+	flags.StringVar(
+		&args.severity,
+		"severity",
+		string(slv1.SeverityInfo),
+		fmt.Sprintf("Severity of the entry. Options: %s.", strings.Join(ocm.ServiceLogSeverities, ", ")),
+	)
+	flags.BoolVar(
+		&args.internalOnly,
+		"internal-only",
+		true,
+		"Make the entry visible only to Red Hat. Set to false to show it to the users of the cluster.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	severity, err := ocm.ParseServiceLogSeverity(args.severity)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+
+	if !args.internalOnly &&
+		!confirm.Confirm("add an entry visible to the users of cluster '%s' to its service log", clusterKey) {
+		os.Exit(0)
+	}
+
+	entry, err := slv1.NewLogEntry().
+		ClusterUUID(cluster.ExternalID()).
+		ClusterID(cluster.ID()).
+		SubscriptionID(cluster.Subscription().ID()).
+		ServiceName("rosa").
+		Summary(args.summary).
+		Description(args.description).
+		Severity(severity).
+		InternalOnly(args.internalOnly).
+		Timestamp(time.Now()).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to build the service log entry: %v", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Adding entry to the service log of cluster '%s'", clusterKey)
+	_, err = r.OCMClient.AddClusterServiceLog(entry)
+	if err != nil {
+		r.Reporter.Errorf("Failed to add entry to the service log of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Added entry to the service log of cluster '%s'", clusterKey)
+}
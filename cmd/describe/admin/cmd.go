@@ -18,11 +18,13 @@ package admin
 
 import (
 	"os"
+	"sort"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/admin"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -62,6 +64,19 @@ func run(cmd *cobra.Command, _ []string) {
 	} else {
 		r.Reporter.Warnf("There is no admin on cluster '%s'. To create it run the following command:\n"+
 			"   rosa create admin -c %s", clusterKey, clusterKey)
-		os.Exit(0)
+	}
+
+	// Report the admins that expire, named ones included
+	expirations := admin.Expirations(cluster)
+	usernames := make([]string, 0, len(expirations))
+	for username := range expirations {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		if admin.HasUser(existingUserList, username) {
+			r.Reporter.Infof("Admin user '%s' expires on %s", username,
+				expirations[username].UTC().Format("2006-01-02 15:04 MST"))
+		}
 	}
 }
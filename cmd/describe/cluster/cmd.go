@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/spf13/cobra"
@@ -29,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/properties"
+	"github.com/openshift/rosa/pkg/proxy"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -37,6 +39,9 @@ const (
 	ProductionURL = "https://console.redhat.com/openshift/details/s/"
 	StageEnv      = "https://api.stage.openshift.com"
 	ProductionEnv = "https://api.openshift.com"
+
+	// OCM doesn't return the content of the additional trust bundle
+	redactedTrustBundle = "REDACTED"
 )
 
 var Cmd = &cobra.Command{
@@ -203,7 +208,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	if cluster.AdditionalTrustBundle() != "" {
-		str = fmt.Sprintf("%s"+"Additional trust bundle:    REDACTED\n", str)
+		str = fmt.Sprintf("%s"+"Additional trust bundle:    %s\n", str, redactedTrustBundle)
 	}
 
 	if cluster.AWS().STS().RoleARN() != "" {
@@ -252,6 +257,11 @@ func run(cmd *cobra.Command, argv []string) {
 		isPrivate,
 		cluster.CreationTimestamp().Format("Jan _2 2006 15:04:05 MST"))
 
+	if expiration, ok := cluster.GetExpirationTimestamp(); ok && !expiration.IsZero() {
+		str = fmt.Sprintf("%s"+
+			"Expiration:                 %s\n", str,
+			expiration.Format("Jan _2 2006 15:04:05 MST"))
+	}
 	if cluster.DisableUserWorkloadMonitoring() {
 		str = fmt.Sprintf("%s"+
 			"User Workload Monitoring:   %s\n",
@@ -312,6 +322,34 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Print short cluster description:
 	fmt.Print(str)
+
+	warnTrustBundleExpiration(r, cluster)
+}
+
+// warnTrustBundleExpiration warns when the first certificate of the additional trust bundle to expire has
+// expired or is about to expire. OCM doesn't return the bundle, so the expiration is taken from the cluster
+// properties saved when the bundle is set.
+func warnTrustBundleExpiration(r *rosa.Runtime, cluster *cmv1.Cluster) {
+	if cluster.AdditionalTrustBundle() == "" {
+		return
+	}
+	expiration, ok := proxy.GetTrustBundleExpiration(cluster.Properties())
+	if !ok {
+		return
+	}
+	now := time.Now()
+	switch {
+	case expiration.Before(now):
+		r.Reporter.Warnf("A certificate of the additional trust bundle expired on %s",
+			expiration.Format("2006-01-02"))
+	case expiration.Before(now.Add(proxy.DefaultExpirationWarning)):
+		r.Reporter.Warnf("A certificate of the additional trust bundle expires on %s",
+			expiration.Format("2006-01-02"))
+	default:
+		return
+	}
+	r.Reporter.Warnf("Run 'rosa edit cluster -c %s --additional-trust-bundle-file' to replace the bundle",
+		r.ClusterKey)
 }
 
 func controlPlaneConfig(cluster *cmv1.Cluster) string {
@@ -22,8 +22,11 @@ import (
 	"github.com/openshift/rosa/cmd/describe/addon"
 	"github.com/openshift/rosa/cmd/describe/admin"
 	"github.com/openshift/rosa/cmd/describe/cluster"
+	"github.com/openshift/rosa/cmd/describe/egressrequirements"
 	"github.com/openshift/rosa/cmd/describe/installation"
 	"github.com/openshift/rosa/cmd/describe/service"
+	"github.com/openshift/rosa/cmd/describe/upgrade"
+	"github.com/openshift/rosa/cmd/describe/upgradepath"
 	"github.com/openshift/rosa/pkg/arguments"
 )
 
@@ -39,6 +42,9 @@ func init() {
 	Cmd.AddCommand(cluster.Cmd)
 	Cmd.AddCommand(service.Cmd)
 	Cmd.AddCommand(installation.Cmd)
+	Cmd.AddCommand(egressrequirements.Cmd)
+	Cmd.AddCommand(upgrade.Cmd)
+	Cmd.AddCommand(upgradepath.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egressrequirements
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/egress"
+	"github.com/openshift/rosa/pkg/fedramp"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	formatJSON            = "json"
+	formatCSV             = "csv"
+	formatNetworkFirewall = "network-firewall"
+
+	ruleGroupFile = "egress_rule_group.json"
+)
+
+var formats = []string{formatJSON, formatCSV, formatNetworkFirewall}
+
+var args struct {
+	privateLink   bool
+	output        string
+	ruleGroupName string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "egress-requirements",
+	Aliases: []string{"egress"},
+	Short:   "Show the endpoints that a cluster must be able to reach",
+	Long: "Show the hosts and ports that a cluster must be able to reach, so that they can be allowed by " +
+		"the firewalls that filter the egress traffic of the VPC. The endpoints depend on the region, the " +
+		"partition, FedRAMP mode and PrivateLink. When a cluster is given, its API and console hosts are " +
+		"included.",
+	Example: `  # Show the endpoints required by a cluster in us-east-1
+  rosa describe egress-requirements --region=us-east-1
+
+  # Show the endpoints required by an existing cluster as CSV
+  rosa describe egress-requirements --cluster=mycluster -o csv
+
+  # Save an AWS Network Firewall rule group that allows the endpoints
+  rosa describe egress-requirements --region=us-east-1 -o network-firewall`,
+	Run: run,
+}
+
+func init() {
+	ocm.AddOptionalClusterFlag(Cmd)
+
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.privateLink,
+		"private-link",
+		false,
+		"Compute the endpoints of a cluster that uses PrivateLink. Ignored when a cluster is given.",
+	)
+	flags.StringVarP(
+		&args.output,
+		"output",
+		"o",
+		"",
+		fmt.Sprintf("Output format. Allowed formats are %s", formats),
+	)
+	flags.StringVar(
+		&args.ruleGroupName,
+		"rule-group-name",
+		"rosa-egress",
+		"Name of the AWS Network Firewall rule group when the output format is network-firewall.",
+	)
+	Cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string,
+		toComplete string) ([]string, cobra.ShellCompDirective) {
+		return formats, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	if args.output != "" && !helper.Contains(formats, args.output) {
+		r.Reporter.Errorf("Invalid output format '%s'. Allowed formats are %s", args.output, formats)
+		os.Exit(1)
+	}
+
+	options := &egress.Options{
+		FedRAMP:     fedramp.Enabled(),
+		PrivateLink: args.privateLink,
+	}
+	if cmd.Flags().Changed("cluster") {
+		r = r.WithAWS().WithOCM()
+		r.GetClusterKey()
+		cluster := r.FetchCluster()
+		options.Region = cluster.Region().ID()
+		options.PrivateLink = cluster.AWS().PrivateLink()
+		options.ClusterURLs = []string{cluster.API().URL(), cluster.Console().URL()}
+		if !options.PrivateLink && cluster.API().URL() == "" {
+			r.Reporter.Warnf("Cluster '%s' doesn't have an API URL yet, so its API and console aren't included",
+				r.ClusterKey)
+		}
+	} else {
+		region, err := aws.GetRegion(arguments.GetRegion())
+		if err != nil {
+			r.Reporter.Errorf("Error getting region: %v", err)
+			os.Exit(1)
+		}
+		options.Region = region
+	}
+	if fedramp.IsGovRegion(options.Region) {
+		options.FedRAMP = true
+	}
+
+	requirements, err := egress.GetRequirements(options)
+	if err != nil {
+		r.Reporter.Errorf("Failed to compute egress requirements: %v", err)
+		os.Exit(1)
+	}
+
+	switch args.output {
+	case formatJSON:
+		err = printJSON(requirements)
+	case formatCSV:
+		err = printCSV(requirements)
+	case formatNetworkFirewall:
+		err = saveRuleGroup(r, requirements, options.Region)
+	default:
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Endpoints required by a cluster in region '%s' of partition '%s':",
+				options.Region, aws.GetRegionPartition(options.Region))
+		}
+		printTable(requirements)
+	}
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+func printTable(requirements []*egress.Requirement) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "HOST\tPORT\tCATEGORY\tPURPOSE\n")
+	for _, requirement := range requirements {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\n",
+			requirement.Domain(),
+			requirement.Port,
+			requirement.Category,
+			requirement.Purpose,
+		)
+	}
+	writer.Flush()
+}
+
+func printJSON(requirements []*egress.Requirement) error {
+	data, err := json.MarshalIndent(requirements, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func printCSV(requirements []*egress.Requirement) error {
+	writer := csv.NewWriter(os.Stdout)
+	err := writer.Write([]string{"host", "port", "wildcard", "category", "purpose"})
+	if err != nil {
+		return err
+	}
+	for _, requirement := range requirements {
+		err = writer.Write([]string{
+			requirement.Host,
+			strconv.Itoa(requirement.Port),
+			strconv.FormatBool(requirement.Wildcard),
+			requirement.Category,
+			requirement.Purpose,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// saveRuleGroup saves the rule group to a file and prints the command that creates it
+func saveRuleGroup(r *rosa.Runtime, requirements []*egress.Requirement, region string) error {
+	ruleGroup := egress.NewNetworkFirewallRuleGroup(requirements)
+	data, err := json.MarshalIndent(ruleGroup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to generate the rule group: %v", err)
+	}
+	err = helper.SaveDocument(string(data), ruleGroupFile)
+	if err != nil {
+		return fmt.Errorf("Failed to save the rule group: %v", err)
+	}
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Rule group saved to '%s' in the current directory", ruleGroupFile)
+		r.Reporter.Infof("Run the following command to create the rule group:\n")
+	}
+	fmt.Println(awscb.NewNetworkFirewallCommandBuilder().
+		SetCommand(awscb.CreateRuleGroup).
+		AddParam(awscb.RuleGroupName, args.ruleGroupName).
+		AddParam(awscb.Type, "STATEFUL").
+		AddParam(awscb.Capacity, strconv.Itoa(ruleGroup.Capacity())).
+		AddParam(awscb.RuleGroup, fmt.Sprintf("file://%s", ruleGroupFile)).
+		AddParam(awscb.Region, region).
+		Build())
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Domain lists only filter HTTP and TLS traffic. Allow the ports of the endpoints " +
+			"in the stateless rules of the firewall policy.")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/pkg/cron"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "upgrade",
+	Aliases: []string{"upgrades"},
+	Short:   "Show details of the upgrades of a cluster",
+	Long:    "Show the scheduled upgrade or the automatic upgrade schedule of a cluster.",
+	Example: `  # Describe the upgrades of the cluster named "mycluster"
+  rosa describe upgrade --cluster=mycluster`,
+	Run: run,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	c := r.FetchCluster()
+
+	automaticUpgrade, err := r.OCMClient.GetAutomaticUpgradePolicy(c.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get automatic upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if automaticUpgrade != nil {
+		state, err := r.OCMClient.GetUpgradePolicyState(c.ID(), automaticUpgrade.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the state of the automatic upgrades of cluster '%s': %v",
+				clusterKey, err)
+			os.Exit(1)
+		}
+		fmt.Printf(""+
+			"ID:                         %s\n"+
+			"Cluster ID:                 %s\n"+
+			"Schedule Type:              %s\n"+
+			"Schedule:                   %s\n"+
+			"State:                      %s\n",
+			automaticUpgrade.ID(),
+			c.ID(),
+			automaticUpgrade.ScheduleType(),
+			automaticUpgrade.Schedule(),
+			state.Value(),
+		)
+		if automaticUpgrade.Version() != "" {
+			fmt.Printf("Version:                    %s\n", automaticUpgrade.Version())
+		}
+		if !automaticUpgrade.NextRun().IsZero() {
+			fmt.Printf("Next Run:                   %s\n", automaticUpgrade.NextRun().Format("2006-01-02 15:04 MST"))
+		}
+		fmt.Println()
+		schedule, err := cron.Parse(automaticUpgrade.Schedule())
+		if err != nil {
+			r.Reporter.Warnf("Failed to compute the upcoming upgrade windows: %v", err)
+			return
+		}
+		cluster.PrintNextRuns(r, schedule)
+		return
+	}
+
+	scheduledUpgrade, upgradeState, err := r.OCMClient.GetScheduledUpgrade(c.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if scheduledUpgrade == nil {
+		r.Reporter.Infof("There are no upgrades scheduled for cluster '%s'", clusterKey)
+		return
+	}
+	fmt.Printf(""+
+		"ID:                         %s\n"+
+		"Cluster ID:                 %s\n"+
+		"Schedule Type:              %s\n"+
+		"Version:                    %s\n"+
+		"State:                      %s\n"+
+		"Next Run:                   %s\n"+
+		"\n",
+		scheduledUpgrade.ID(),
+		c.ID(),
+		scheduledUpgrade.ScheduleType(),
+		scheduledUpgrade.Version(),
+		upgradeState.Value(),
+		scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"),
+	)
+	if scheduledUpgrade.NextRun().After(time.Now()) {
+		r.Reporter.Infof("The upgrade starts in %s", time.Until(scheduledUpgrade.NextRun()).Round(time.Minute))
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradepath
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	upgradecluster "github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	to               string
+	scheduleFirstHop bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "upgrade-path",
+	Aliases: []string{"upgradepath"},
+	Short:   "Show the upgrades needed to reach a version",
+	Long: "Show the shortest sequence of supported upgrades that takes a cluster from its current version " +
+		"to the given version, with the version gates to acknowledge and the role policies to upgrade " +
+		"before each of them.",
+	Example: `  # Show how to upgrade the cluster named "mycluster" to the latest 4.12 version
+  rosa describe upgrade-path -c mycluster --to 4.12.x
+
+  # Show how to upgrade the cluster to 4.12.10 and schedule the first upgrade
+  rosa describe upgrade-path -c mycluster --to 4.12.10 --schedule-first-hop`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.to,
+		"to",
+		"",
+		"Version to upgrade to. Use a minor version like '4.12.x' to upgrade to its latest reachable version.",
+	)
+	Cmd.MarkFlagRequired("to")
+
+	flags.BoolVar(
+		&args.scheduleFirstHop,
+		"schedule-first-hop",
+		false,
+		"Schedule the upgrade to the first version of the path, as 'rosa upgrade cluster' does.",
+	)
+	aws.AddModeFlag(Cmd)
+
+	confirm.AddFlag(flags)
+}
+
+// hop is one of the upgrades of the path
+type hop struct {
+	from         string
+	to           string
+	gates        []*cmv1.VersionGate
+	rolePolicies []string
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	currentVersion := cluster.Version().RawID()
+	if currentVersion == "" {
+		currentVersion = cluster.OpenshiftVersion()
+	}
+
+	versions, err := r.OCMClient.GetVersions(cluster.Version().ChannelGroup())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get versions: %v", err)
+		os.Exit(1)
+	}
+	path, err := ocm.NewUpgradeGraph(versions).FindUpgradePath(currentVersion, args.to)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	isSTS := cluster.AWS().STS().RoleARN() != ""
+	hops := make([]*hop, len(path))
+	from := currentVersion
+	for i, to := range path {
+		hops[i] = &hop{from: from, to: to}
+		from = to
+	}
+
+	r.Reporter.Debugf("Checking the requirements of the upgrades of cluster '%s'", clusterKey)
+	for i, h := range hops {
+		fromMinor, err := ocm.ParseVersion(h.from)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		toMinor, err := ocm.ParseVersion(h.to)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		// Gates and role policies only change between minor versions
+		if fromMinor == toMinor {
+			continue
+		}
+		h.gates, err = getGates(r, cluster, h.to, toMinor, i == 0, isSTS)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the version gates of version '%s': %v", h.to, err)
+			os.Exit(1)
+		}
+		if isSTS {
+			h.rolePolicies, err = getRolePolicyUpgrades(r, cluster, toMinor)
+			if err != nil {
+				r.Reporter.Errorf("Failed to check the role policies of cluster '%s': %v", clusterKey, err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Cluster '%s' can be upgraded from version '%s' to version '%s' in %d steps:",
+			clusterKey, currentVersion, path[len(path)-1], len(path))
+	}
+	printHops(hops)
+
+	for i, h := range hops {
+		for _, gate := range h.gates {
+			r.Reporter.Warnf("Upgrading to version '%s' requires acknowledging: %s (%s)",
+				h.to, strings.TrimSpace(gate.Description()), gate.DocumentationURL())
+		}
+		if len(h.rolePolicies) > 0 {
+			r.Reporter.Warnf("Upgrading to version '%s' requires upgrading the %s role policies. "+
+				"Run 'rosa upgrade roles -c %s --cluster-version %s' before step %d",
+				h.to, strings.Join(h.rolePolicies, " and "), clusterKey, h.to, i+1)
+		}
+	}
+
+	if !args.scheduleFirstHop {
+		return
+	}
+	scheduleFirstHop(r, cluster, hops[0])
+}
+
+// scheduleFirstHop schedules the upgrade to the first version of the path the same way as the 'upgrade
+// cluster' command
+func scheduleFirstHop(r *rosa.Runtime, cluster *cmv1.Cluster, h *hop) {
+	upgradecluster.CheckExistingUpgrades(r, cluster)
+	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
+		os.Exit(1)
+	}
+	mode, err := aws.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	upgradecluster.ScheduleManualUpgrade(r, upgradecluster.Cmd, cluster, availableUpgrades, h.to, mode)
+}
+
+// getGates returns the gates of a minor version. The gates of the first upgrade are the ones that the
+// cluster hasn't acknowledged yet, the gates of the following upgrades can't be checked until the cluster
+// runs the previous version.
+func getGates(r *rosa.Runtime, cluster *cmv1.Cluster, version string, minor string, first bool,
+	isSTS bool) ([]*cmv1.VersionGate, error) {
+	if first {
+		upgradePolicy, err := cmv1.NewUpgradePolicy().
+			ScheduleType("manual").
+			Version(version).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		return r.OCMClient.GetMissingGateAgreements(cluster.ID(), upgradePolicy)
+	}
+	if isSTS {
+		return r.OCMClient.ListAllOcpGates(minor)
+	}
+	return r.OCMClient.ListOcpGates(minor)
+}
+
+// getRolePolicyUpgrades returns the kinds of roles whose policies aren't compatible with the minor version
+func getRolePolicyUpgrades(r *rosa.Runtime, cluster *cmv1.Cluster, minor string) ([]string, error) {
+	var rolePolicies []string
+	isAccountUpgradeNeeded, err := r.AWSClient.IsUpgradedNeededForAccountRolePoliciesForCluster(cluster, minor)
+	if err != nil {
+		return nil, err
+	}
+	if isAccountUpgradeNeeded {
+		rolePolicies = append(rolePolicies, "account")
+	}
+
+	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+	if err != nil {
+		return nil, err
+	}
+	operatorRolePolicyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+	if err != nil {
+		return nil, err
+	}
+	isOperatorUpgradeNeeded, err := r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(
+		cluster,
+		r.Creator.AccountID,
+		minor,
+		credRequests,
+		operatorRolePolicyPrefix,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if isOperatorUpgradeNeeded {
+		rolePolicies = append(rolePolicies, "operator")
+	}
+	return rolePolicies, nil
+}
+
+func printHops(hops []*hop) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "STEP\tFROM\tTO\tGATES\tROLE POLICIES\n")
+	for i, h := range hops {
+		rolePolicies := "-"
+		if len(h.rolePolicies) > 0 {
+			rolePolicies = strings.Join(h.rolePolicies, ", ")
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%d\t%s\n", i+1, h.from, h.to, len(h.gates), rolePolicies)
+	}
+	writer.Flush()
+}
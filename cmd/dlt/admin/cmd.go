@@ -18,68 +18,20 @@ package admin
 
 import (
 	"os"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/admin"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
-type DeleteAdminUserStrategy interface {
-	deleteAdmin(r *rosa.Runtime, identityProvider *cmv1.IdentityProvider)
-}
-
-type DeleteAdminIDP struct{}
-
-func (d *DeleteAdminIDP) deleteAdmin(r *rosa.Runtime, identityProvider *cmv1.IdentityProvider) {
-	err := r.OCMClient.DeleteIdentityProvider(r.Cluster.ID(), identityProvider.ID())
-	if err != nil {
-		r.Reporter.Errorf("Failed to delete htpasswd idp '%s' of cluster '%s': %s",
-			identityProvider.ID(), r.ClusterKey, err)
-		os.Exit(1)
-	}
-}
-
-type DeleteUserAdminFromIDP struct{}
-
-func (d *DeleteUserAdminFromIDP) deleteAdmin(r *rosa.Runtime, identityProvider *cmv1.IdentityProvider) {
-	clusterID := r.Cluster.ID()
-
-	r.Reporter.Debugf("Deleting user '%s' from identity provider user list on cluster '%s'",
-		idp.ClusterAdminUsername, r.ClusterKey)
-	err := r.OCMClient.DeleteHTPasswdUser(idp.ClusterAdminUsername, clusterID, identityProvider)
-	if err != nil {
-		r.Reporter.Errorf("Failed to delete '%s' user from htpasswd idp users list of cluster '%s': %s",
-			idp.ClusterAdminUsername, r.ClusterKey, err)
-		os.Exit(1)
-	}
-
-	users, err := r.OCMClient.GetHTPasswdUserList(clusterID, identityProvider.ID())
-	if err != nil {
-		r.Reporter.Errorf("Failed to list htpasswd idp users of cluster '%s': %s",
-			r.ClusterKey, err)
-		os.Exit(1)
-	}
-
-	htpasswdIdentityProvider, ok := identityProvider.GetHtpasswd()
-	if !ok {
-		r.Reporter.Errorf("Failed to get htpasswd idp of cluster '%s': %s",
-			r.ClusterKey, err)
-		os.Exit(1)
-	}
-
-	if users.Len() == 0 && htpasswdIdentityProvider.Username() == "" {
-		r.Reporter.Debugf("Deleting '%s' identity provider on cluster '%s'", idp.HTPasswdIDPName, r.ClusterKey)
-		err := r.OCMClient.DeleteIdentityProvider(clusterID, identityProvider.ID())
-		if err != nil {
-			r.Reporter.Errorf("Failed to delete htpasswd idp '%s' of cluster '%s': %s",
-				identityProvider.ID(), r.ClusterKey, err)
-			os.Exit(1)
-		}
-	}
+var args struct {
+	name string
 }
 
 var Cmd = &cobra.Command{
@@ -87,12 +39,21 @@ var Cmd = &cobra.Command{
 	Short: "Deletes the admin user",
 	Long:  "Deletes the cluster-admin user used to login to the cluster",
 	Example: `  # Delete the admin user
-  rosa delete admin --cluster=mycluster`,
+  rosa delete admin --cluster=mycluster
+
+  # Delete the admin user named "breakglass"
+  rosa delete admin --cluster=mycluster --name=breakglass`,
 	Run: run,
 }
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	Cmd.Flags().StringVar(
+		&args.name,
+		"name",
+		idp.ClusterAdminUsername,
+		"Name of the admin user.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -108,53 +69,35 @@ func run(cmd *cobra.Command, _ []string) {
 	// Try to find the htpasswd identity provider:
 	r.Reporter.Debugf("Loading HTPasswd identity provider")
 	clusterID := cluster.ID()
-	idps, err := r.OCMClient.GetIdentityProviders(clusterID)
+	identityProvider, err := r.OCMClient.GetHTPasswdIDP(clusterID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get HTPasswd identity provider for cluster '%s': %v", r.ClusterKey, err)
 		os.Exit(1)
 	}
-
-	var identityProvider *cmv1.IdentityProvider
-	for _, item := range idps {
-		if ocm.IdentityProviderType(item) == ocm.HTPasswdIDPType {
-			identityProvider = item
-		}
-	}
 	if identityProvider == nil {
 		r.Reporter.Errorf("Cluster '%s' does not have an admin user", r.ClusterKey)
 		os.Exit(1)
 	}
 
-	if confirm.Confirm("delete %s user on cluster %s", idp.ClusterAdminUsername, r.ClusterKey) {
-		// delete `cluster-admin` user from the HTPasswd IDP
-		r.Reporter.Debugf("Deleting user '%s' from cluster-admins group on cluster '%s'",
-			idp.ClusterAdminUsername, r.ClusterKey)
-		err = r.OCMClient.DeleteUser(clusterID, "cluster-admins", idp.ClusterAdminUsername)
+	if confirm.Confirm("delete %s user on cluster %s", args.name, r.ClusterKey) {
+		r.Reporter.Debugf("Deleting user '%s' on cluster '%s'", args.name, r.ClusterKey)
+		err = r.OCMClient.DeleteAdmin(clusterID, identityProvider, args.name)
 		if err != nil {
-			r.Reporter.Errorf("Failed to delete '%s' user from cluster-admins groups of cluster '%s': %s",
-				idp.ClusterAdminUsername, r.ClusterKey, err)
+			r.Reporter.Errorf("Failed to delete admin user of cluster '%s': %v", r.ClusterKey, err)
 			os.Exit(1)
 		}
 
-		deletionStrategy := getAdminUserDeletionStrategy(r, identityProvider)
-		deletionStrategy.deleteAdmin(r, identityProvider)
-
-		r.Reporter.Infof("Admin user '%s' has been deleted from cluster '%s'", idp.ClusterAdminUsername, r.ClusterKey)
-	}
-}
-
-func getAdminUserDeletionStrategy(r *rosa.Runtime, identityProvider *cmv1.IdentityProvider) DeleteAdminUserStrategy {
-	if wasAdminCreatedUsingOldROSA(r, identityProvider) {
-		return &DeleteAdminIDP{}
-	}
-	return &DeleteUserAdminFromIDP{}
-}
+		if _, ok := admin.Expirations(cluster)[args.name]; ok {
+			err = r.OCMClient.UpdateClusterProperties(clusterID, func(props map[string]string) map[string]string {
+				return admin.Properties(props, args.name, time.Time{})
+			})
+			if err != nil {
+				r.Reporter.Errorf("Failed to remove the expiration of admin user '%s' of cluster '%s': %v",
+					args.name, r.ClusterKey, err)
+				os.Exit(1)
+			}
+		}
 
-func wasAdminCreatedUsingOldROSA(r *rosa.Runtime, identityProvider *cmv1.IdentityProvider) bool {
-	htpasswdIdp, ok := identityProvider.GetHtpasswd()
-	if !ok {
-		r.Reporter.Errorf("Failed to get htpasswd idp for cluster '%s'", r.Cluster.ID())
-		os.Exit(1)
+		r.Reporter.Infof("Admin user '%s' has been deleted from cluster '%s'", args.name, r.ClusterKey)
 	}
-	return htpasswdIdp.Username() == idp.ClusterAdminUsername
 }
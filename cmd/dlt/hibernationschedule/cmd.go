@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "hibernation-schedule",
+	Aliases: []string{"hibernationschedule"},
+	Short:   "Delete the hibernation schedule of a cluster",
+	Long:    "Delete the hibernation schedule of a cluster. The current state of the cluster isn't changed.",
+	Example: `  # Delete the hibernation schedule of the cluster "mycluster"
+  rosa delete hibernation-schedule -c mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	schedule, err := hibernation.GetSchedule(cluster)
+	if err != nil {
+		r.Reporter.Warnf("The hibernation schedule of cluster '%s' isn't valid: %v", clusterKey, err)
+	} else if schedule == nil {
+		r.Reporter.Warnf("Cluster '%s' doesn't have a hibernation schedule", clusterKey)
+		os.Exit(0)
+	}
+
+	if !confirm.Confirm("delete the hibernation schedule of cluster '%s'", clusterKey) {
+		os.Exit(0)
+	}
+	var noSchedule *hibernation.Schedule
+	err = r.OCMClient.UpdateClusterProperties(cluster.ID(), noSchedule.Properties)
+	if err != nil {
+		r.Reporter.Errorf("Failed to delete the hibernation schedule of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Deleted the hibernation schedule of cluster '%s'", clusterKey)
+}
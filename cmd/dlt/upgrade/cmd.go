@@ -31,7 +31,7 @@ var Cmd = &cobra.Command{
 	Use:     "upgrade",
 	Aliases: []string{"upgrades"},
 	Short:   "Cancel cluster upgrade",
-	Long:    "Cancel scheduled cluster upgrade",
+	Long:    "Cancel scheduled cluster upgrade or remove the automatic upgrade schedule of the cluster",
 	Run:     run,
 }
 
@@ -51,6 +51,26 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	automaticUpgrade, err := r.OCMClient.GetAutomaticUpgradePolicy(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get automatic upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if automaticUpgrade != nil {
+		if confirm.Confirm("remove automatic upgrade schedule '%s' from cluster %s", automaticUpgrade.Schedule(),
+			clusterKey) {
+			r.Reporter.Debugf("Deleting automatic upgrade policy for cluster '%s'", clusterKey)
+			err = r.OCMClient.DeleteUpgradePolicy(cluster.ID(), automaticUpgrade.ID())
+			if err != nil {
+				r.Reporter.Errorf("Failed to remove automatic upgrade schedule from cluster '%s': %v",
+					clusterKey, err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("Successfully removed automatic upgrade schedule from cluster '%s'", clusterKey)
+		}
+		return
+	}
+
 	scheduledUpgrade, _, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
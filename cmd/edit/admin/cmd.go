@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"os"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/admin"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	name            string
+	rotatePassword  bool
+	password        string
+	expireIn        string
+	noExpiration    bool
+	passwordFile    string
+	passwordCommand string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Edit the admin user",
+	Long:  "Rotate the password of an admin user created with 'rosa create admin', or change when it expires.",
+	Example: `  # Replace the password of the admin user with a new random password
+  rosa edit admin -c mycluster --rotate-password
+
+  # Extend the break-glass admin user "breakglass" for 4 more hours
+  rosa edit admin -c mycluster --name breakglass --expire-in 4h`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.name,
+		"name",
+		idp.ClusterAdminUsername,
+		"Name of the admin user.",
+	)
+	flags.BoolVar(
+		&args.rotatePassword,
+		"rotate-password",
+		false,
+		"Replace the password of the admin user with a new random password.",
+	)
+	flags.StringVarP(
+		&args.password,
+		"password",
+		"p",
+		"",
+		"Replace the password of the admin user with this password.",
+	)
+	flags.StringVar(
+		&args.expireIn,
+		"expire-in",
+		"",
+		"Duration from now after which the admin user expires, for example '8h' or '2d'. Expired admin "+
+			"users are deleted by 'rosa schedule run'.",
+	)
+	flags.BoolVar(
+		&args.noExpiration,
+		"no-expiration",
+		false,
+		"Remove the expiration of the admin user.",
+	)
+	flags.StringVar(
+		&args.passwordFile,
+		"password-file",
+		"",
+		"Write the new password to this file, readable only by the current user, instead of printing it.",
+	)
+	flags.StringVar(
+		&args.passwordCommand,
+		"password-command",
+		"",
+		"Pass the new password to the standard input of this command, for example a password manager, "+
+			"instead of printing it.",
+	)
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	username := args.name
+	changePassword := args.rotatePassword || args.password != ""
+	changeExpiration := args.expireIn != "" || args.noExpiration
+	if args.rotatePassword && args.password != "" {
+		r.Reporter.Errorf("Flags '--rotate-password' and '--password' are mutually exclusive")
+		os.Exit(1)
+	}
+	if args.expireIn != "" && args.noExpiration {
+		r.Reporter.Errorf("Flags '--expire-in' and '--no-expiration' are mutually exclusive")
+		os.Exit(1)
+	}
+	if !changePassword && !changeExpiration {
+		r.Reporter.Errorf("Expected at least one of '--rotate-password', '--password', '--expire-in' " +
+			"or '--no-expiration'")
+		os.Exit(1)
+	}
+	if (args.passwordFile != "" || args.passwordCommand != "") && !args.rotatePassword {
+		r.Reporter.Errorf("Flags '--password-file' and '--password-command' require '--rotate-password'")
+		os.Exit(1)
+	}
+	var expiration time.Time
+	if args.expireIn != "" {
+		duration, err := helper.ParseDuration(args.expireIn)
+		if err != nil || duration <= 0 {
+			r.Reporter.Errorf("Expected a positive duration for '--expire-in', got '%s'", args.expireIn)
+			os.Exit(1)
+		}
+		expiration = time.Now().Add(duration)
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	htpasswdIDP, userList := idp.FindExistingHTPasswdIDP(cluster, r)
+	if !admin.HasUser(userList, username) {
+		r.Reporter.Errorf("Cluster '%s' doesn't have an admin user named '%s'. To create it, run "+
+			"'rosa create admin -c %s --name %s'", clusterKey, username, clusterKey, username)
+		os.Exit(1)
+	}
+	user, err := r.OCMClient.GetUser(cluster.ID(), ocm.ClusterAdminsGroup, username)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get user '%s' of cluster '%s': %v", username, clusterKey, err)
+		os.Exit(1)
+	}
+	if user == nil {
+		r.Reporter.Errorf("User '%s' of cluster '%s' isn't an admin user", username, clusterKey)
+		os.Exit(1)
+	}
+
+	if changePassword {
+		if !confirm.Confirm("replace the password of user '%s' of cluster '%s'", username, clusterKey) {
+			os.Exit(0)
+		}
+		password := args.password
+		if args.rotatePassword {
+			r.Reporter.Debugf("Generating random password")
+			password, err = admin.GeneratePassword(23)
+			if err != nil {
+				r.Reporter.Errorf("Failed to generate a random password")
+				os.Exit(1)
+			}
+		}
+		err = r.OCMClient.UpdateHTPasswdUser(username, password, cluster.ID(), htpasswdIDP)
+		if err != nil {
+			r.Reporter.Errorf("Failed to replace the password of user '%s' of cluster '%s': %v",
+				username, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Replaced the password of admin user '%s' of cluster '%s'", username, clusterKey)
+		if args.rotatePassword {
+			if args.passwordFile != "" || args.passwordCommand != "" {
+				err = admin.SavePassword(password, args.passwordFile, args.passwordCommand)
+				if err != nil {
+					r.Reporter.Errorf("%v", err)
+					os.Exit(1)
+				}
+				r.Reporter.Infof("The new password has been saved")
+			} else {
+				r.Reporter.Infof("Please securely store this generated password. To login, run the "+
+					"following command:\n\n   oc login %s --username %s --password %s\n",
+					cluster.API().URL(), username, password)
+			}
+		}
+	}
+
+	if changeExpiration {
+		err = r.OCMClient.UpdateClusterProperties(cluster.ID(), func(props map[string]string) map[string]string {
+			return admin.Properties(props, username, expiration)
+		})
+		if err != nil {
+			r.Reporter.Errorf("Failed to change the expiration of admin user '%s' of cluster '%s': %v",
+				username, clusterKey, err)
+			os.Exit(1)
+		}
+		if expiration.IsZero() {
+			r.Reporter.Infof("Admin user '%s' of cluster '%s' no longer expires", username, clusterKey)
+		} else {
+			r.Reporter.Infof("Admin user '%s' of cluster '%s' expires on %s", username, clusterKey,
+				expiration.UTC().Format("2006-01-02 15:04 MST"))
+		}
+	}
+}
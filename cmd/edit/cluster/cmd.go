@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/proxy"
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/spf13/cobra"
 )
@@ -40,6 +41,7 @@ var args struct {
 	// Basic options
 	expirationTime     string
 	expirationDuration time.Duration
+	clearExpiration    bool
 
 	// Networking options
 	private                   bool
@@ -57,6 +59,9 @@ var Cmd = &cobra.Command{
 	Example: `  # Edit a cluster named "mycluster" to make it private
   rosa edit cluster mycluster --private
 
+  # Delete the cluster named "mycluster" automatically in 3 days
+  rosa edit cluster -c mycluster --expiration 72h
+
   # Edit all options interactively
   rosa edit cluster -c mycluster --interactive`,
 	Run: run,
@@ -81,9 +86,12 @@ func init() {
 		0,
 		"Expire cluster after a relative duration like 2h, 8h, 72h. Only one of expiration-time / expiration may be used.",
 	)
-	// Cluster expiration is not supported in production
-	flags.MarkHidden("expiration-time")
-	flags.MarkHidden("expiration")
+	flags.BoolVar(
+		&args.clearExpiration,
+		"clear-expiration",
+		false,
+		"Remove the expiration of the cluster, so that it isn't deleted automatically.",
+	)
 
 	// Networking options
 	flags.BoolVar(
@@ -138,7 +146,7 @@ func run(cmd *cobra.Command, _ []string) {
 	// Enable interactive mode if no flags have been set
 	if !interactive.Enabled() {
 		changedFlags := false
-		for _, flag := range []string{"expiration-time", "expiration", "private",
+		for _, flag := range []string{"expiration-time", "expiration", "clear-expiration", "private",
 			"disable-workload-monitoring", "http-proxy", "https-proxy", "no-proxy", "additional-trust-bundle-file"} {
 			if cmd.Flags().Changed(flag) {
 				changedFlags = true
@@ -152,11 +160,18 @@ func run(cmd *cobra.Command, _ []string) {
 	cluster := r.FetchCluster()
 
 	// Validate flags:
-	expiration, err := validateExpiration()
+	expiration, err := validateExpiration(cmd)
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
 		os.Exit(1)
 	}
+	if !expiration.IsZero() && time.Until(expiration) < minExpiration {
+		r.Reporter.Warnf("Cluster '%s' will be deleted on %s, when it expires", clusterKey,
+			expiration.Format(time.RFC3339))
+		if !confirm.Confirm("set the expiration of cluster '%s' less than %s from now", clusterKey, minExpiration) {
+			os.Exit(0)
+		}
+	}
 
 	if interactive.Enabled() {
 		r.Reporter.Infof("Interactive mode enabled.\n" +
@@ -507,22 +522,49 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	if args.clearExpiration {
+		r.Reporter.Debugf("Clearing the expiration of cluster '%s'", clusterKey)
+		err = r.OCMClient.ClearClusterExpiration(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to clear the expiration of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+	}
+
 	r.Reporter.Debugf("Updating cluster '%s'", clusterKey)
 	err = r.OCMClient.UpdateCluster(clusterKey, r.Creator, clusterConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
 		os.Exit(1)
 	}
+
+	if clusterConfig.AdditionalTrustBundle != nil {
+		// OCM doesn't return the additional trust bundle, so its expiration is kept to warn about it
+		err = r.OCMClient.UpdateClusterProperties(cluster.ID(), func(props map[string]string) map[string]string {
+			return proxy.TrustBundleProperties(props, *clusterConfig.AdditionalTrustBundle)
+		})
+		if err != nil {
+			r.Reporter.Warnf("Failed to save the expiration of the additional trust bundle: %v", err)
+		}
+	}
 	r.Reporter.Infof("Updated cluster '%s'", clusterKey)
 }
 
-func validateExpiration() (expiration time.Time, err error) {
+// minExpiration is the shortest expiration that is set without confirmation, as OCM deletes expired clusters
+const minExpiration = time.Hour
+
+func validateExpiration(cmd *cobra.Command) (expiration time.Time, err error) {
 	// Validate options
 	if len(args.expirationTime) > 0 && args.expirationDuration != 0 {
 		err = errors.New("At most one of 'expiration-time' or 'expiration' may be specified")
 		return
 	}
 
+	if args.clearExpiration && (len(args.expirationTime) > 0 || args.expirationDuration != 0) {
+		err = errors.New("'clear-expiration' can't be used with 'expiration-time' or 'expiration'")
+		return
+	}
+
 	// Parse the expiration options
 	if len(args.expirationTime) > 0 {
 		t, err := parseRFC3339(args.expirationTime)
@@ -531,9 +573,18 @@ func validateExpiration() (expiration time.Time, err error) {
 			return expiration, err
 		}
 
+		if !t.After(time.Now()) {
+			err = fmt.Errorf("Expiration time '%s' isn't in the future", args.expirationTime)
+			return expiration, err
+		}
+
 		expiration = t
 	}
-	if args.expirationDuration != 0 {
+	if cmd.Flags().Changed("expiration") {
+		if args.expirationDuration <= 0 {
+			err = fmt.Errorf("Expiration '%s' must be a positive duration", args.expirationDuration)
+			return
+		}
 		// round up to the nearest second
 		expiration = time.Now().Add(args.expirationDuration).Round(time.Second)
 	}
@@ -0,0 +1,285 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	dryRun bool
+
+	// HTPasswd
+	htpasswdSync string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "idp NAME",
+	Aliases: []string{"idps"},
+	Short:   "Edit cluster IDP",
+	Long: "Edit an identity provider of a cluster in place, without deleting and recreating it. Settings " +
+		"that aren't given keep their current values, and they are asked for in interactive mode.",
+	Example: `  # Change the organizations of the GitHub identity provider "github-1"
+  rosa edit idp -c mycluster github-1 --organizations myorg,otherorg
+
+  # Show what would change when replacing the bind password of the LDAP identity provider "ldap-1"
+  rosa edit idp -c mycluster ldap-1 --bind-password MyNewPassword --dry-run
+
+  # Edit the OpenID identity provider "openid-1" interactively
+  rosa edit idp -c mycluster openid-1 --interactive
+
+  # Make the users of the identity provider "htpasswd" match the users in a file
+  rosa edit idp -c mycluster htpasswd --sync users.htpasswd
+
+  # Show the changes without applying them
+  rosa edit idp -c mycluster htpasswd --sync users.htpasswd --dry-run`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the name of the identity provider",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Show the changes that would be applied to the identity provider without applying them.",
+	)
+	for _, f := range fields {
+		if f.boolean {
+			flags.Bool(f.flag, false, f.usage)
+		} else {
+			flags.String(f.flag, "", f.usage)
+		}
+	}
+
+	// HTPasswd
+	flags.StringVar(
+		&args.htpasswdSync,
+		"sync",
+		"",
+		"HTPasswd: File with the users of the identity provider, in the format accepted by\n"+
+			"'rosa create idp --from-file'. Users are added, updated and removed to match the file, except\n"+
+			"the admin users created with 'rosa create admin'.",
+	)
+	confirm.AddFlag(flags)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	idpName := argv[0]
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	// Try to find the identity provider:
+	r.Reporter.Debugf("Loading identity provider '%s'", idpName)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	var idp *cmv1.IdentityProvider
+	for _, item := range idps {
+		if item.Name() == idpName {
+			idp = item
+		}
+	}
+	if idp == nil {
+		r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+		os.Exit(1)
+	}
+
+	switch ocm.IdentityProviderType(idp) {
+	case ocm.HTPasswdIDPType:
+		if args.htpasswdSync == "" {
+			r.Reporter.Errorf("Expected the file with the users of identity provider '%s' in '--sync'", idpName)
+			os.Exit(1)
+		}
+		syncHTPasswdUsers(r, cluster, idp)
+	default:
+		if args.htpasswdSync != "" {
+			r.Reporter.Errorf("Flag '--sync' only applies to HTPasswd identity providers")
+			os.Exit(1)
+		}
+		editIDP(r, cmd, cluster, idp)
+	}
+}
+
+// editIDP replaces the fields of an identity provider with the values of the flags, or the answers in
+// interactive mode, and updates it
+func editIDP(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster, idp *cmv1.IdentityProvider) {
+	idpType := ocm.IdentityProviderType(idp)
+	flagNames, ok := fieldsByType[idpType]
+	if !ok {
+		r.Reporter.Errorf("Editing %s identity providers isn't supported", idpType)
+		os.Exit(1)
+	}
+	applies := make(map[string]bool)
+	for _, flag := range flagNames {
+		applies[flag] = true
+	}
+
+	current := getValues(idp)
+	desired := make(map[string]string)
+	for key, value := range current {
+		desired[key] = value
+	}
+	changed := false
+	for _, f := range fields {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		if !applies[f.flag] {
+			r.Reporter.Errorf("Flag '--%s' doesn't apply to %s identity providers", f.flag, idpType)
+			os.Exit(1)
+		}
+		value := cmd.Flags().Lookup(f.flag).Value.String()
+		if f.file {
+			value = readFile(r, value)
+		}
+		desired[f.flag] = value
+		changed = true
+	}
+
+	if !changed {
+		interactive.Enable()
+	}
+	if interactive.Enabled() {
+		for _, flag := range flagNames {
+			desired[flag] = ask(r, getField(flag), desired[flag])
+		}
+	}
+
+	update, err := buildIDP(idpType, desired)
+	if err != nil {
+		r.Reporter.Errorf("Failed to edit identity provider '%s': %v", idp.Name(), err)
+		os.Exit(1)
+	}
+	lines := diffValues(idpType, current, desired)
+	if len(lines) == 0 {
+		r.Reporter.Infof("There are no changes to identity provider '%s'", idp.Name())
+		return
+	}
+	r.Reporter.Infof("Changes to identity provider '%s':", idp.Name())
+	for _, line := range lines {
+		fmt.Printf("  %s\n", line)
+	}
+	if args.dryRun {
+		return
+	}
+
+	if !confirm.Confirm("edit identity provider '%s' of cluster '%s'", idp.Name(), r.ClusterKey) {
+		os.Exit(0)
+	}
+	_, err = r.OCMClient.UpdateIdentityProvider(cluster.ID(), idp.ID(), update)
+	if err != nil {
+		r.Reporter.Errorf("Failed to edit identity provider '%s' of cluster '%s': %v", idp.Name(),
+			r.ClusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Identity provider '%s' has been updated. It may take several minutes for the changes "+
+		"to become active.", idp.Name())
+}
+
+// ask asks for the value of a field, using the current one as default
+func ask(r *rosa.Runtime, f field, value string) string {
+	var err error
+	input := interactive.Input{
+		Question: f.question,
+		Help:     f.usage,
+		Default:  value,
+	}
+	switch {
+	case f.boolean:
+		var current, answer bool
+		current, _ = strconv.ParseBool(value)
+		input.Default = current
+		answer, err = interactive.GetBool(input)
+		value = strconv.FormatBool(answer)
+	case f.secret:
+		input.Default = nil
+		input.Help = f.usage + " Leave empty to keep the current value."
+		var answer string
+		answer, err = interactive.GetPassword(input)
+		if answer != "" {
+			value = answer
+		}
+	case f.file:
+		// The current value is the content of the file, so the default keeps it
+		input.Default = ""
+		input.Help = f.usage + " Leave empty to keep the current value."
+		var path string
+		path, err = interactive.GetCert(input)
+		if err == nil && path != "" {
+			value = readFile(r, path)
+		}
+	case f.flag == "mapping-method":
+		input.Options = idpPack.ValidMappingMethods
+		input.Required = true
+		value, err = interactive.GetOption(input)
+	default:
+		value, err = interactive.GetString(input)
+	}
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid value for '%s': %v", f.question, err)
+		os.Exit(1)
+	}
+	return value
+}
+
+// readFile returns the content of a file given to a file field, or an empty string for an empty path
+func readFile(r *rosa.Runtime, path string) string {
+	if path == "" {
+		return ""
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid certificate bundle: %v", err)
+		os.Exit(1)
+	}
+	return string(content)
+}
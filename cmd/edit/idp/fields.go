@@ -0,0 +1,341 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// field is a setting of an identity provider that can be edited. Fields are named after the flags of
+// 'rosa create idp' that set them, and their values are kept as the strings given to those flags.
+type field struct {
+	flag     string
+	question string
+	usage    string
+	// secret fields aren't returned by the API, so they are never shown and an empty value keeps the
+	// current one
+	secret bool
+	// file fields are given as the path of a file with the value, such as a CA bundle
+	file    bool
+	boolean bool
+}
+
+var fields = []field{
+	{flag: "client-id", question: "Client ID", usage: "Client ID from the registered application."},
+	{flag: "client-secret", question: "Client Secret", secret: true,
+		usage: "Client Secret from the registered application."},
+	{flag: "mapping-method", question: "Mapping method",
+		usage: fmt.Sprintf("Specifies how new identities are mapped to users when they log in. Options are %s",
+			idpPack.ValidMappingMethods)},
+	{flag: "ca", question: "CA file path", file: true,
+		usage: "Path to PEM-encoded certificate file to use when making requests to the server. " +
+			"An empty path removes the certificate.\n"},
+
+	// GitHub
+	{flag: "hostname", question: "GitHub Enterprise Hostname",
+		usage: "GitHub: Optional domain to use with a hosted instance of GitHub Enterprise."},
+	{flag: "organizations", question: "GitHub organizations",
+		usage: "GitHub: Only users that are members of at least one of the listed organizations will be " +
+			"allowed to log in."},
+	{flag: "teams", question: "GitHub teams",
+		usage: "GitHub: Only users that are members of at least one of the listed teams will be allowed to " +
+			"log in. The format is <org>/<team>.\n"},
+
+	// GitLab
+	{flag: "host-url", question: "URL", usage: "GitLab: The host URL of a GitLab provider.\n"},
+
+	// Google
+	{flag: "hosted-domain", question: "Hosted domain", usage: "Google: Restrict users to a Google Apps domain.\n"},
+
+	// LDAP
+	{flag: "url", question: "LDAP URL",
+		usage: "LDAP: An RFC 2255 URL which specifies the LDAP search parameters to use."},
+	{flag: "insecure", question: "Insecure", boolean: true,
+		usage: "LDAP: Do not make TLS connections to the server."},
+	{flag: "bind-dn", question: "Bind DN", usage: "LDAP: DN to bind with during the search phase."},
+	{flag: "bind-password", question: "Bind password", secret: true,
+		usage: "LDAP: Password to bind with during the search phase."},
+	{flag: "id-attributes", question: "ID",
+		usage: "LDAP: The list of attributes whose values should be used as the user ID."},
+	{flag: "username-attributes", question: "Preferred username",
+		usage: "LDAP: The list of attributes whose values should be used as the preferred username."},
+	{flag: "name-attributes", question: "Name",
+		usage: "LDAP: The list of attributes whose values should be used as the display name."},
+	{flag: "email-attributes", question: "Email",
+		usage: "LDAP: The list of attributes whose values should be used as the email address.\n"},
+
+	// OpenID
+	{flag: "issuer-url", question: "Issuer URL",
+		usage: "OpenID: The URL that the OpenID Provider asserts as the Issuer Identifier. " +
+			"It must use the https scheme with no URL query parameters or fragment."},
+	{flag: "email-claims", question: "Email", usage: "OpenID: List of claims to use as the email address."},
+	{flag: "name-claims", question: "Name", usage: "OpenID: List of claims to use as the display name."},
+	{flag: "username-claims", question: "Preferred username",
+		usage: "OpenID: List of claims to use as the preferred username when provisioning a user."},
+	{flag: "groups-claims", question: "Groups", usage: "OpenID: List of claims to use as the groups names."},
+	{flag: "extra-scopes", question: "Extra scopes",
+		usage: "OpenID: List of scopes to request, in addition to the 'openid' scope, during the " +
+			"authorization token request.\n"},
+}
+
+// fieldsByType contains the fields that can be edited for each type of identity provider, in the order
+// they are asked for
+var fieldsByType = map[string][]string{
+	ocm.GithubIDPType: {"client-id", "client-secret", "organizations", "teams", "hostname", "ca",
+		"mapping-method"},
+	ocm.GitlabIDPType: {"host-url", "client-id", "client-secret", "ca", "mapping-method"},
+	ocm.GoogleIDPType: {"client-id", "client-secret", "mapping-method", "hosted-domain"},
+	ocm.LDAPIDPType: {"url", "insecure", "ca", "mapping-method", "bind-dn", "bind-password", "id-attributes",
+		"username-attributes", "name-attributes", "email-attributes"},
+	ocm.OpenIDIDPType: {"client-id", "client-secret", "issuer-url", "ca", "mapping-method", "email-claims",
+		"name-claims", "username-claims", "groups-claims", "extra-scopes"},
+}
+
+func getField(flag string) field {
+	for _, f := range fields {
+		if f.flag == flag {
+			return f
+		}
+	}
+	panic(fmt.Sprintf("unknown identity provider field '%s'", flag))
+}
+
+func join(values []string) string {
+	return strings.Join(values, ",")
+}
+
+func split(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// getValues returns the current values of the fields of an identity provider. Secrets are never returned.
+func getValues(idp *cmv1.IdentityProvider) map[string]string {
+	values := map[string]string{
+		"mapping-method": string(idp.MappingMethod()),
+	}
+	switch ocm.IdentityProviderType(idp) {
+	case ocm.GithubIDPType:
+		github := idp.Github()
+		values["client-id"] = github.ClientID()
+		values["organizations"] = join(github.Organizations())
+		values["teams"] = join(github.Teams())
+		values["hostname"] = github.Hostname()
+		values["ca"] = github.CA()
+	case ocm.GitlabIDPType:
+		gitlab := idp.Gitlab()
+		values["client-id"] = gitlab.ClientID()
+		values["host-url"] = gitlab.URL()
+		values["ca"] = gitlab.CA()
+	case ocm.GoogleIDPType:
+		google := idp.Google()
+		values["client-id"] = google.ClientID()
+		values["hosted-domain"] = google.HostedDomain()
+	case ocm.LDAPIDPType:
+		ldap := idp.LDAP()
+		values["url"] = ldap.URL()
+		values["insecure"] = strconv.FormatBool(ldap.Insecure())
+		values["ca"] = ldap.CA()
+		values["bind-dn"] = ldap.BindDN()
+		values["id-attributes"] = join(ldap.Attributes().ID())
+		values["username-attributes"] = join(ldap.Attributes().PreferredUsername())
+		values["name-attributes"] = join(ldap.Attributes().Name())
+		values["email-attributes"] = join(ldap.Attributes().Email())
+	case ocm.OpenIDIDPType:
+		openid := idp.OpenID()
+		values["client-id"] = openid.ClientID()
+		values["issuer-url"] = openid.Issuer()
+		values["ca"] = openid.CA()
+		values["email-claims"] = join(openid.Claims().Email())
+		values["name-claims"] = join(openid.Claims().Name())
+		values["username-claims"] = join(openid.Claims().PreferredUsername())
+		values["groups-claims"] = join(openid.Claims().Groups())
+		values["extra-scopes"] = join(openid.ExtraScopes())
+	}
+	return values
+}
+
+// buildIDP validates the values of the fields of an identity provider and builds the update of the
+// identity provider. Empty secrets aren't sent, so that the current ones are kept.
+func buildIDP(idpType string, values map[string]string) (*cmv1.IdentityProvider, error) {
+	mappingMethod := values["mapping-method"]
+	validMappingMethod := false
+	for _, method := range idpPack.ValidMappingMethods {
+		if method == mappingMethod {
+			validMappingMethod = true
+		}
+	}
+	if !validMappingMethod {
+		return nil, fmt.Errorf("Expected a valid mapping method. Options are %s", idpPack.ValidMappingMethods)
+	}
+	builder := cmv1.NewIdentityProvider().
+		MappingMethod(cmv1.IdentityProviderMappingMethod(mappingMethod))
+
+	switch idpType {
+	case ocm.GithubIDPType:
+		organizations := values["organizations"]
+		teams := values["teams"]
+		if organizations != "" && teams != "" {
+			return nil, errors.New("GitHub IDP only allows either organizations or teams, but not both")
+		}
+		if organizations == "" && teams == "" {
+			return nil, errors.New("GitHub IdP requires either organizations or teams")
+		}
+		for _, team := range split(teams) {
+			if len(strings.Split(team, "/")) != 2 {
+				return nil, fmt.Errorf("Expected GitHub team '%s' to follow the form '<org>/<team>'", team)
+			}
+		}
+		if values["hostname"] == "" && values["ca"] != "" {
+			return nil, errors.New("CA is not expected when not using a hosted instance of Github Enterprise")
+		}
+		github := cmv1.NewGithubIdentityProvider().
+			ClientID(values["client-id"]).
+			Hostname(values["hostname"]).
+			CA(values["ca"]).
+			Organizations(split(organizations)...).
+			Teams(split(teams)...)
+		if values["client-secret"] != "" {
+			github.ClientSecret(values["client-secret"])
+		}
+		builder.Type("GithubIdentityProvider").Github(github)
+	case ocm.GitlabIDPType:
+		err := idpPack.ValidateGitlabHostURL(values["host-url"])
+		if err != nil {
+			return nil, err
+		}
+		gitlab := cmv1.NewGitlabIdentityProvider().
+			ClientID(values["client-id"]).
+			URL(values["host-url"]).
+			CA(values["ca"])
+		if values["client-secret"] != "" {
+			gitlab.ClientSecret(values["client-secret"])
+		}
+		builder.Type("GitlabIdentityProvider").Gitlab(gitlab)
+	case ocm.GoogleIDPType:
+		hostedDomain := values["hosted-domain"]
+		if hostedDomain == "" && mappingMethod != "lookup" {
+			return nil, errors.New("Google IdP requires a hosted domain unless the mapping method is 'lookup'")
+		}
+		if hostedDomain != "" {
+			err := idpPack.ValidateGoogleHostedDomain(hostedDomain)
+			if err != nil {
+				return nil, err
+			}
+		}
+		google := cmv1.NewGoogleIdentityProvider().
+			ClientID(values["client-id"]).
+			HostedDomain(hostedDomain)
+		if values["client-secret"] != "" {
+			google.ClientSecret(values["client-secret"])
+		}
+		builder.Type("GoogleIdentityProvider").Google(google)
+	case ocm.LDAPIDPType:
+		err := idpPack.ValidateLdapURL(values["url"])
+		if err != nil {
+			return nil, err
+		}
+		insecure, err := strconv.ParseBool(values["insecure"])
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid insecure value: %v", err)
+		}
+		if insecure && strings.HasPrefix(values["url"], "ldaps") {
+			return nil, errors.New("Cannot use insecure connection on ldaps URLs")
+		}
+		if insecure && values["ca"] != "" {
+			return nil, errors.New("Cannot use certificate bundle with an insecure connection")
+		}
+		if values["id-attributes"] == "" {
+			return nil, errors.New("LDAP ID is required")
+		}
+		ldap := cmv1.NewLDAPIdentityProvider().
+			URL(values["url"]).
+			Insecure(insecure).
+			CA(values["ca"]).
+			BindDN(values["bind-dn"]).
+			Attributes(cmv1.NewLDAPAttributes().
+				ID(split(values["id-attributes"])...).
+				PreferredUsername(split(values["username-attributes"])...).
+				Name(split(values["name-attributes"])...).
+				Email(split(values["email-attributes"])...))
+		if values["bind-password"] != "" {
+			ldap.BindPassword(values["bind-password"])
+		}
+		builder.Type("LDAPIdentityProvider").LDAP(ldap)
+	case ocm.OpenIDIDPType:
+		err := idpPack.ValidateOpenidIssuerURL(values["issuer-url"])
+		if err != nil {
+			return nil, err
+		}
+		if values["email-claims"] == "" && values["name-claims"] == "" && values["username-claims"] == "" &&
+			values["groups-claims"] == "" {
+			return nil, errors.New("At least one claim is required: [email-claims name-claims " +
+				"username-claims groups-claims]")
+		}
+		openid := cmv1.NewOpenIDIdentityProvider().
+			ClientID(values["client-id"]).
+			Issuer(values["issuer-url"]).
+			CA(values["ca"]).
+			ExtraScopes(split(values["extra-scopes"])...).
+			Claims(cmv1.NewOpenIDClaims().
+				Email(split(values["email-claims"])...).
+				Name(split(values["name-claims"])...).
+				PreferredUsername(split(values["username-claims"])...).
+				Groups(split(values["groups-claims"])...))
+		if values["client-secret"] != "" {
+			openid.ClientSecret(values["client-secret"])
+		}
+		builder.Type("OpenIDIdentityProvider").OpenID(openid)
+	default:
+		return nil, fmt.Errorf("Editing %s identity providers isn't supported", idpType)
+	}
+	return builder.Build()
+}
+
+// diffValues returns a line for each field that changes. The values of secrets and files aren't shown.
+func diffValues(idpType string, current map[string]string, desired map[string]string) []string {
+	var lines []string
+	for _, flag := range fieldsByType[idpType] {
+		f := getField(flag)
+		before := current[flag]
+		after := desired[flag]
+		switch {
+		case f.secret:
+			if after != "" {
+				lines = append(lines, fmt.Sprintf("%s: changed", flag))
+			}
+		case before == after:
+		case f.file && after == "":
+			lines = append(lines, fmt.Sprintf("%s: removed", flag))
+		case f.file:
+			lines = append(lines, fmt.Sprintf("%s: changed", flag))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: '%s' -> '%s'", flag, before, after))
+		}
+	}
+	return lines
+}
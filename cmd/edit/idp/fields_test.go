@@ -0,0 +1,125 @@
+package idp
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Fields", func() {
+	buildGithub := func() *cmv1.IdentityProvider {
+		idp, err := cmv1.NewIdentityProvider().
+			Type("GithubIdentityProvider").
+			Name("github-1").
+			MappingMethod(cmv1.IdentityProviderMappingMethodClaim).
+			Github(cmv1.NewGithubIdentityProvider().
+				ClientID("client").
+				Organizations("myorg")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return idp
+	}
+
+	It("Edits the organizations of a GitHub identity provider", func() {
+		idp := buildGithub()
+		current := getValues(idp)
+		Expect(current).To(HaveKeyWithValue("organizations", "myorg"))
+		Expect(current).ToNot(HaveKey("client-secret"))
+
+		desired := getValues(idp)
+		desired["organizations"] = "myorg,otherorg"
+		desired["client-secret"] = "secret"
+		update, err := buildIDP(ocm.GithubIDPType, desired)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(update.Github().Organizations()).To(Equal([]string{"myorg", "otherorg"}))
+		Expect(update.Github().ClientSecret()).To(Equal("secret"))
+		Expect(update.MappingMethod()).To(Equal(cmv1.IdentityProviderMappingMethodClaim))
+
+		Expect(diffValues(ocm.GithubIDPType, current, desired)).To(Equal([]string{
+			"client-secret: changed",
+			"organizations: 'myorg' -> 'myorg,otherorg'",
+		}))
+	})
+
+	It("Keeps the current secrets when they aren't given", func() {
+		update, err := buildIDP(ocm.GithubIDPType, getValues(buildGithub()))
+		Expect(err).ToNot(HaveOccurred())
+		_, ok := update.Github().GetClientSecret()
+		Expect(ok).To(BeFalse())
+		Expect(diffValues(ocm.GithubIDPType, getValues(buildGithub()), getValues(buildGithub()))).To(BeEmpty())
+	})
+
+	It("Validates the GitHub organizations and teams", func() {
+		desired := getValues(buildGithub())
+		desired["teams"] = "myorg/myteam"
+		_, err := buildIDP(ocm.GithubIDPType, desired)
+		Expect(err).To(MatchError(ContainSubstring("either organizations or teams")))
+
+		desired["organizations"] = ""
+		desired["teams"] = "myteam"
+		_, err = buildIDP(ocm.GithubIDPType, desired)
+		Expect(err).To(MatchError(ContainSubstring("<org>/<team>")))
+	})
+
+	It("Edits the CA and bind password of an LDAP identity provider", func() {
+		idp, err := cmv1.NewIdentityProvider().
+			Type("LDAPIdentityProvider").
+			Name("ldap-1").
+			MappingMethod(cmv1.IdentityProviderMappingMethodClaim).
+			LDAP(cmv1.NewLDAPIdentityProvider().
+				URL("ldap://ldap.example.com/ou=users,dc=example,dc=com?uid").
+				Insecure(true).
+				BindDN("cn=admin").
+				Attributes(cmv1.NewLDAPAttributes().ID("dn").PreferredUsername("uid"))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		current := getValues(idp)
+		Expect(current).To(HaveKeyWithValue("insecure", "true"))
+		Expect(current).To(HaveKeyWithValue("username-attributes", "uid"))
+
+		desired := getValues(idp)
+		desired["ca"] = "-----BEGIN CERTIFICATE-----"
+		_, err = buildIDP(ocm.LDAPIDPType, desired)
+		Expect(err).To(MatchError("Cannot use certificate bundle with an insecure connection"))
+
+		desired["insecure"] = "false"
+		desired["bind-password"] = "password"
+		update, err := buildIDP(ocm.LDAPIDPType, desired)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(update.LDAP().CA()).To(Equal("-----BEGIN CERTIFICATE-----"))
+		Expect(update.LDAP().BindPassword()).To(Equal("password"))
+		Expect(update.LDAP().Attributes().PreferredUsername()).To(Equal([]string{"uid"}))
+		Expect(diffValues(ocm.LDAPIDPType, current, desired)).To(Equal([]string{
+			"insecure: 'true' -> 'false'",
+			"ca: changed",
+			"bind-password: changed",
+		}))
+	})
+
+	It("Requires a claim in OpenID identity providers", func() {
+		desired := map[string]string{
+			"mapping-method": "claim",
+			"client-id":      "client",
+			"issuer-url":     "https://issuer.example.com",
+		}
+		_, err := buildIDP(ocm.OpenIDIDPType, desired)
+		Expect(err).To(MatchError(ContainSubstring("At least one claim is required")))
+
+		desired["email-claims"] = "email"
+		desired["extra-scopes"] = "profile,email"
+		update, err := buildIDP(ocm.OpenIDIDPType, desired)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(update.OpenID().Claims().Email()).To(Equal([]string{"email"}))
+		Expect(update.OpenID().ExtraScopes()).To(Equal([]string{"profile", "email"}))
+	})
+
+	It("Rejects invalid mapping methods", func() {
+		desired := getValues(buildGithub())
+		desired["mapping-method"] = "merge"
+		_, err := buildIDP(ocm.GithubIDPType, desired)
+		Expect(err).To(MatchError(ContainSubstring("Expected a valid mapping method")))
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/htpasswd"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// syncHTPasswdUsers adds, updates and removes the users of an htpasswd identity provider to match a file.
+// Admin users are left alone.
+func syncHTPasswdUsers(r *rosa.Runtime, cluster *cmv1.Cluster, idp *cmv1.IdentityProvider) {
+	if idp.Htpasswd().Username() != "" {
+		r.Reporter.Errorf("Users can't be added to a single user HTPasswd IDP. Delete the IDP and recreate " +
+			"it as a multi user HTPasswd IDP")
+		os.Exit(1)
+	}
+
+	desired, err := htpasswd.ParseFile(args.htpasswdSync)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	admins, err := r.OCMClient.GetUsers(cluster.ID(), ocm.ClusterAdminsGroup)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get %s of cluster '%s': %v", ocm.ClusterAdminsGroup, r.ClusterKey, err)
+		os.Exit(1)
+	}
+	isAdmin := make(map[string]bool)
+	isAdmin[idpPack.ClusterAdminUsername] = true
+	for _, admin := range admins {
+		isAdmin[admin.ID()] = true
+	}
+	for _, user := range desired {
+		if isAdmin[user.Username] {
+			r.Reporter.Errorf("User '%s' is an admin user, manage it with 'rosa edit admin'", user.Username)
+			os.Exit(1)
+		}
+	}
+
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), idp.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the users of identity provider '%s': %v", idp.Name(), err)
+		os.Exit(1)
+	}
+	var existing []string
+	userIDs := make(map[string]string)
+	userList.Each(func(user *cmv1.HTPasswdUser) bool {
+		existing = append(existing, user.Username())
+		userIDs[user.Username()] = user.ID()
+		return true
+	})
+
+	changes := htpasswd.Diff(existing, desired, func(username string) bool {
+		return isAdmin[username]
+	})
+	if changes.Empty() {
+		r.Reporter.Infof("The users of identity provider '%s' already match '%s'", idp.Name(), args.htpasswdSync)
+		return
+	}
+	for _, user := range changes.Add {
+		r.Reporter.Infof("Add user '%s'", user.Username)
+	}
+	for _, user := range changes.Update {
+		r.Reporter.Infof("Update the password of user '%s'", user.Username)
+	}
+	for _, username := range changes.Remove {
+		r.Reporter.Infof("Remove user '%s'", username)
+	}
+	if args.dryRun {
+		return
+	}
+	if !confirm.Confirm("apply these changes to identity provider '%s' of cluster '%s'", idp.Name(),
+		r.ClusterKey) {
+		os.Exit(0)
+	}
+
+	// Users are added before others are removed, so that the identity provider is never empty
+	failed := false
+	for _, user := range changes.Add {
+		err = r.OCMClient.AddHTPasswdUserSpec(cluster.ID(), idp.ID(), user)
+		if err != nil {
+			r.Reporter.Errorf("Failed to add user '%s': %v", user.Username, err)
+			failed = true
+		}
+	}
+	for _, user := range changes.Update {
+		err = r.OCMClient.UpdateHTPasswdUserSpec(cluster.ID(), idp.ID(), userIDs[user.Username], user)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update user '%s': %v", user.Username, err)
+			failed = true
+		}
+	}
+	for _, username := range changes.Remove {
+		err = r.OCMClient.DeleteHTPasswdUserByID(cluster.ID(), idp.ID(), userIDs[username])
+		if err != nil {
+			r.Reporter.Errorf("Failed to remove user '%s': %v", username, err)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Synchronized the users of identity provider '%s' of cluster '%s': %d added, "+
+		"%d updated and %d removed", idp.Name(), r.ClusterKey, len(changes.Add), len(changes.Update),
+		len(changes.Remove))
+}
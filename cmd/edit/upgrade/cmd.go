@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/pkg/cron"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	schedule string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "upgrade",
+	Aliases: []string{"upgrades"},
+	Short:   "Edit the automatic upgrade schedule of a cluster",
+	Long:    "Change the cron expression of the policy that upgrades the cluster automatically.",
+	Example: `  # Upgrade the cluster named "mycluster" every Saturday at 22:30 UTC
+  rosa edit upgrade -c mycluster --schedule "30 22 * * SAT"`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC that schedules recurring automatic upgrades, for example '0 2 * * SUN'.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	c := r.FetchCluster()
+	if c.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	automaticUpgrade, err := r.OCMClient.GetAutomaticUpgradePolicy(c.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get automatic upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if automaticUpgrade == nil {
+		r.Reporter.Errorf("Cluster '%s' isn't upgraded automatically. To schedule automatic upgrades, "+
+			"run 'rosa upgrade cluster -c %s --schedule'", clusterKey, clusterKey)
+		os.Exit(1)
+	}
+
+	schedule := args.schedule
+	if schedule == "" || interactive.Enabled() {
+		if schedule == "" {
+			schedule = automaticUpgrade.Schedule()
+		}
+		schedule, err = interactive.GetString(interactive.Input{
+			Question: "Upgrade schedule",
+			Help:     cmd.Flags().Lookup("schedule").Usage,
+			Default:  schedule,
+			Required: true,
+			Validators: []interactive.Validator{
+				ocm.ValidateUpgradeSchedule,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid schedule: %s", err)
+			os.Exit(1)
+		}
+	}
+	parsedSchedule, err := cron.Parse(schedule)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if parsedSchedule.String() == automaticUpgrade.Schedule() {
+		r.Reporter.Infof("Cluster '%s' is already upgraded with schedule '%s'", clusterKey, schedule)
+		return
+	}
+	if !cluster.PrintNextRuns(r, parsedSchedule) {
+		r.Reporter.Errorf("Schedule '%s' never runs", schedule)
+		os.Exit(1)
+	}
+	if !confirm.Confirm("change the upgrade schedule of cluster '%s' to '%s'", clusterKey, schedule) {
+		os.Exit(0)
+	}
+
+	upgradePolicy, err := cmv1.NewUpgradePolicy().
+		Schedule(parsedSchedule.String()).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to update the upgrade schedule of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	_, err = r.OCMClient.UpdateUpgradePolicy(c.ID(), automaticUpgrade.ID(), upgradePolicy)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update the upgrade schedule of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Updated the upgrade schedule of cluster '%s'", clusterKey)
+}
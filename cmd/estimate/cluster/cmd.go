@@ -0,0 +1,309 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cost"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	multiAZ                   bool
+	private                   bool
+	subnetIDs                 []string
+	controlPlaneMachineType   string
+	infraMachineType          string
+	computeMachineType        string
+	computeNodes              int
+	autoscalingEnabled        bool
+	maxReplicas               int
+	controlPlaneVolumeSizeGiB int
+	infraVolumeSizeGiB        int
+	computeVolumeSizeGiB      int
+	useSpotInstances          bool
+	pricingFile               string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Estimate the cost of a cluster",
+	Long: "Estimate the hourly and monthly AWS infrastructure cost of a cluster before creating it. " +
+		"The estimate includes the instances, root volumes, NAT gateways and load balancers of the cluster, " +
+		"priced from an embedded table of on-demand prices. Data transfer, load balancer capacity units and " +
+		"the OpenShift service fee aren't included.",
+	Example: `  # Estimate the cost of a cluster with the default options
+  rosa estimate cluster --region=us-east-1
+
+  # Estimate the cost of a multi-AZ cluster with up to 12 r5.2xlarge compute nodes
+  rosa estimate cluster --multi-az --compute-machine-type=r5.2xlarge --enable-autoscaling --max-replicas=12
+
+  # Estimate the cost of compute nodes running on spot instances at the current spot price
+  rosa estimate cluster --use-spot-instances
+
+  # Estimate the cost with an updated pricing table
+  rosa estimate cluster --pricing-file=pricing.json`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	arguments.AddRegionFlag(flags)
+	arguments.AddProfileFlag(flags)
+
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Estimate the cost of a cluster deployed to multiple data centers.",
+	)
+	flags.BoolVar(
+		&args.private,
+		"private",
+		false,
+		"Estimate the cost of a cluster with a private API endpoint.",
+	)
+	flags.StringSliceVar(
+		&args.subnetIDs,
+		"subnet-ids",
+		nil,
+		"The Subnet IDs the cluster will be installed into. When set, the cost of the NAT gateways isn't included.",
+	)
+	flags.StringVar(
+		&args.computeMachineType,
+		"compute-machine-type",
+		ocm.DefaultComputeMachineType,
+		"Instance type for the compute nodes.",
+	)
+	flags.IntVar(
+		&args.computeNodes,
+		"replicas",
+		0,
+		"Number of compute nodes. Defaults to 2 for single zone clusters and 3 for multizone clusters.",
+	)
+	flags.BoolVar(
+		&args.autoscalingEnabled,
+		"enable-autoscaling",
+		false,
+		"Estimate the cost of the maximum number of compute nodes.",
+	)
+	flags.IntVar(
+		&args.maxReplicas,
+		"max-replicas",
+		0,
+		"Maximum number of compute nodes when autoscaling is enabled.",
+	)
+	flags.IntVar(
+		&args.computeVolumeSizeGiB,
+		"compute-volume-size",
+		ocm.DefaultComputeVolumeSizeGiB,
+		"Size in GiB of the root volume of the compute nodes.",
+	)
+	flags.StringVar(
+		&args.controlPlaneMachineType,
+		"control-plane-machine-type",
+		ocm.DefaultControlPlaneMachineType,
+		"Instance type for the control plane nodes.",
+	)
+	flags.IntVar(
+		&args.controlPlaneVolumeSizeGiB,
+		"control-plane-volume-size",
+		ocm.DefaultControlPlaneVolumeSizeGiB,
+		"Size in GiB of the root volume of the control plane nodes.",
+	)
+	flags.StringVar(
+		&args.infraMachineType,
+		"infra-machine-type",
+		ocm.DefaultInfraMachineType,
+		"Instance type for the infra nodes.",
+	)
+	flags.IntVar(
+		&args.infraVolumeSizeGiB,
+		"infra-volume-size",
+		ocm.DefaultInfraVolumeSizeGiB,
+		"Size in GiB of the root volume of the infra nodes.",
+	)
+	flags.BoolVar(
+		&args.useSpotInstances,
+		"use-spot-instances",
+		false,
+		"Price the compute nodes at the current spot price of the region instead of the on-demand price.",
+	)
+	flags.StringVar(
+		&args.pricingFile,
+		"pricing-file",
+		"",
+		"Path to a JSON file with the prices to use instead of the embedded pricing table.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		r.Reporter.Errorf("Error getting region: %v", err)
+		os.Exit(1)
+	}
+
+	defaultNodes := ocm.GetDefaultNodes(args.multiAZ)
+	computeNodes := args.computeNodes
+	if args.autoscalingEnabled {
+		computeNodes = args.maxReplicas
+		if computeNodes == 0 {
+			r.Reporter.Errorf("The maximum number of replicas is required when autoscaling is enabled")
+			os.Exit(1)
+		}
+	}
+	if computeNodes == 0 {
+		computeNodes = defaultNodes
+	}
+	if computeNodes < defaultNodes {
+		r.Reporter.Errorf("The number of compute nodes needs to be at least %d", defaultNodes)
+		os.Exit(1)
+	}
+
+	// Validate the instance types against the machine types supported by the service
+	machineTypes, err := r.OCMClient.GetAvailableMachineTypes()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine types: %v", err)
+		os.Exit(1)
+	}
+	err = machineTypes.ValidateMachineType(args.computeMachineType, args.multiAZ)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	for _, machineType := range []string{args.controlPlaneMachineType, args.infraMachineType} {
+		if machineTypes.Find(machineType) == nil {
+			r.Reporter.Errorf("Machine type '%s' is not supported", machineType)
+			os.Exit(1)
+		}
+	}
+
+	pricing, err := cost.LoadPricing(args.pricingFile)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	computePool := cost.MachinePool{
+		Name:          "compute",
+		InstanceType:  args.computeMachineType,
+		Replicas:      computeNodes,
+		VolumeSizeGiB: args.computeVolumeSizeGiB,
+	}
+	if args.useSpotInstances {
+		r.AWSClient, err = aws.NewClient().
+			Logger(r.Logger).
+			Region(region).
+			Build()
+		if err != nil {
+			r.Reporter.Errorf("Error creating AWS client: %v", err)
+			os.Exit(1)
+		}
+		spotPrices, err := r.AWSClient.GetSpotPriceHistory(args.computeMachineType, nil, time.Now())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get spot prices of instance type '%s': %v", args.computeMachineType, err)
+			os.Exit(1)
+		}
+		spotPrice, ok := aws.GetCurrentSpotPrice(spotPrices)
+		if !ok {
+			r.Reporter.Errorf("Instance type '%s' isn't offered as a spot instance in region '%s'",
+				args.computeMachineType, region)
+			os.Exit(1)
+		}
+		computePool.SpotPrice = &spotPrice
+	}
+
+	estimate, err := pricing.Estimate(&cost.ClusterSpec{
+		Region:  region,
+		MultiAZ: args.multiAZ,
+		Private: args.private,
+		BYOVPC:  len(args.subnetIDs) > 0,
+		MachinePools: []cost.MachinePool{
+			{
+				Name:          "control-plane",
+				InstanceType:  args.controlPlaneMachineType,
+				Replicas:      ocm.ControlPlaneReplicas,
+				VolumeSizeGiB: args.controlPlaneVolumeSizeGiB,
+			},
+			{
+				Name:          "infra",
+				InstanceType:  args.infraMachineType,
+				Replicas:      defaultNodes,
+				VolumeSizeGiB: args.infraVolumeSizeGiB,
+			},
+			computePool,
+		},
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to estimate the cost of the cluster: %v", err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(estimate)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	printEstimate(estimate)
+	r.Reporter.Infof("Estimated AWS infrastructure cost: %.2f %s/hour, %.2f %s/month",
+		estimate.Hourly, estimate.Currency, estimate.Monthly, estimate.Currency)
+	if args.autoscalingEnabled {
+		r.Reporter.Infof("The estimate assumes the cluster runs the maximum number of compute nodes")
+	}
+	r.Reporter.Infof("Prices as of %s. Data transfer, load balancer capacity units and the OpenShift "+
+		"service fee aren't included.", estimate.PricesUpdated)
+}
+
+func printEstimate(estimate *cost.Estimate) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "NAME\tRESOURCE\tQUANTITY\tUNIT PRICE\tHOURLY\tMONTHLY\n")
+	for _, item := range estimate.Items {
+		fmt.Fprintf(writer, "%s\t%s\t%g\t%.4f/%s\t%.2f\t%.2f\n",
+			item.Name,
+			item.Resource,
+			item.Quantity,
+			item.UnitPrice,
+			item.Unit,
+			item.Hourly,
+			item.Monthly,
+		)
+	}
+	fmt.Fprintf(writer, "TOTAL\t\t\t\t%.2f\t%.2f\n", estimate.Hourly, estimate.Monthly)
+	writer.Flush()
+}
@@ -180,8 +180,8 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	// Validate AWS quota
-	// Call `verify quota` as part of init
-	err = quota.Cmd.RunE(cmd, argv)
+	// Check the minimum quota needed to install clusters
+	err = quota.ValidateMinimumQuota(r, client)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
 		os.Exit(1)
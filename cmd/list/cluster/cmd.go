@@ -20,20 +20,35 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	limitedSupport bool
+	expiringWithin string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "clusters",
 	Aliases: []string{"cluster"},
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # List the clusters that are in limited support
+  rosa list clusters --limited-support
+
+  # List the clusters that will be deleted automatically within the next week
+  rosa list clusters --expiring-within 7d`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -42,6 +57,19 @@ func init() {
 	flags := Cmd.Flags()
 	flags.SortFlags = false
 
+	flags.BoolVar(
+		&args.limitedSupport,
+		"limited-support",
+		false,
+		"List only the clusters that are in limited support.",
+	)
+	flags.StringVar(
+		&args.expiringWithin,
+		"expiring-within",
+		"",
+		"List only the clusters that expire within the given duration, like 24h or 7d.",
+	)
+
 	output.AddFlag(Cmd)
 }
 
@@ -49,6 +77,16 @@ func run(_ *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
+	var expiringWithin time.Duration
+	if args.expiringWithin != "" {
+		var err error
+		expiringWithin, err = helper.ParseDuration(args.expiringWithin)
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid duration for 'expiring-within': %v", err)
+			os.Exit(1)
+		}
+	}
+
 	// Retrieve the list of clusters:
 	clusters, err := r.OCMClient.GetClusters(r.Creator, 1000)
 	if err != nil {
@@ -56,6 +94,26 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.limitedSupport {
+		var limitedSupportClusters []*cmv1.Cluster
+		for _, cluster := range clusters {
+			if cluster.Status().LimitedSupportReasonCount() > 0 {
+				limitedSupportClusters = append(limitedSupportClusters, cluster)
+			}
+		}
+		clusters = limitedSupportClusters
+	}
+	if args.expiringWithin != "" {
+		now := time.Now()
+		var expiringClusters []*cmv1.Cluster
+		for _, cluster := range clusters {
+			if ocm.IsExpiringWithin(cluster, expiringWithin, now) {
+				expiringClusters = append(expiringClusters, cluster)
+			}
+		}
+		clusters = expiringClusters
+	}
+
 	if output.HasFlag() {
 		err = output.Print(clusters)
 		if err != nil {
@@ -66,21 +124,35 @@ func run(_ *cobra.Command, _ []string) {
 	}
 
 	if len(clusters) == 0 {
-		r.Reporter.Infof("No clusters available")
+		if args.limitedSupport {
+			r.Reporter.Infof("No clusters in limited support")
+		} else if args.expiringWithin != "" {
+			r.Reporter.Infof("No clusters expiring within %s", args.expiringWithin)
+		} else {
+			r.Reporter.Infof("No clusters available")
+		}
 		os.Exit(0)
 	}
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tNAME\tSTATE\n")
+	header := "ID\tNAME\tSTATE"
+	if args.limitedSupport {
+		header += "\tLIMITED SUPPORT REASONS"
+	}
+	if args.expiringWithin != "" {
+		header += "\tEXPIRATION"
+	}
+	fmt.Fprintf(writer, "%s\n", header)
 	for _, cluster := range clusters {
-		fmt.Fprintf(
-			writer,
-			"%s\t%s\t%s\n",
-			cluster.ID(),
-			cluster.Name(),
-			cluster.State(),
-		)
+		line := fmt.Sprintf("%s\t%s\t%s", cluster.ID(), cluster.Name(), cluster.State())
+		if args.limitedSupport {
+			line += fmt.Sprintf("\t%d", cluster.Status().LimitedSupportReasonCount())
+		}
+		if args.expiringWithin != "" {
+			line += "\t" + cluster.ExpirationTimestamp().Format("2006-01-02 15:04 MST")
+		}
+		fmt.Fprintf(writer, "%s\n", line)
 	}
 	writer.Flush()
 }
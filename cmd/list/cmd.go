@@ -24,12 +24,15 @@ import (
 	"github.com/openshift/rosa/cmd/list/cluster"
 	"github.com/openshift/rosa/cmd/list/gates"
 	"github.com/openshift/rosa/cmd/list/idp"
+	"github.com/openshift/rosa/cmd/list/idpusers"
 	"github.com/openshift/rosa/cmd/list/ingress"
 	"github.com/openshift/rosa/cmd/list/instancetypes"
+	"github.com/openshift/rosa/cmd/list/limitedsupportreasons"
 	"github.com/openshift/rosa/cmd/list/machinepool"
 	"github.com/openshift/rosa/cmd/list/ocmroles"
 	"github.com/openshift/rosa/cmd/list/region"
 	"github.com/openshift/rosa/cmd/list/service"
+	"github.com/openshift/rosa/cmd/list/spotprices"
 	"github.com/openshift/rosa/cmd/list/upgrade"
 	"github.com/openshift/rosa/cmd/list/user"
 	"github.com/openshift/rosa/cmd/list/userroles"
@@ -48,7 +51,9 @@ func init() {
 	Cmd.AddCommand(cluster.Cmd)
 	Cmd.AddCommand(gates.Cmd)
 	Cmd.AddCommand(idp.Cmd)
+	Cmd.AddCommand(idpusers.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(limitedsupportreasons.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
 	Cmd.AddCommand(region.Cmd)
 	Cmd.AddCommand(upgrade.Cmd)
@@ -59,6 +64,7 @@ func init() {
 	Cmd.AddCommand(ocmroles.Cmd)
 	Cmd.AddCommand(userroles.Cmd)
 	Cmd.AddCommand(service.Cmd)
+	Cmd.AddCommand(spotprices.Cmd)
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
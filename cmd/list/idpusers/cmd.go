@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idpusers
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	idpName string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "idp-users",
+	Aliases: []string{"idp-user", "idpusers"},
+	Short:   "List the users of an HTPasswd IDP",
+	Long:    "List the users of the HTPasswd identity provider of a cluster.",
+	Example: `  # List the users of the HTPasswd identity provider of the cluster named "mycluster"
+  rosa list idp-users --cluster=mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	Cmd.Flags().StringVar(
+		&args.idpName,
+		"idp",
+		"",
+		"Name of the HTPasswd identity provider. Defaults to the HTPasswd identity provider of the cluster.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	var idp *cmv1.IdentityProvider
+	for _, item := range idps {
+		if ocm.IdentityProviderType(item) != ocm.HTPasswdIDPType {
+			continue
+		}
+		if args.idpName == "" || item.Name() == args.idpName {
+			idp = item
+		}
+	}
+	if idp == nil {
+		if args.idpName != "" {
+			r.Reporter.Errorf("Cluster '%s' doesn't have an HTPasswd identity provider named '%s'",
+				clusterKey, args.idpName)
+		} else {
+			r.Reporter.Errorf("Cluster '%s' doesn't have an HTPasswd identity provider", clusterKey)
+		}
+		os.Exit(1)
+	}
+
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), idp.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the users of identity provider '%s': %v", idp.Name(), err)
+		os.Exit(1)
+	}
+	users := userList.Slice()
+	// Single user identity providers created by older versions of rosa don't list their user
+	if username := idp.Htpasswd().Username(); username != "" {
+		user, err := cmv1.NewHTPasswdUser().Username(username).Build()
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		users = append(users, user)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(users)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if len(users) == 0 {
+		r.Reporter.Infof("Identity provider '%s' of cluster '%s' has no users", idp.Name(), clusterKey)
+		os.Exit(0)
+	}
+
+	admins, err := r.OCMClient.GetUsers(cluster.ID(), ocm.ClusterAdminsGroup)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get %s of cluster '%s': %v", ocm.ClusterAdminsGroup, clusterKey, err)
+		os.Exit(1)
+	}
+	isAdmin := make(map[string]bool)
+	for _, admin := range admins {
+		isAdmin[admin.ID()] = true
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "USERNAME\tCLUSTER ADMIN\n")
+	for _, user := range users {
+		admin := "no"
+		if isAdmin[user.Username()] {
+			admin = "yes"
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", user.Username(), admin)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitedsupportreasons
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "limited-support-reasons",
+	Aliases: []string{"limited-support-reason", "limited-support"},
+	Short:   "List limited support reasons",
+	Long: "List the reasons why a cluster is in limited support. A cluster in limited support " +
+		"isn't fully supported by Red Hat until the reasons are addressed.",
+	Example: `  # List the limited support reasons of the cluster named "mycluster"
+  rosa list limited-support-reasons --cluster=mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Loading limited support reasons for cluster '%s'", clusterKey)
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(reasons)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if len(reasons) == 0 {
+		r.Reporter.Infof("Cluster '%s' is not in limited support", clusterKey)
+		os.Exit(0)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tSUMMARY\tDETECTION TYPE\tCREATED\tDETAILS\n")
+	for _, reason := range reasons {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			reason.ID(),
+			reason.Summary(),
+			reason.DetectionType(),
+			reason.CreationTimestamp().UTC().Format("2006-01-02 15:04 MST"),
+			reason.Details(),
+		)
+	}
+	writer.Flush()
+}
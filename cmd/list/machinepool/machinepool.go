@@ -6,7 +6,10 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -47,13 +50,16 @@ func listMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster)
 		os.Exit(0)
 	}
 
+	zoneTypes := getZoneTypes(r, cluster, machinePools)
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	fmt.Fprintf(writer,
-		"ID\tAUTOSCALING\tREPLICAS\tINSTANCE TYPE\tLABELS\t\tTAINTS\t\tAVAILABILITY ZONES\t\tSUBNETS\t\tSPOT INSTANCES\n")
+		"ID\tAUTOSCALING\tREPLICAS\tINSTANCE TYPE\tLABELS\t\tTAINTS\t\tAVAILABILITY ZONES\t\tZONE TYPE\t\t"+
+			"SUBNETS\t\tSPOT INSTANCES\n")
 	for _, machinePool := range machinePools {
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\t%s\t\t%s\t\t%s\t\t%s\n",
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\t%s\t\t%s\t\t%s\t\t%s\t\t%s\n",
 			machinePool.ID(),
 			printMachinePoolAutoscaling(machinePool.Autoscaling()),
 			printMachinePoolReplicas(machinePool.Autoscaling(), machinePool.Replicas()),
@@ -61,6 +67,7 @@ func listMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster)
 			printLabels(machinePool.Labels()),
 			printTaints(machinePool.Taints()),
 			printStringSlice(machinePool.AvailabilityZones()),
+			printZoneType(machinePool.AvailabilityZones(), zoneTypes),
 			printStringSlice(machinePool.Subnets()),
 			printSpot(machinePool),
 		)
@@ -68,6 +75,49 @@ func listMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster)
 	writer.Flush()
 }
 
+// getZoneTypes resolves the type of the zones used by the machine pools. The zones of the cluster are
+// regular availability zones, so AWS is only queried for other zones, like Local Zones.
+func getZoneTypes(r *rosa.Runtime, cluster *cmv1.Cluster, machinePools []*cmv1.MachinePool) map[string]string {
+	zoneTypes := make(map[string]string)
+	for _, zone := range cluster.Nodes().AvailabilityZones() {
+		zoneTypes[zone] = aws.AvailabilityZoneType
+	}
+
+	var otherZones []string
+	for _, machinePool := range machinePools {
+		for _, zone := range machinePool.AvailabilityZones() {
+			if _, ok := zoneTypes[zone]; !ok && !helper.Contains(otherZones, zone) {
+				otherZones = append(otherZones, zone)
+			}
+		}
+	}
+	if len(otherZones) == 0 {
+		return zoneTypes
+	}
+
+	zones, err := r.AWSClient.GetAvailabilityZones(otherZones)
+	if err != nil {
+		r.Reporter.Warnf("Failed to get zone types: %v", err)
+		return zoneTypes
+	}
+	for _, zone := range zones {
+		zoneTypes[awssdk.StringValue(zone.ZoneName)] = awssdk.StringValue(zone.ZoneType)
+	}
+
+	return zoneTypes
+}
+
+func printZoneType(zones []string, zoneTypes map[string]string) string {
+	var types []string
+	for _, zone := range zones {
+		zoneType := zoneTypes[zone]
+		if zoneType != "" && !helper.Contains(types, zoneType) {
+			types = append(types, zoneType)
+		}
+	}
+	return printStringSlice(types)
+}
+
 func printMachinePoolAutoscaling(autoscaling *cmv1.MachinePoolAutoscaling) string {
 	if autoscaling != nil {
 		return "Yes"
@@ -6,6 +6,8 @@ import (
 	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -18,12 +20,32 @@ func listNodePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) {
 		os.Exit(1)
 	}
 
+	if output.HasFlag() {
+		err = output.Print(nodePools)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// The versions and upgrades are only informative, so failing to get them leaves their columns empty
+	versions, err := r.OCMClient.GetNodePoolVersions(cluster.ID(), cluster.Version().ChannelGroup())
+	if err != nil {
+		r.Reporter.Warnf("Failed to get machine pool versions for cluster '%s': %v", clusterKey, err)
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-	fmt.Fprintf(writer, "ID\tAUTOSCALING\tREPLICAS\tINSTANCE TYPE\tAVAILABILITY ZONE\tSUBNET\tNODEPOOL\t\n")
+	fmt.Fprintf(writer, "ID\tAUTOSCALING\tREPLICAS\tINSTANCE TYPE\tAVAILABILITY ZONE\tSUBNET\tNODEPOOL\t"+
+		"VERSION\tUPGRADE\t\n")
 	for _, nodePool := range nodePools {
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+		scheduledUpgrade, err := r.OCMClient.GetScheduledNodePoolUpgrade(cluster.ID(), nodePool.ID())
+		if err != nil {
+			r.Reporter.Warnf("Failed to get scheduled upgrades for machine pool '%s': %v", nodePool.ID(), err)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
 			nodePool.ID(),
 			printNodePoolAutoscaling(nodePool.Autoscaling()),
 			printNodePoolReplicas(nodePool.Autoscaling(), nodePool.Replicas()),
@@ -31,6 +53,8 @@ func listNodePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) {
 			nodePool.AvailabilityZone(),
 			nodePool.Subnet(),
 			printNodePoolName(nodePool.AWSNodePool()),
+			versions[nodePool.ID()],
+			printNodePoolUpgrade(scheduledUpgrade),
 		)
 	}
 	writer.Flush()
@@ -69,3 +93,14 @@ func printNodePoolName(aws *cmv1.AWSNodePool) string {
 	}
 	return ""
 }
+
+func printNodePoolUpgrade(upgradePolicy *ocm.NodePoolUpgradePolicy) string {
+	if upgradePolicy == nil {
+		return ""
+	}
+	state := "scheduled"
+	if upgradePolicy.State != nil && upgradePolicy.State.Value != "" {
+		state = upgradePolicy.State.Value
+	}
+	return fmt.Sprintf("%s %s", upgradePolicy.Version, state)
+}
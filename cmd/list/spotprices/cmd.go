@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotprices
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	instanceType string
+	days         int
+}
+
+var Cmd = &cobra.Command{
+	Use:     "spot-prices",
+	Aliases: []string{"spotprices", "spot-price"},
+	Short:   "List spot price history",
+	Long: "List a summary of the spot price history of an instance type in the availability zones of a " +
+		"cluster, together with the on-demand price and an estimate of the interruption risk.",
+	Example: `  # List the spot prices of r5.2xlarge instances for a cluster named "mycluster"
+  rosa list spot-prices --cluster=mycluster --instance-type=r5.2xlarge
+
+  # List the spot prices of the last 30 days
+  rosa list spot-prices --cluster=mycluster --instance-type=r5.2xlarge --days=30`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.instanceType,
+		"instance-type",
+		"",
+		"Instance type to list the spot prices of.",
+	)
+	Cmd.MarkFlagRequired("instance-type")
+	flags.IntVar(
+		&args.days,
+		"days",
+		7,
+		"Number of days of price history to summarize. AWS keeps up to 90 days of history.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	if args.days < 1 || args.days > 90 {
+		r.Reporter.Errorf("The number of days must be between 1 and 90")
+		os.Exit(1)
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	// Initiate the AWS client with the cluster's region
+	var err error
+	r.AWSClient, err = aws.NewClient().
+		Region(cluster.Region().ID()).
+		Logger(r.Logger).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create awsClient: %s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Fetching spot prices of instance type '%s' for cluster '%s'", args.instanceType, clusterKey)
+	summaries, err := r.AWSClient.GetSpotPriceSummaries(cluster.Region().ID(),
+		cluster.Nodes().AvailabilityZones(), args.instanceType, args.days)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get spot prices of instance type '%s': %v", args.instanceType, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(summaries)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if len(summaries) == 0 {
+		r.Reporter.Warnf("Instance type '%s' isn't offered as a spot instance in the availability zones "+
+			"of cluster '%s'", args.instanceType, clusterKey)
+		os.Exit(1)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "AVAILABILITY ZONE\tCURRENT\tP50\tP90\tMAX\tON-DEMAND\tINTERRUPTION RISK\n")
+	for _, summary := range summaries {
+		onDemand := "N/A"
+		if summary.OnDemand != nil {
+			onDemand = fmt.Sprintf("%.4f", *summary.OnDemand)
+		}
+		fmt.Fprintf(writer, "%s\t%.4f\t%.4f\t%.4f\t%.4f\t%s\t%s\n",
+			summary.AvailabilityZone,
+			summary.Current,
+			summary.P50,
+			summary.P90,
+			summary.Max,
+			onDemand,
+			summary.InterruptionRisk,
+		)
+	}
+	writer.Flush()
+
+	maxPrice, ok := aws.RecommendSpotMaxPrice(summaries)
+	if ok {
+		r.Reporter.Infof("Recommended spot max price based on the p%d of the last %d days: %.4f. "+
+			"Use '--spot-max-price=auto' when creating a machine pool to apply it.",
+			aws.SpotMaxPricePercentile, args.days, maxPrice)
+	} else {
+		r.Reporter.Infof("Spot prices reach the on-demand price, the on-demand price is recommended as spot max price")
+	}
+	r.Reporter.Infof("The interruption risk is estimated from the price history and isn't provided by AWS")
+}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
@@ -30,16 +31,32 @@ import (
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	history bool
+}
+
 var Cmd = &cobra.Command{
 	Use:     "upgrades",
 	Aliases: []string{"upgrade"},
 	Short:   "List available cluster upgrades",
 	Long:    "List available and scheduled cluster version upgrades",
-	Run:     run,
+	Example: `  # List the available upgrades of the cluster named "mycluster"
+  rosa list upgrades -c mycluster
+
+  # List the past and current upgrades of the cluster named "mycluster"
+  rosa list upgrades -c mycluster --history`,
+	Run: run,
 }
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
+
+	Cmd.Flags().BoolVar(
+		&args.history,
+		"history",
+		false,
+		"List the past and current upgrades of the cluster instead of the available upgrades.",
+	)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -49,6 +66,10 @@ func run(_ *cobra.Command, _ []string) {
 	clusterKey := r.GetClusterKey()
 
 	cluster := r.FetchCluster()
+	if args.history {
+		listHistory(r, clusterKey, cluster)
+		return
+	}
 	if cluster.State() != cmv1.ClusterStateReady {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
 		os.Exit(1)
@@ -93,6 +114,66 @@ func run(_ *cobra.Command, _ []string) {
 	writer.Flush()
 }
 
+// listHistory prints the upgrades of the cluster from its upgrade policies and its service log
+func listHistory(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) {
+	r.Reporter.Debugf("Loading upgrade history for cluster '%s'", clusterKey)
+	upgradePolicies, err := r.OCMClient.GetUpgradePolicies(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get upgrade policies for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	states := make(map[string]*cmv1.UpgradePolicyState)
+	for _, upgradePolicy := range upgradePolicies {
+		states[upgradePolicy.ID()], err = r.OCMClient.GetUpgradePolicyState(cluster.ID(), upgradePolicy.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the state of upgrade policy '%s': %v", upgradePolicy.ID(), err)
+			os.Exit(1)
+		}
+	}
+
+	entries, err := r.OCMClient.GetClusterServiceLogs(cluster.ExternalID(), ocm.UpgradeHistorySearch)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the service log of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	records := ocm.NewUpgradeHistory(upgradePolicies, states, entries)
+
+	if len(records) == 0 {
+		r.Reporter.Infof("There are no upgrades in the history of cluster '%s'", clusterKey)
+		return
+	}
+
+	if r.Reporter.IsTerminal() {
+		gracePeriod := cluster.NodeDrainGracePeriod()
+		if gracePeriod != nil && gracePeriod.Value() > 0 {
+			r.Reporter.Infof("Node drain grace period of cluster '%s': %g %s", clusterKey,
+				gracePeriod.Value(), gracePeriod.Unit())
+		}
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "VERSION\tTYPE\tSTATE\tSCHEDULED AT\tNEXT RUN\tSTARTED\tFINISHED\n")
+	for _, record := range records {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			record.Version,
+			record.ScheduleType,
+			record.State,
+			printTime(record.ScheduledAt),
+			printTime(record.NextRun),
+			printTime(record.StartedAt),
+			printTime(record.FinishedAt),
+		)
+	}
+	writer.Flush()
+}
+
+func printTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
+
 func latestInCurrentMinor(current string, versions []string) string {
 	latestVersion := current
 	currentParts := strings.Split(current, ".")
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/logs/install"
+	"github.com/openshift/rosa/cmd/logs/service"
 	"github.com/openshift/rosa/cmd/logs/uninstall"
 	"github.com/openshift/rosa/pkg/arguments"
 )
@@ -27,17 +28,21 @@ import (
 var Cmd = &cobra.Command{
 	Use:     "logs",
 	Aliases: []string{"log"},
-	Short:   "Show installation or uninstallation logs for a cluster",
-	Long:    "Show installation or uninstallation logs for a cluster",
+	Short:   "Show installation, uninstallation or service logs for a cluster",
+	Long:    "Show installation, uninstallation or service logs for a cluster",
 	Example: `  # Show install logs for a cluster named 'mycluster'
   rosa logs install --cluster=mycluster
 
   # Show uninstall logs for a cluster named 'mycluster'
-  rosa logs uninstall --cluster=mycluster`,
+  rosa logs uninstall --cluster=mycluster
+
+  # Show service logs for a cluster named 'mycluster'
+  rosa logs service --cluster=mycluster`,
 }
 
 func init() {
 	Cmd.AddCommand(install.Cmd)
+	Cmd.AddCommand(service.Cmd)
 	Cmd.AddCommand(uninstall.Cmd)
 
 	flags := Cmd.PersistentFlags()
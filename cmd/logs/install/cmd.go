@@ -17,9 +17,9 @@ limitations under the License.
 package install
 
 import (
+	"bytes"
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -27,13 +27,15 @@ import (
 	"github.com/spf13/cobra"
 	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/logs"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var args struct {
-	tail  int
-	watch bool
+	tail    int
+	watch   bool
+	archive string
 }
 
 var Cmd = &cobra.Command{
@@ -44,7 +46,10 @@ var Cmd = &cobra.Command{
   rosa logs install mycluster --tail=100
 
   # Show install logs for a cluster using the --cluster flag
-  rosa logs install --cluster=mycluster`,
+  rosa logs install --cluster=mycluster
+
+  # Watch the errors of the installation and save them to a file
+  rosa logs install --cluster=mycluster --watch --level=error --output-file=install.log`,
 	Run: run,
 }
 
@@ -67,6 +72,15 @@ func init() {
 		false,
 		"After getting the logs, watch for changes.",
 	)
+
+	flags.StringVar(
+		&args.archive,
+		"archive",
+		"",
+		"When the installation fails, write the complete logs to the given compressed archive.",
+	)
+
+	logs.AddFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -115,8 +129,19 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	printer, err := logs.NewPrinterFromFlags()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	defer printer.Close()
+
+	if cluster.State() == cmv1.ClusterStateError {
+		archiveLogs(r, cluster)
+	}
+
 	// Get logs from Hive
-	logs, err := r.OCMClient.GetInstallLogs(cluster.ID(), args.tail)
+	log, err := r.OCMClient.GetInstallLogsFrom(cluster.ID(), 0)
 	if err != nil {
 		if errors.GetType(err) == errors.NotFound {
 			r.Reporter.Infof(pendingMessage)
@@ -125,7 +150,11 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
-	printLog(logs, nil)
+	_, err = printer.PrintLast(log.Content(), args.tail)
+	if err != nil {
+		r.Reporter.Errorf("Failed to write logs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
 
 	if watch {
 		if cluster.State() == cmv1.ClusterStateReady {
@@ -141,37 +170,44 @@ func run(cmd *cobra.Command, argv []string) {
 			spin.Start()
 		}
 
-		// Poll for changing logs:
-		response, err := r.OCMClient.PollInstallLogs(cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
+		// Poll for new log lines:
+		err = r.OCMClient.PollInstallLogs(cluster.ID(), printer.Offset(), func(log *cmv1.Log) (int, bool) {
+			printLog(r, printer, log, spin)
 			state, _ := r.OCMClient.GetClusterState(cluster.ID())
 			if state == cmv1.ClusterStateError {
+				if spin != nil {
+					spin.Stop()
+				}
 				r.Reporter.Errorf("There was an error installing cluster '%s'", clusterKey)
+				archiveLogs(r, cluster)
+				printer.Close()
 				os.Exit(1)
 			}
 			if state == cmv1.ClusterStateReady {
+				if spin != nil {
+					spin.Stop()
+				}
 				r.Reporter.Infof("Cluster '%s' is now ready", clusterKey)
+				printer.Close()
 				os.Exit(0)
 			}
-			printLog(logResponse.Body(), spin)
-			return false
+			return printer.Offset(), false
 		})
 		if err != nil {
-			if errors.GetType(err) != errors.NotFound {
-				r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
-				os.Exit(1)
-			}
+			r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
+			os.Exit(1)
 		}
-		printLog(response, spin)
 	}
 }
 
-var lastLine string
-
-// Print next log lines
-func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
-	lines := findNextLines(logs)
-	if lines != "" {
-		fmt.Printf("%s\n", lines)
+// Print the next log lines
+func printLog(r *rosa.Runtime, printer *logs.Printer, log *cmv1.Log, spin *spinner.Spinner) {
+	printed, err := printer.Print(log.Content())
+	if err != nil {
+		r.Reporter.Errorf("Failed to write logs: %v", err)
+		os.Exit(1)
+	}
+	if printed {
 		if spin != nil {
 			spin.Stop()
 		}
@@ -180,24 +216,32 @@ func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
 	}
 }
 
-// Remove duplicate lines from the log poll response
-func findNextLines(logs *cmv1.Log) string {
-	lines := strings.Split(logs.Content(), "\n")
-	// Last element is always empty, remove it
-	if len(lines) > 0 {
-		lines = lines[:len(lines)-1]
+// Write the complete install log and the description of the cluster to an archive that can be
+// attached to a support case
+func archiveLogs(r *rosa.Runtime, cluster *cmv1.Cluster) {
+	if args.archive == "" {
+		r.Reporter.Infof("To collect the logs of the failed installation, run "+
+			"'rosa logs install -c %s --archive %s-install-logs.tar.gz'", r.ClusterKey, cluster.Name())
+		return
 	}
-	// Find where the new logs and the last line overlap
-	for i, line := range lines {
-		if lastLine != "" && line == lastLine {
-			// Remove any duplicate lines
-			lines = lines[i+1:]
-			break
-		}
+	log, err := r.OCMClient.GetInstallLogsFrom(cluster.ID(), 0)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get logs for cluster '%s': %v", r.ClusterKey, err)
+		return
 	}
-	// Store the last log lne
-	if len(lines) > 0 {
-		lastLine = lines[len(lines)-1]
+	var description bytes.Buffer
+	err = cmv1.MarshalCluster(cluster, &description)
+	if err != nil {
+		r.Reporter.Errorf("Failed to describe cluster '%s': %v", r.ClusterKey, err)
+		return
+	}
+	err = logs.WriteArchive(args.archive, map[string][]byte{
+		"install.log":  []byte(log.Content()),
+		"cluster.json": description.Bytes(),
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to write archive '%s': %v", args.archive, err)
+		return
 	}
-	return strings.Join(lines, "\n")
+	r.Reporter.Infof("Wrote the logs of cluster '%s' to '%s'", r.ClusterKey, args.archive)
 }
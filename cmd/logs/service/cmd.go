@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	since    string
+	severity string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "service",
+	Short: "Show cluster service logs",
+	Long: "Show the service log of a cluster, which contains the notifications sent by Red Hat SRE, " +
+		"upgrade notices and limited support events.",
+	Example: `  # Show the service log of a cluster named "mycluster"
+  rosa logs service --cluster=mycluster
+
+  # Show the errors of the last day
+  rosa logs service --cluster=mycluster --since=24h --severity=error`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"Show only the entries newer than a relative duration like 24h or 7d.",
+	)
+	flags.StringVar(
+		&args.severity,
+		"severity",
+		"",
+		fmt.Sprintf("Show only the entries with the given severity. Options: %s.",
+			strings.Join(ocm.ServiceLogSeverities, ", ")),
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	var since time.Time
+	if args.since != "" {
+		duration, err := helper.ParseDuration(args.since)
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid duration for 'since': %v", err)
+			os.Exit(1)
+		}
+		since = time.Now().Add(-duration)
+	}
+	var severity slv1.Severity
+	if args.severity != "" {
+		var err error
+		severity, err = ocm.ParseServiceLogSeverity(args.severity)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Loading service log of cluster '%s'", clusterKey)
+	entries, err := r.OCMClient.GetClusterServiceLogs(cluster.ExternalID(),
+		ocm.ServiceLogSearch(since, string(severity)))
+	if err != nil {
+		r.Reporter.Errorf("Failed to get service log of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(entries)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if len(entries) == 0 {
+		r.Reporter.Infof("There are no service log entries for cluster '%s'", clusterKey)
+		os.Exit(0)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TIMESTAMP\tSEVERITY\tSERVICE\tSUMMARY\tDESCRIPTION\n")
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp().UTC().Format("2006-01-02 15:04 MST"),
+			entry.Severity(),
+			entry.ServiceName(),
+			entry.Summary(),
+			entry.Description(),
+		)
+	}
+	writer.Flush()
+}
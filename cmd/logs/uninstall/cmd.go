@@ -19,7 +19,6 @@ package uninstall
 import (
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -27,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/logs"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -67,6 +67,8 @@ func init() {
 		false,
 		"After getting the logs, watch for changes.",
 	)
+
+	logs.AddFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -101,8 +103,15 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	printer, err := logs.NewPrinterFromFlags()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	defer printer.Close()
+
 	// Get logs from Hive
-	logs, err := r.OCMClient.GetUninstallLogs(cluster.ID(), args.tail)
+	log, err := r.OCMClient.GetUninstallLogsFrom(cluster.ID(), 0)
 	if err != nil {
 		if errors.GetType(err) == errors.NotFound {
 			r.Reporter.Warnf("Logs for cluster '%s' are not available", clusterKey)
@@ -111,7 +120,11 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
-	printLog(logs, nil)
+	_, err = printer.PrintLast(log.Content(), args.tail)
+	if err != nil {
+		r.Reporter.Errorf("Failed to write logs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
 
 	if watch {
 		var spin *spinner.Spinner
@@ -122,33 +135,35 @@ func run(cmd *cobra.Command, argv []string) {
 			spin.Start()
 		}
 
-		// Poll for changing logs:
-		response, err := r.OCMClient.PollUninstallLogs(cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
+		// Poll for new log lines:
+		err = r.OCMClient.PollUninstallLogs(cluster.ID(), printer.Offset(), func(log *cmv1.Log) (int, bool) {
+			printLog(r, printer, log, spin)
 			state, err := r.OCMClient.GetClusterState(cluster.ID())
 			if err != nil || state == cmv1.ClusterState("") {
+				if spin != nil {
+					spin.Stop()
+				}
 				r.Reporter.Infof("Cluster '%s' completed uninstallation", clusterKey)
+				printer.Close()
 				os.Exit(0)
 			}
-			printLog(logResponse.Body(), spin)
-			return false
+			return printer.Offset(), false
 		})
 		if err != nil {
-			if errors.GetType(err) != errors.NotFound {
-				r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
-				os.Exit(1)
-			}
+			r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
+			os.Exit(1)
 		}
-		printLog(response, spin)
 	}
 }
 
-var lastLine string
-
-// Print next log lines
-func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
-	lines := findNextLines(logs)
-	if lines != "" {
-		fmt.Printf("%s\n", lines)
+// Print the next log lines
+func printLog(r *rosa.Runtime, printer *logs.Printer, log *cmv1.Log, spin *spinner.Spinner) {
+	printed, err := printer.Print(log.Content())
+	if err != nil {
+		r.Reporter.Errorf("Failed to write logs: %v", err)
+		os.Exit(1)
+	}
+	if printed {
 		if spin != nil {
 			spin.Stop()
 		}
@@ -156,25 +171,3 @@ func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
 		spin.Restart()
 	}
 }
-
-// Remove duplicate lines from the log poll response
-func findNextLines(logs *cmv1.Log) string {
-	lines := strings.Split(logs.Content(), "\n")
-	// Last element is always empty, remove it
-	if len(lines) > 0 {
-		lines = lines[:len(lines)-1]
-	}
-	// Find where the new logs and the last line overlap
-	for i, line := range lines {
-		if lastLine != "" && line == lastLine {
-			// Remove any duplicate lines
-			lines = lines[i+1:]
-			break
-		}
-	}
-	// Store the last log lne
-	if len(lines) > 0 {
-		lastLine = lines[len(lines)-1]
-	}
-	return strings.Join(lines, "\n")
-}
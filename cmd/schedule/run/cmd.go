@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"os"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/admin"
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	dryRun bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "run",
+	Short: "Hibernate and resume clusters according to their schedules",
+	Long: "Hibernate and resume clusters according to their hibernation schedules, and delete the admin " +
+		"users that expired. The command is meant to run periodically, for example every 5 minutes from a " +
+		"cron job or an AWS Lambda function. Each run of a schedule is applied once, so clusters hibernated or " +
+		"resumed by hand stay so until the next run. Clusters with an upgrade in progress aren't hibernated.",
+	Example: `  # Apply the hibernation schedules of all clusters
+  rosa schedule run
+
+  # Show what would be done to the cluster "mycluster" without changing it
+  rosa schedule run -c mycluster --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddOptionalClusterFlag(Cmd)
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Report the changes that would be applied without applying them.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	var clusters []*cmv1.Cluster
+	if cmd.Flags().Changed("cluster") {
+		clusters = []*cmv1.Cluster{r.FetchCluster()}
+	} else {
+		var err error
+		clusters, err = r.OCMClient.GetClusters(r.Creator, 1000)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get clusters: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	now := time.Now()
+	failed := false
+	for _, cluster := range clusters {
+		if !reconcile(r, cluster, now) {
+			failed = true
+		}
+		if !reconcileAdmins(r, cluster, now) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// reconcile applies the hibernation schedule to a cluster and returns false if it failed
+func reconcile(r *rosa.Runtime, cluster *cmv1.Cluster, now time.Time) bool {
+	schedule, err := hibernation.GetSchedule(cluster)
+	if err != nil {
+		r.Reporter.Errorf("The hibernation schedule of cluster '%s' isn't valid: %v", cluster.Name(), err)
+		return false
+	}
+	if schedule == nil {
+		return true
+	}
+
+	action, run := schedule.Action(cluster.State(), hibernation.GetLastRun(cluster), now)
+	if action == hibernation.ActionNone {
+		r.Reporter.Debugf("Cluster '%s' is %s, nothing to do", cluster.Name(), cluster.State())
+		if run.IsZero() || args.dryRun {
+			return true
+		}
+		return saveLastRun(r, cluster, run)
+	}
+
+	if action == hibernation.ActionHibernate {
+		upgrading, err := r.OCMClient.IsUpgradeInProgress(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the upgrade policies of cluster '%s': %v", cluster.Name(), err)
+			return false
+		}
+		if upgrading {
+			r.Reporter.Warnf("Skipping the hibernation of cluster '%s' while it's being upgraded", cluster.Name())
+			return true
+		}
+	}
+
+	if args.dryRun {
+		r.Reporter.Infof("Would %s cluster '%s'", action, cluster.Name())
+		return true
+	}
+	switch action {
+	case hibernation.ActionHibernate:
+		err = r.OCMClient.HibernateCluster(cluster.ID())
+	case hibernation.ActionResume:
+		err = r.OCMClient.ResumeCluster(cluster.ID())
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to %s cluster '%s': %v", action, cluster.Name(), err)
+		return false
+	}
+	r.Reporter.Infof("Requested to %s cluster '%s'", action, cluster.Name())
+	return saveLastRun(r, cluster, run)
+}
+
+// saveLastRun records the run of the hibernation schedule that was applied to a cluster, so that it isn't
+// applied again after the cluster is hibernated or resumed by hand, and returns false if it failed
+func saveLastRun(r *rosa.Runtime, cluster *cmv1.Cluster, run time.Time) bool {
+	err := r.OCMClient.UpdateClusterProperties(cluster.ID(), func(props map[string]string) map[string]string {
+		return hibernation.LastRunProperties(props, run)
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to save the last run of the hibernation schedule of cluster '%s': %v",
+			cluster.Name(), err)
+		return false
+	}
+	return true
+}
+
+// reconcileAdmins deletes the admin users of a cluster that expired and returns false if it failed
+func reconcileAdmins(r *rosa.Runtime, cluster *cmv1.Cluster, now time.Time) bool {
+	expired := admin.Expired(cluster, now)
+	if len(expired) == 0 {
+		return true
+	}
+	if args.dryRun {
+		for _, username := range expired {
+			r.Reporter.Infof("Would delete expired admin user '%s' of cluster '%s'", username, cluster.Name())
+		}
+		return true
+	}
+
+	htpasswdIDP, err := r.OCMClient.GetHTPasswdIDP(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the HTPasswd identity provider of cluster '%s': %v", cluster.Name(), err)
+		return false
+	}
+	var userList *cmv1.HTPasswdUserList
+	if htpasswdIDP != nil {
+		userList, err = r.OCMClient.GetHTPasswdUserList(cluster.ID(), htpasswdIDP.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the users of the HTPasswd identity provider of cluster '%s': %v",
+				cluster.Name(), err)
+			return false
+		}
+	}
+
+	var deleted []string
+	succeeded := true
+	for _, username := range expired {
+		// Users that were already deleted only need their expiration removed
+		if admin.HasUser(userList, username) {
+			err = r.OCMClient.DeleteAdmin(cluster.ID(), htpasswdIDP, username)
+			if err != nil {
+				r.Reporter.Errorf("Failed to delete expired admin user '%s' of cluster '%s': %v",
+					username, cluster.Name(), err)
+				succeeded = false
+				continue
+			}
+			r.Reporter.Infof("Deleted expired admin user '%s' of cluster '%s'", username, cluster.Name())
+		}
+		deleted = append(deleted, username)
+	}
+	if len(deleted) == 0 {
+		return succeeded
+	}
+	// The properties are read again, as the list of clusters may be outdated
+	err = r.OCMClient.UpdateClusterProperties(cluster.ID(), func(props map[string]string) map[string]string {
+		for _, username := range deleted {
+			props = admin.Properties(props, username, time.Time{})
+		}
+		return props
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to remove the expiration of the admin users of cluster '%s': %v",
+			cluster.Name(), err)
+		return false
+	}
+	return succeeded
+}
@@ -0,0 +1,316 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/logs"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	outputFile string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Write a diagnostics bundle of a cluster",
+	Long: "Write a compressed archive with the description, logs, machine pools, identity providers, " +
+		"ingresses, upgrade policies, limited support reasons, AWS roles, OIDC provider and quotas of a " +
+		"cluster, that can be attached to a Red Hat support case. Secrets are redacted.",
+	Example: `  # Write the diagnostics bundle of the cluster named "mycluster"
+  rosa support bundle -c mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.outputFile,
+		"output-file",
+		"",
+		"File to write the bundle to. Defaults to '<cluster>-support-bundle-<time>.tar.gz'.",
+	)
+}
+
+// bundle collects the files of the archive, together with the errors found collecting them, so that a
+// partial bundle can still be written
+type bundle struct {
+	files  map[string][]byte
+	errors []string
+}
+
+// add adds a JSON document to the bundle, with the secrets redacted
+func (b *bundle) add(name string, marshal func(io.Writer) error) {
+	var buffer bytes.Buffer
+	err := marshal(&buffer)
+	if err != nil {
+		b.fail(name, err)
+		return
+	}
+	redacted, err := logging.RedactJSON(buffer.Bytes(), logging.SensitiveFields)
+	if err != nil {
+		b.fail(name, err)
+		return
+	}
+	b.files[name] = redacted
+}
+
+// addObject adds an object encoded as JSON to the bundle
+func (b *bundle) addObject(name string, object interface{}) {
+	b.add(name, func(writer io.Writer) error {
+		return json.NewEncoder(writer).Encode(object)
+	})
+}
+
+// addLog adds a log to the bundle, with the secrets redacted
+func (b *bundle) addLog(name string, content string) {
+	b.files[name] = logging.RedactLines([]byte(content), logging.SensitiveFields)
+}
+
+func (b *bundle) fail(name string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", name, err))
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	outputFile := args.outputFile
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("%s-support-bundle-%s.tar.gz", cluster.Name(),
+			time.Now().UTC().Format("20060102150405"))
+	}
+
+	b := &bundle{files: make(map[string][]byte)}
+
+	r.Reporter.Infof("Collecting the diagnostics of cluster '%s'", clusterKey)
+	collectTool(r, b)
+	collectCluster(r, b, cluster)
+	collectLogs(r, b, cluster)
+	if cluster.AWS().STS().RoleARN() != "" {
+		collectRoles(r, b, cluster)
+		collectOIDCProvider(r, b, cluster)
+	}
+	collectQuota(r, b)
+
+	if len(b.errors) > 0 {
+		for _, message := range b.errors {
+			r.Reporter.Warnf("Failed to collect %s", message)
+		}
+		b.files["errors.txt"] = []byte(strings.Join(b.errors, "\n") + "\n")
+	}
+
+	err := logs.WriteArchive(outputFile, b.files)
+	if err != nil {
+		r.Reporter.Errorf("Failed to write support bundle '%s': %v", outputFile, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Wrote the support bundle of cluster '%s' to '%s'", clusterKey, outputFile)
+}
+
+// collectTool adds the version of the tool and the accounts it uses
+func collectTool(r *rosa.Runtime, b *bundle) {
+	b.addObject("version.json", map[string]string{
+		"version": info.Version,
+	})
+
+	whoami := map[string]string{
+		"aws_account_id": r.Creator.AccountID,
+		"aws_arn":        r.Creator.ARN,
+		"aws_region":     r.AWSClient.GetRegion(),
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		b.fail("whoami.json", err)
+	} else if cfg != nil {
+		whoami["ocm_api"] = cfg.URL
+	}
+	account, err := r.OCMClient.GetCurrentAccount()
+	if err != nil {
+		b.fail("whoami.json", err)
+	} else if account != nil {
+		whoami["ocm_account_username"] = account.Username()
+		whoami["ocm_organization_id"] = account.Organization().ID()
+		whoami["ocm_organization_name"] = account.Organization().Name()
+	}
+	b.addObject("whoami.json", whoami)
+}
+
+// collectCluster adds the description of the cluster and of its resources
+func collectCluster(r *rosa.Runtime, b *bundle, cluster *cmv1.Cluster) {
+	// The properties are left out, as they are free form and other tools may keep secrets in them
+	clusterWithoutProperties, err := cmv1.NewCluster().Copy(cluster).Properties(nil).Build()
+	if err != nil {
+		b.fail("cluster.json", err)
+	} else {
+		b.add("cluster.json", func(writer io.Writer) error {
+			return cmv1.MarshalCluster(clusterWithoutProperties, writer)
+		})
+	}
+
+	if cluster.Hypershift().Enabled() {
+		nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+		if err != nil {
+			b.fail("node_pools.json", err)
+		} else {
+			b.add("node_pools.json", func(writer io.Writer) error {
+				return cmv1.MarshalNodePoolList(nodePools, writer)
+			})
+		}
+	} else {
+		machinePools, err := r.OCMClient.GetMachinePools(cluster.ID())
+		if err != nil {
+			b.fail("machine_pools.json", err)
+		} else {
+			b.add("machine_pools.json", func(writer io.Writer) error {
+				return cmv1.MarshalMachinePoolList(machinePools, writer)
+			})
+		}
+	}
+
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		b.fail("identity_providers.json", err)
+	} else {
+		b.add("identity_providers.json", func(writer io.Writer) error {
+			return cmv1.MarshalIdentityProviderList(idps, writer)
+		})
+	}
+
+	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
+	if err != nil {
+		b.fail("ingresses.json", err)
+	} else {
+		b.add("ingresses.json", func(writer io.Writer) error {
+			return cmv1.MarshalIngressList(ingresses, writer)
+		})
+	}
+
+	upgradePolicies, err := r.OCMClient.GetUpgradePolicies(cluster.ID())
+	if err != nil {
+		b.fail("upgrade_policies.json", err)
+	} else {
+		b.add("upgrade_policies.json", func(writer io.Writer) error {
+			return cmv1.MarshalUpgradePolicyList(upgradePolicies, writer)
+		})
+	}
+
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		b.fail("limited_support_reasons.json", err)
+	} else {
+		b.add("limited_support_reasons.json", func(writer io.Writer) error {
+			return cmv1.MarshalLimitedSupportReasonList(reasons, writer)
+		})
+	}
+}
+
+// collectLogs adds the install and uninstall logs, which are only available in some states of the cluster
+func collectLogs(r *rosa.Runtime, b *bundle, cluster *cmv1.Cluster) {
+	installLog, err := r.OCMClient.GetInstallLogsFrom(cluster.ID(), 0)
+	if err != nil {
+		if errors.GetType(err) != errors.NotFound {
+			b.fail("install.log", err)
+		}
+	} else {
+		b.addLog("install.log", installLog.Content())
+	}
+
+	uninstallLog, err := r.OCMClient.GetUninstallLogsFrom(cluster.ID(), 0)
+	if err != nil {
+		if errors.GetType(err) != errors.NotFound {
+			b.fail("uninstall.log", err)
+		}
+	} else {
+		b.addLog("uninstall.log", uninstallLog.Content())
+	}
+}
+
+// collectRoles adds the trust and permission policies of the account and operator roles of the cluster
+func collectRoles(r *rosa.Runtime, b *bundle, cluster *cmv1.Cluster) {
+	sts := cluster.AWS().STS()
+	roleARNs := []string{
+		sts.RoleARN(),
+		sts.SupportRoleARN(),
+		sts.InstanceIAMRoles().MasterRoleARN(),
+		sts.InstanceIAMRoles().WorkerRoleARN(),
+	}
+	for _, operatorRole := range sts.OperatorIAMRoles() {
+		roleARNs = append(roleARNs, operatorRole.RoleARN())
+	}
+
+	for _, roleARN := range roleARNs {
+		if roleARN == "" {
+			continue
+		}
+		name := fmt.Sprintf("aws/roles/%s.json", roleARN[strings.LastIndex(roleARN, "/")+1:])
+		policies, err := r.AWSClient.GetRolePolicyDocuments(roleARN)
+		if err != nil {
+			b.fail(name, err)
+			continue
+		}
+		b.addObject(name, policies)
+	}
+}
+
+// collectOIDCProvider adds the details of the OIDC provider of the cluster
+func collectOIDCProvider(r *rosa.Runtime, b *bundle, cluster *cmv1.Cluster) {
+	issuerURL := cluster.AWS().STS().OIDCEndpointURL()
+	if issuerURL == "" {
+		return
+	}
+	provider, err := r.AWSClient.DescribeOpenIDConnectProvider(issuerURL, r.Creator.AccountID)
+	if err != nil {
+		b.fail("aws/oidc_provider.json", err)
+		return
+	}
+	b.addObject("aws/oidc_provider.json", provider)
+}
+
+// collectQuota adds the usage of the AWS quotas consumed by the cluster
+func collectQuota(r *rosa.Runtime, b *bundle) {
+	usages, err := r.AWSClient.GetQuotaUsage()
+	if err != nil {
+		b.fail("aws/quota.json", err)
+		return
+	}
+	b.addObject("aws/quota.json", usages)
+}
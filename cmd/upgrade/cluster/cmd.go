@@ -17,6 +17,7 @@ limitations under the License.
 package cluster
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/roles"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cron"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -39,8 +41,12 @@ var args struct {
 	scheduleDate         string
 	scheduleTime         string
 	nodeDrainGracePeriod string
+	schedule             string
 }
 
+// PreviewRuns is the number of upcoming runs shown for automatic upgrade schedules
+const PreviewRuns = 5
+
 var nodeDrainOptions = []string{
 	"15 minutes",
 	"30 minutes",
@@ -59,7 +65,10 @@ var Cmd = &cobra.Command{
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgrade cluster -c mycluster --version 4.5.20`,
+  rosa upgrade cluster -c mycluster --version 4.5.20
+
+  # Upgrade the cluster to the latest patch version every Sunday at 02:00 UTC
+  rosa upgrade cluster -c mycluster --schedule "0 2 * * SUN"`,
 	Run: run,
 }
 
@@ -101,6 +110,15 @@ func init() {
 			"options are ['%s']", strings.Join(nodeDrainOptions, "','")),
 	)
 
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC that schedules recurring automatic upgrades to the latest patch version of "+
+			"the current minor version, for example '0 2 * * SUN'. Can't be used together with 'version', "+
+			"'schedule-date' and 'schedule-time'.",
+	)
+
 	confirm.AddFlag(flags)
 }
 
@@ -128,23 +146,23 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	scheduledUpgrade, upgradeState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
-	if err != nil {
-		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+	if args.schedule != "" {
+		for _, flag := range []string{"version", "schedule-date", "schedule-time"} {
+			if cmd.Flags().Changed(flag) {
+				r.Reporter.Errorf("The '%s' option can't be used together with 'schedule'", flag)
+				os.Exit(1)
+			}
+		}
 	}
-	if scheduledUpgrade != nil {
-		r.Reporter.Warnf("There is already a %s upgrade to version %s on %s",
-			upgradeState.Value(),
-			scheduledUpgrade.Version(),
-			scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"),
-		)
-		os.Exit(0)
+
+	CheckExistingUpgrades(r, cluster)
+
+	if args.schedule != "" {
+		scheduleAutomaticUpgrade(r, cmd, cluster)
+		return
 	}
 
 	version := args.version
-	scheduleDate := args.scheduleDate
-	scheduleTime := args.scheduleTime
 
 	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
 	if err != nil {
@@ -172,7 +190,51 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
-	err = r.OCMClient.CheckUpgradeClusterVersion(availableUpgrades, version, cluster)
+	ScheduleManualUpgrade(r, cmd, cluster, availableUpgrades, version, mode)
+}
+
+// CheckExistingUpgrades exits when the cluster is upgraded automatically or already has a scheduled
+// upgrade, as another upgrade can't be scheduled then
+func CheckExistingUpgrades(r *rosa.Runtime, cluster *cmv1.Cluster) {
+	automaticUpgrade, err := r.OCMClient.GetAutomaticUpgradePolicy(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get automatic upgrades for cluster '%s': %v", r.ClusterKey, err)
+		os.Exit(1)
+	}
+	if automaticUpgrade != nil {
+		r.Reporter.Errorf("Cluster '%s' is upgraded automatically with schedule '%s'. To change the schedule, "+
+			"run 'rosa edit upgrade -c %s --schedule'. To remove it, run 'rosa delete upgrade -c %s'",
+			r.ClusterKey, automaticUpgrade.Schedule(), r.ClusterKey, r.ClusterKey)
+		os.Exit(1)
+	}
+
+	scheduledUpgrade, upgradeState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", r.ClusterKey, err)
+		os.Exit(1)
+	}
+	if scheduledUpgrade != nil {
+		r.Reporter.Warnf("There is already a %s upgrade to version %s on %s",
+			upgradeState.Value(),
+			scheduledUpgrade.Version(),
+			scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"),
+		)
+		os.Exit(0)
+	}
+}
+
+// ScheduleManualUpgrade schedules the upgrade of the cluster to one of the available versions. It checks
+// that the role policies of STS clusters are compatible with the version, and asks for the schedule, the
+// node drain grace period and the acknowledgement of the version gates, unless they are given by the
+// options of the upgrade command.
+func ScheduleManualUpgrade(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster, availableUpgrades []string,
+	version string, mode string) {
+	clusterKey := r.ClusterKey
+	_, isSTS := cluster.AWS().STS().GetRoleARN()
+	scheduleDate := args.scheduleDate
+	scheduleTime := args.scheduleTime
+
+	err := r.OCMClient.CheckUpgradeClusterVersion(availableUpgrades, version, cluster)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
 		os.Exit(1)
@@ -224,20 +286,37 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
-	upgradePolicyBuilder := cmv1.NewUpgradePolicy().
-		ScheduleType("manual").
-		Version(version)
+	nextRun := GetNextRun(r, cmd, scheduleDate, scheduleTime)
 
-	upgradePolicy, err := upgradePolicyBuilder.Build()
+	nodeDrainValue := getNodeDrainGracePeriod(r, cmd, cluster)
+
+	clusterSpec := ocm.Spec{
+		NodeDrainGracePeriodInMinutes: nodeDrainValue,
+	}
+
+	err = r.OCMClient.ScheduleManualUpgrade(cluster.ID(), version, nextRun, func(gates []*cmv1.VersionGate) error {
+		return acknowledgeGates(r, gates)
+	})
+	if errors.Is(err, errGatesNotAcknowledged) {
+		os.Exit(0)
+	}
 	if err != nil {
 		r.Reporter.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
-	err = checkAndAckMissingAgreements(r, cluster, upgradePolicy, clusterKey)
+
+	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterSpec)
 	if err != nil {
-		r.Reporter.Errorf("%v", err)
+		r.Reporter.Errorf("Failed to update cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
+
+	r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+}
+
+// GetNextRun returns the time of the upgrade from the schedule date and time options. The upgrade runs
+// within the next 10 minutes by default.
+func GetNextRun(r *rosa.Runtime, cmd *cobra.Command, scheduleDate string, scheduleTime string) time.Time {
 	// Set the default next run within the next 10 minutes
 	now := time.Now().UTC().Add(time.Minute * 10)
 	if scheduleDate == "" {
@@ -302,8 +381,87 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	upgradePolicyBuilder = upgradePolicyBuilder.NextRun(nextRun)
+	return nextRun
+}
+
+// scheduleAutomaticUpgrade creates a policy that upgrades the cluster to the latest patch version on the
+// schedule given by the user
+func scheduleAutomaticUpgrade(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster) {
+	var err error
+	schedule := args.schedule
+	if interactive.Enabled() {
+		schedule, err = interactive.GetString(interactive.Input{
+			Question: "Upgrade schedule",
+			Help:     cmd.Flags().Lookup("schedule").Usage,
+			Default:  schedule,
+			Required: true,
+			Validators: []interactive.Validator{
+				ocm.ValidateUpgradeSchedule,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid schedule: %s", err)
+			os.Exit(1)
+		}
+	}
+	parsedSchedule, err := cron.Parse(schedule)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if !PrintNextRuns(r, parsedSchedule) {
+		r.Reporter.Errorf("Schedule '%s' never runs", schedule)
+		os.Exit(1)
+	}
+	if !confirm.Confirm("upgrade cluster '%s' automatically with schedule '%s'", r.ClusterKey, schedule) {
+		os.Exit(0)
+	}
+
+	nodeDrainValue := getNodeDrainGracePeriod(r, cmd, cluster)
 
+	upgradePolicy, err := cmv1.NewUpgradePolicy().
+		ScheduleType("automatic").
+		Schedule(parsedSchedule.String()).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule upgrades for cluster '%s': %v", r.ClusterKey, err)
+		os.Exit(1)
+	}
+	err = r.OCMClient.ScheduleUpgrade(cluster.ID(), upgradePolicy)
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule upgrades for cluster '%s': %v", r.ClusterKey, err)
+		os.Exit(1)
+	}
+	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, ocm.Spec{
+		NodeDrainGracePeriodInMinutes: nodeDrainValue,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to update cluster '%s': %v", r.ClusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Automatic upgrades successfully scheduled for cluster '%s'", r.ClusterKey)
+}
+
+// PrintNextRuns prints the upcoming runs of an upgrade schedule. It returns false when the schedule
+// never runs.
+func PrintNextRuns(r *rosa.Runtime, schedule *cron.Schedule) bool {
+	runs := schedule.NextRuns(time.Now(), PreviewRuns)
+	if len(runs) == 0 {
+		return false
+	}
+	var dates []string
+	for _, run := range runs {
+		dates = append(dates, run.Format("Mon 2006-01-02 15:04 MST"))
+	}
+	r.Reporter.Infof("Next %d upgrade windows for schedule '%s':\n- %s", len(runs), schedule,
+		strings.Join(dates, "\n- "))
+	return true
+}
+
+// getNodeDrainGracePeriod returns the node drain grace period in minutes, defaulting to the one already
+// set on the cluster
+func getNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster) float64 {
+	var err error
 	nodeDrainGracePeriod := ""
 	// Determine if the cluster already has a node drain grace period set and use that as the default
 	nd := cluster.NodeDrainGracePeriod()
@@ -360,73 +518,45 @@ func run(cmd *cobra.Command, _ []string) {
 		nodeDrainValue = nodeDrainValue * 60
 	}
 
-	clusterSpec := ocm.Spec{
-		NodeDrainGracePeriodInMinutes: nodeDrainValue,
-	}
-
-	upgradePolicy, err = upgradePolicyBuilder.Build()
-	if err != nil {
-		r.Reporter.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
-	}
-
-	err = r.OCMClient.ScheduleUpgrade(cluster.ID(), upgradePolicy)
-	if err != nil {
-		r.Reporter.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
-	}
-
-	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterSpec)
-	if err != nil {
-		r.Reporter.Errorf("Failed to update cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
-	}
-
-	r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+	return nodeDrainValue
 }
 
-func checkAndAckMissingAgreements(r *rosa.Runtime, cluster *cmv1.Cluster, upgradePolicy *cmv1.UpgradePolicy,
-	clusterKey string) error {
-	// check if the cluster upgrade requires gate agreements
-	gates, err := r.OCMClient.GetMissingGateAgreements(cluster.ID(), upgradePolicy)
-	if err != nil {
-		return fmt.Errorf("failed to check for missing gate agreements upgrade for "+
-			"cluster '%s': %v", clusterKey, err)
-	}
+// errGatesNotAcknowledged is returned by acknowledgeGates when the user doesn't acknowledge a version gate
+var errGatesNotAcknowledged = errors.New("version gates weren't acknowledged")
+
+// acknowledgeGates shows the version gates that the user needs to agree to before upgrading and asks
+// for their acknowledgement. Gates that only apply to STS clusters are agreed to without asking.
+func acknowledgeGates(r *rosa.Runtime, gates []*cmv1.VersionGate) error {
 	isWarningDisplayed := false
 	for _, gate := range gates {
-		if !gate.STSOnly() {
-			if !isWarningDisplayed {
-				r.Reporter.Warnf("Missing required acknowledgements to schedule upgrade. \n")
-				isWarningDisplayed = true
-			}
-			str := fmt.Sprintf("Description: %s\n", gate.Description())
+		if gate.STSOnly() {
+			continue
+		}
+		if !isWarningDisplayed {
+			r.Reporter.Warnf("Missing required acknowledgements to schedule upgrade. \n")
+			isWarningDisplayed = true
+		}
+		str := fmt.Sprintf("Description: %s\n", gate.Description())
 
-			if gate.WarningMessage() != "" {
-				str = fmt.Sprintf("%s"+
-					"    Warning:     %s\n", str, gate.WarningMessage())
-			}
+		if gate.WarningMessage() != "" {
 			str = fmt.Sprintf("%s"+
-				"    URL:         %s\n", str, gate.DocumentationURL())
-
-			err = interactive.PrintHelp(interactive.Help{
-				Message: "Read the below description and acknowledge to proceed with upgrade",
-				Steps:   []string{str},
-			})
-			if err != nil {
-				return fmt.Errorf("failed to get version gate '%s' for cluster '%s': %v",
-					gate.ID(), clusterKey, err)
-			}
-			// for non sts gates we require user agreement
-			if !confirm.Prompt(true, "I acknowledge") {
-				os.Exit(0)
-			}
+				"    Warning:     %s\n", str, gate.WarningMessage())
 		}
-		err = r.OCMClient.AckVersionGate(cluster.ID(), gate.ID())
+		str = fmt.Sprintf("%s"+
+			"    URL:         %s\n", str, gate.DocumentationURL())
+
+		err := interactive.PrintHelp(interactive.Help{
+			Message: "Read the below description and acknowledge to proceed with upgrade",
+			Steps:   []string{str},
+		})
 		if err != nil {
-			return fmt.Errorf("failed to acknowledge version gate '%s' for cluster '%s': %v",
-				gate.ID(), clusterKey, err)
+			return fmt.Errorf("failed to get version gate '%s' for cluster '%s': %v",
+				gate.ID(), r.ClusterKey, err)
+		}
+		// for non sts gates we require user agreement
+		if !confirm.Prompt(true, "I acknowledge") {
+			return errGatesNotAcknowledged
 		}
 	}
-	return err
+	return nil
 }
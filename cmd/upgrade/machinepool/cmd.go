@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	upgradecluster "github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version      string
+	scheduleDate string
+	scheduleTime string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Upgrade machine pool",
+	Long: "Upgrade the nodes of a machine pool of a hosted control plane cluster to a new version. " +
+		"The nodes can't be newer than the control plane, nor more than 2 minor versions older.",
+	Example: `  # Upgrade the machine pool 'mp1' of the cluster 'mycluster' within the hour
+  rosa upgrade machinepool -c mycluster mp1 --version 4.12.10
+
+  # Schedule the upgrade of the machine pool 'mp1' of the cluster 'mycluster'
+  rosa upgrade machinepool -c mycluster mp1 --version 4.12.10 --schedule-date 2023-01-10 --schedule-time 22:00`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the machine pool",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of OpenShift that the nodes of the machine pool will be upgraded to",
+	)
+
+	flags.StringVar(
+		&args.scheduleDate,
+		"schedule-date",
+		"",
+		"Next date the upgrade should run at the specified UTC time. Format should be 'yyyy-mm-dd'",
+	)
+
+	flags.StringVar(
+		&args.scheduleTime,
+		"schedule-time",
+		"",
+		"Next UTC time that the upgrade should run on the specified date. Format should be 'HH:mm'",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	machinePoolID := argv[0]
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	if !cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("The machine pools of cluster '%s' are upgraded with the cluster. "+
+			"Run 'rosa upgrade cluster -c %s' instead", clusterKey, clusterKey)
+		os.Exit(1)
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	channelGroup := cluster.Version().ChannelGroup()
+	nodePoolVersion, err := r.OCMClient.GetNodePoolVersion(cluster.ID(), machinePoolID, channelGroup)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pool '%s' for cluster '%s': %v",
+			machinePoolID, clusterKey, err)
+		os.Exit(1)
+	}
+	if nodePoolVersion == "" {
+		r.Reporter.Errorf("Failed to get the version of machine pool '%s' for cluster '%s'",
+			machinePoolID, clusterKey)
+		os.Exit(1)
+	}
+
+	scheduledUpgrade, err := r.OCMClient.GetScheduledNodePoolUpgrade(cluster.ID(), machinePoolID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get scheduled upgrades for machine pool '%s': %v", machinePoolID, err)
+		os.Exit(1)
+	}
+	if scheduledUpgrade != nil {
+		nextRun := ""
+		if scheduledUpgrade.NextRun != nil {
+			nextRun = fmt.Sprintf(" on %s", scheduledUpgrade.NextRun.Format("2006-01-02 15:04 MST"))
+		}
+		r.Reporter.Warnf("There is already an upgrade of machine pool '%s' to version '%s' scheduled%s",
+			machinePoolID, scheduledUpgrade.Version, nextRun)
+		os.Exit(0)
+	}
+
+	controlPlaneVersion := cluster.Version().RawID()
+	if controlPlaneVersion == "" {
+		controlPlaneVersion = cluster.OpenshiftVersion()
+	}
+	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.CreateVersionID(nodePoolVersion, channelGroup))
+	if err != nil {
+		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
+		os.Exit(1)
+	}
+	availableUpgrades = ocm.FilterNodePoolUpgrades(availableUpgrades, controlPlaneVersion)
+	if len(availableUpgrades) == 0 {
+		r.Reporter.Warnf("There are no available upgrades for machine pool '%s'. The machine pool runs "+
+			"version '%s' and the control plane runs version '%s'",
+			machinePoolID, nodePoolVersion, controlPlaneVersion)
+		os.Exit(0)
+	}
+
+	version := args.version
+	scheduleDate := args.scheduleDate
+	scheduleTime := args.scheduleTime
+
+	if version == "" || interactive.Enabled() {
+		if version == "" {
+			version = availableUpgrades[0]
+		}
+		version, err = interactive.GetOption(interactive.Input{
+			Question: "Version",
+			Help:     cmd.Flags().Lookup("version").Usage,
+			Options:  availableUpgrades,
+			Default:  version,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid version to upgrade to: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	err = ocm.ValidateNodePoolVersionSkew(controlPlaneVersion, version)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if !helper.Contains(availableUpgrades, version) {
+		r.Reporter.Errorf("Expected a valid version to upgrade machine pool to.\nValid versions: %s",
+			helper.SliceToString(availableUpgrades))
+		os.Exit(1)
+	}
+
+	if scheduleDate == "" || scheduleTime == "" {
+		interactive.Enable()
+	}
+
+	if !confirm.Confirm("upgrade machine pool '%s' to version '%s'", machinePoolID, version) {
+		os.Exit(0)
+	}
+
+	nextRun := upgradecluster.GetNextRun(r, cmd, scheduleDate, scheduleTime)
+	_, err = r.OCMClient.ScheduleNodePoolUpgrade(cluster.ID(), machinePoolID,
+		ocm.NewNodePoolUpgradePolicy(machinePoolID, version, nextRun))
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule upgrade for machine pool '%s': %v", machinePoolID, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Upgrade successfully scheduled for machine pool '%s' on cluster '%s'",
+		machinePoolID, clusterKey)
+}
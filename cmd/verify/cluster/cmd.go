@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Verify that a cluster is ready and fully supported",
+	Long: "Verify that a cluster is ready and that it isn't in limited support. The command exits with a " +
+		"non-zero code when it isn't, so that it can be used by monitoring.",
+	Example: `  # Verify the cluster named "mycluster"
+  rosa verify cluster --cluster=mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	healthy := true
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is %s", clusterKey, cluster.State())
+		healthy = false
+	}
+
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if len(reasons) > 0 {
+		r.Reporter.Errorf("Cluster '%s' is in limited support:", clusterKey)
+		for _, reason := range reasons {
+			r.Reporter.Errorf(" - %s: %s", reason.Summary(), reason.Details())
+		}
+		r.Reporter.Infof("To see the details, run 'rosa list limited-support-reasons -c %s'", clusterKey)
+		healthy = false
+	}
+
+	if !healthy {
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Cluster '%s' is ready and fully supported", clusterKey)
+}
@@ -19,9 +19,13 @@ package verify
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/verify/cluster"
+	"github.com/openshift/rosa/cmd/verify/idp"
 	"github.com/openshift/rosa/cmd/verify/oc"
 	"github.com/openshift/rosa/cmd/verify/permissions"
+	"github.com/openshift/rosa/cmd/verify/proxy"
 	"github.com/openshift/rosa/cmd/verify/quota"
+	"github.com/openshift/rosa/cmd/verify/upgrade"
 )
 
 var Cmd = &cobra.Command{
@@ -31,7 +35,11 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(oc.Cmd)
 	Cmd.AddCommand(permissions.Cmd)
+	Cmd.AddCommand(proxy.Cmd)
 	Cmd.AddCommand(quota.Cmd)
+	Cmd.AddCommand(upgrade.Cmd)
 }
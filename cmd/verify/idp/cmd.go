@@ -0,0 +1,367 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/idpcheck"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// timeout is the time allowed to each connection to the identity provider
+const timeout = 10 * time.Second
+
+var args struct {
+	idpType  string
+	caPath   string
+	testUser string
+
+	// GitHub
+	githubHostname string
+
+	// GitLab
+	gitlabURL string
+
+	// LDAP
+	ldapURL          string
+	ldapInsecure     bool
+	ldapBindDN       string
+	ldapBindPassword string
+
+	// OpenID
+	openidIssuerURL      string
+	openidEmailClaims    string
+	openidNameClaims     string
+	openidUsernameClaims string
+	openidGroupsClaims   string
+	openidExtraScopes    string
+}
+
+var validIdps = []string{"github", "gitlab", "ldap", "openid"}
+
+var Cmd = &cobra.Command{
+	Use:   "idp [NAME]",
+	Short: "Verify the configuration of an identity provider",
+	Long: "Verify that an identity provider can be reached and is configured correctly, before or after " +
+		"adding it to a cluster. Given the name of an identity provider of a cluster, its configuration is " +
+		"loaded from the cluster, and the flags replace the loaded settings.",
+	Example: `  # Verify an OpenID identity provider before creating it
+  rosa verify idp --type openid --issuer-url https://sso.example.com --email-claims email
+
+  # Verify the LDAP identity provider "ldap-1" of cluster "mycluster", finding the user "alice"
+  rosa verify idp -c mycluster ldap-1 --bind-password MyPassword --test-user alice
+
+  # Verify that a GitLab instance can be reached with a custom CA
+  rosa verify idp --type gitlab --host-url https://gitlab.example.com --ca ca.pem`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) > 1 {
+			return fmt.Errorf(
+				"Expected at most one command line parameter containing the name of the identity provider",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddOptionalClusterFlag(Cmd)
+	flags.StringVarP(
+		&args.idpType,
+		"type",
+		"t",
+		"",
+		fmt.Sprintf("Type of identity provider. Options are %s.", validIdps),
+	)
+	flags.StringVar(
+		&args.caPath,
+		"ca",
+		"",
+		"Path to PEM-encoded certificate file to use when making requests to the server.",
+	)
+	flags.StringVar(
+		&args.testUser,
+		"test-user",
+		"",
+		"LDAP: Username to search for, to check that users can be found.\n",
+	)
+
+	// GitHub
+	flags.StringVar(
+		&args.githubHostname,
+		"hostname",
+		"",
+		"GitHub: Domain of a hosted instance of GitHub Enterprise.\n",
+	)
+
+	// GitLab
+	flags.StringVar(
+		&args.gitlabURL,
+		"host-url",
+		"",
+		"GitLab: The host URL of a GitLab provider.\n",
+	)
+
+	// LDAP
+	flags.StringVar(
+		&args.ldapURL,
+		"url",
+		"",
+		"LDAP: An RFC 2255 URL which specifies the LDAP search parameters to use.",
+	)
+	flags.BoolVar(
+		&args.ldapInsecure,
+		"insecure",
+		false,
+		"LDAP: Do not make TLS connections to the server.",
+	)
+	flags.StringVar(
+		&args.ldapBindDN,
+		"bind-dn",
+		"",
+		"LDAP: DN to bind with during the search phase.",
+	)
+	flags.StringVar(
+		&args.ldapBindPassword,
+		"bind-password",
+		"",
+		"LDAP: Password to bind with during the search phase.\n",
+	)
+
+	// OpenID
+	flags.StringVar(
+		&args.openidIssuerURL,
+		"issuer-url",
+		"",
+		"OpenID: The URL that the OpenID provider asserts as the Issuer Identifier.",
+	)
+	flags.StringVar(
+		&args.openidEmailClaims,
+		"email-claims",
+		"",
+		"OpenID: List of claims to use as the email address.",
+	)
+	flags.StringVar(
+		&args.openidNameClaims,
+		"name-claims",
+		"",
+		"OpenID: List of claims to use as the display name.",
+	)
+	flags.StringVar(
+		&args.openidUsernameClaims,
+		"username-claims",
+		"",
+		"OpenID: List of claims to use as the preferred username when provisioning a user.",
+	)
+	flags.StringVar(
+		&args.openidGroupsClaims,
+		"groups-claims",
+		"",
+		"OpenID: List of claims to use as the groups names.",
+	)
+	flags.StringVar(
+		&args.openidExtraScopes,
+		"extra-scopes",
+		"",
+		"OpenID: List of scopes to request, in addition to the 'openid' scope, during the authorization "+
+			"request.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	idpType := strings.ToLower(args.idpType)
+	var idp *cmv1.IdentityProvider
+	if len(argv) == 1 {
+		idp = loadIDP(r, argv[0])
+		loadedType := strings.ToLower(ocm.IdentityProviderType(idp))
+		if idpType != "" && idpType != loadedType {
+			r.Reporter.Errorf("Identity provider '%s' is of type '%s'", argv[0], loadedType)
+			os.Exit(1)
+		}
+		idpType = loadedType
+	}
+	if idpType == "" {
+		r.Reporter.Errorf("Expected the name of an identity provider of a cluster, or its type in '--type'")
+		os.Exit(1)
+	}
+
+	ca := ""
+	if idp != nil {
+		switch idpType {
+		case "github":
+			ca = idp.Github().CA()
+		case "gitlab":
+			ca = idp.Gitlab().CA()
+		case "ldap":
+			ca = idp.LDAP().CA()
+		case "openid":
+			ca = idp.OpenID().CA()
+		}
+	}
+	if args.caPath != "" {
+		cert, err := ioutil.ReadFile(args.caPath)
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid certificate file: %s", err)
+			os.Exit(1)
+		}
+		ca = string(cert)
+	}
+
+	var results []idpcheck.Result
+	switch idpType {
+	case "github":
+		hostname := value(cmd, "hostname", args.githubHostname, idp.Github().Hostname())
+		if hostname == "" {
+			r.Reporter.Errorf("Expected the domain of a GitHub Enterprise instance in '--hostname'")
+			os.Exit(1)
+		}
+		results = idpcheck.VerifyHost(httpClient(r, ca), "https://"+hostname)
+	case "gitlab":
+		hostURL := value(cmd, "host-url", args.gitlabURL, idp.Gitlab().URL())
+		if hostURL == "" {
+			r.Reporter.Errorf("Expected the URL of the GitLab provider in '--host-url'")
+			os.Exit(1)
+		}
+		results = idpcheck.VerifyHost(httpClient(r, ca), hostURL)
+	case "ldap":
+		ldap := idp.LDAP()
+		config := &idpcheck.LDAPConfig{
+			URL:          value(cmd, "url", args.ldapURL, ldap.URL()),
+			Insecure:     ldap.Insecure(),
+			CA:           ca,
+			BindDN:       value(cmd, "bind-dn", args.ldapBindDN, ldap.BindDN()),
+			BindPassword: args.ldapBindPassword,
+			TestUser:     args.testUser,
+		}
+		if cmd.Flags().Changed("insecure") {
+			config.Insecure = args.ldapInsecure
+		}
+		if config.URL == "" {
+			r.Reporter.Errorf("Expected the URL of the LDAP provider in '--url'")
+			os.Exit(1)
+		}
+		if idp != nil && config.BindDN != "" && config.BindPassword == "" {
+			r.Reporter.Warnf("The bind password of identity provider '%s' can't be loaded from the cluster, "+
+				"set it in '--bind-password'", idp.Name())
+		}
+		results = idpcheck.VerifyLDAP(config, timeout)
+	case "openid":
+		openid := idp.OpenID()
+		claims := openid.Claims()
+		config := &idpcheck.OpenIDConfig{
+			IssuerURL: value(cmd, "issuer-url", args.openidIssuerURL, openid.Issuer()),
+			ExtraScopes: split(value(cmd, "extra-scopes", args.openidExtraScopes,
+				strings.Join(openid.ExtraScopes(), ","))),
+		}
+		for _, list := range []string{
+			value(cmd, "email-claims", args.openidEmailClaims, strings.Join(claims.Email(), ",")),
+			value(cmd, "name-claims", args.openidNameClaims, strings.Join(claims.Name(), ",")),
+			value(cmd, "username-claims", args.openidUsernameClaims,
+				strings.Join(claims.PreferredUsername(), ",")),
+			value(cmd, "groups-claims", args.openidGroupsClaims, strings.Join(claims.Groups(), ",")),
+		} {
+			config.Claims = append(config.Claims, split(list)...)
+		}
+		if config.IssuerURL == "" {
+			r.Reporter.Errorf("Expected the issuer URL of the OpenID provider in '--issuer-url'")
+			os.Exit(1)
+		}
+		results = idpcheck.VerifyOpenID(httpClient(r, ca), config)
+	default:
+		r.Reporter.Errorf("Verifying '%s' identity providers isn't supported. Options are %s",
+			idpType, validIdps)
+		os.Exit(1)
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			r.Reporter.Errorf("%s: %v", result.Check, result.Err)
+		} else {
+			r.Reporter.Infof("%s: %s", result.Check, result.Detail)
+		}
+	}
+	if idpcheck.Failed(results) {
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Identity provider configuration is valid")
+}
+
+// loadIDP finds the identity provider with the given name in the cluster
+func loadIDP(r *rosa.Runtime, idpName string) *cmv1.IdentityProvider {
+	r.WithAWS().WithOCM()
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Loading identity provider '%s'", idpName)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, item := range idps {
+		if item.Name() == idpName {
+			return item
+		}
+	}
+	r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+	os.Exit(1)
+	return nil
+}
+
+// value returns the value of the flag when it is given, and the loaded value otherwise
+func value(cmd *cobra.Command, flag string, flagValue string, loaded string) string {
+	if cmd.Flags().Changed(flag) {
+		return flagValue
+	}
+	return loaded
+}
+
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func httpClient(r *rosa.Runtime, ca string) *http.Client {
+	client, err := idpcheck.HTTPClient(ca, timeout)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return client
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/egress"
+	"github.com/openshift/rosa/pkg/fedramp"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/proxy"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	httpProxy                 string
+	httpsProxy                string
+	additionalTrustBundleFile string
+	endpoints                 []string
+	timeout                   time.Duration
+	expirationWarningDays     int
+}
+
+var Cmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Verify a cluster-wide proxy and its trust bundle",
+	Long: "Verify the certificates of the additional trust bundle and that the endpoints required by a " +
+		"cluster can be reached through the proxy. Run the command from a host in the VPC of the cluster " +
+		"to verify the same network path the cluster will use.",
+	Example: `  # Verify the certificates of a trust bundle
+  rosa verify proxy --additional-trust-bundle-file=ca-bundle.pem
+
+  # Verify that the endpoints required in us-east-1 can be reached through the proxy
+  rosa verify proxy --region=us-east-1 --https-proxy=http://proxy.example.com:3128 \
+  --additional-trust-bundle-file=ca-bundle.pem
+
+  # Verify a custom list of endpoints
+  rosa verify proxy --https-proxy=http://proxy.example.com:3128 --endpoints=quay.io:443,sso.redhat.com:443`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	arguments.AddRegionFlag(flags)
+	arguments.AddProfileFlag(flags)
+
+	flags.StringVar(
+		&args.httpProxy,
+		"http-proxy",
+		"",
+		"A proxy URL to use for creating HTTP connections outside the cluster. The URL scheme must be http.",
+	)
+	flags.StringVar(
+		&args.httpsProxy,
+		"https-proxy",
+		"",
+		"A proxy URL to use for creating HTTPS connections outside the cluster. "+
+			"When not set, the HTTP proxy is used to verify the endpoints.",
+	)
+	flags.StringVar(
+		&args.additionalTrustBundleFile,
+		"additional-trust-bundle-file",
+		"",
+		"A file containing a PEM-encoded X.509 certificate bundle that will be added to the nodes' "+
+			"trusted certificate store.",
+	)
+	flags.StringSliceVar(
+		&args.endpoints,
+		"endpoints",
+		nil,
+		"Comma-separated list of 'host:port' endpoints to reach through the proxy. "+
+			"Defaults to the endpoints required by a cluster in the region.",
+	)
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		10*time.Second,
+		"Time to wait for each endpoint to be reached through the proxy.",
+	)
+	flags.IntVar(
+		&args.expirationWarningDays,
+		"expiration-warning-days",
+		int(proxy.DefaultExpirationWarning.Hours()/24),
+		"Report certificates that expire within this number of days.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	if args.httpProxy == "" && args.httpsProxy == "" && args.additionalTrustBundleFile == "" {
+		r.Reporter.Errorf("Expected at least one of the following: http-proxy, https-proxy, " +
+			"additional-trust-bundle-file")
+		os.Exit(1)
+	}
+	err := ocm.ValidateHTTPProxy(args.httpProxy)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = interactive.IsURL(args.httpsProxy)
+	if err != nil {
+		r.Reporter.Errorf("Invalid https-proxy value '%s'", args.httpsProxy)
+		os.Exit(1)
+	}
+	if args.expirationWarningDays < 0 {
+		r.Reporter.Errorf("Expected a non-negative number of days for expiration-warning-days")
+		os.Exit(1)
+	}
+
+	failed := false
+
+	var bundle []*x509.Certificate
+	if args.additionalTrustBundleFile != "" {
+		data, err := ioutil.ReadFile(args.additionalTrustBundleFile)
+		if err != nil {
+			r.Reporter.Errorf("Failed to read additional trust bundle file: %v", err)
+			os.Exit(1)
+		}
+		bundle, err = proxy.ParseCertificates(data)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		if !verifyCertificates(r, bundle) {
+			failed = true
+		}
+	}
+
+	proxyValue := args.httpsProxy
+	if proxyValue == "" {
+		proxyValue = args.httpProxy
+	}
+	if proxyValue != "" {
+		proxyURL, err := url.Parse(proxyValue)
+		if err != nil {
+			r.Reporter.Errorf("Invalid proxy value '%s': %v", proxyValue, err)
+			os.Exit(1)
+		}
+		endpoints := args.endpoints
+		if len(endpoints) == 0 {
+			region, err := aws.GetRegion(arguments.GetRegion())
+			if err != nil {
+				r.Reporter.Errorf("Error getting region: %v", err)
+				os.Exit(1)
+			}
+			endpoints, err = getRequiredEndpoints(region)
+			if err != nil {
+				r.Reporter.Errorf("%v", err)
+				os.Exit(1)
+			}
+		}
+		if !verifyEndpoints(r, proxyURL, endpoints, proxy.GetCertPool(bundle)) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Proxy configuration verified")
+}
+
+// getRequiredEndpoints returns the endpoints that a cluster in the region must reach. Wildcard endpoints
+// can't be verified and are skipped.
+func getRequiredEndpoints(region string) ([]string, error) {
+	requirements, err := egress.GetRequirements(&egress.Options{
+		Region:  region,
+		FedRAMP: fedramp.Enabled() || fedramp.IsGovRegion(region),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []string
+	for _, requirement := range requirements {
+		if !requirement.Wildcard {
+			endpoints = append(endpoints, requirement.Address())
+		}
+	}
+	return endpoints, nil
+}
+
+// verifyCertificates prints the certificates of the bundle and returns false when any of them is
+// invalid or can't be chained to a trusted root
+func verifyCertificates(r *rosa.Runtime, bundle []*x509.Certificate) bool {
+	now := time.Now()
+	warning := time.Duration(args.expirationWarningDays) * 24 * time.Hour
+
+	valid := true
+	var warnings []string
+	var chainErrors []string
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "#\tSUBJECT\tISSUER\tNOT AFTER\tCA\tSTATUS\n")
+	for i, cert := range bundle {
+		info := proxy.DescribeCertificate(cert, now, warning)
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%t\t%s\n",
+			i+1,
+			info.Subject,
+			info.Issuer,
+			info.NotAfter.Format("2006-01-02 15:04 MST"),
+			info.IsCA,
+			info.Status,
+		)
+		switch info.Status {
+		case proxy.StatusExpired, proxy.StatusNotYetValid:
+			valid = false
+			continue
+		case proxy.StatusExpiring:
+			warnings = append(warnings, fmt.Sprintf("Certificate %d '%s' expires on %s", i+1, info.Subject,
+				info.NotAfter.Format("2006-01-02")))
+		}
+		err := proxy.VerifyChain(cert, bundle, now)
+		if err != nil {
+			chainErrors = append(chainErrors, fmt.Sprintf("Certificate %d '%s': %v", i+1, info.Subject, err))
+		}
+	}
+	writer.Flush()
+
+	for _, warning := range warnings {
+		r.Reporter.Warnf("%s", warning)
+	}
+	if !valid {
+		r.Reporter.Errorf("The additional trust bundle contains certificates that aren't valid")
+	}
+	for _, chainError := range chainErrors {
+		r.Reporter.Errorf("Failed to build certificate chain: %s", chainError)
+	}
+	return valid && len(chainErrors) == 0
+}
+
+// verifyEndpoints opens a tunnel to each endpoint through the proxy and returns false when any of them
+// can't be reached
+func verifyEndpoints(r *rosa.Runtime, proxyURL *url.URL, endpoints []string, rootCAs *x509.CertPool) bool {
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Verifying %d endpoints through proxy '%s'...", len(endpoints), proxyURL.Redacted())
+	}
+	reachable := true
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ENDPOINT\tRESULT\n")
+	for _, endpoint := range endpoints {
+		result := "OK"
+		err := proxy.TestTunnel(proxyURL, endpoint, rootCAs, args.timeout)
+		if err != nil {
+			result = err.Error()
+			reachable = false
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", endpoint, result)
+	}
+	writer.Flush()
+	if !reachable {
+		r.Reporter.Errorf("Some of the endpoints can't be reached through the proxy")
+	}
+	return reachable
+}
@@ -18,38 +18,199 @@ package quota
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	multiAZ                   bool
+	private                   bool
+	subnetIDs                 []string
+	controlPlaneMachineType   string
+	infraMachineType          string
+	computeMachineType        string
+	computeNodes              int
+	autoscalingEnabled        bool
+	maxReplicas               int
+	controlPlaneVolumeSizeGiB int
+	infraVolumeSizeGiB        int
+	computeVolumeSizeGiB      int
+	requestIncrease           bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "quota",
 	Short: "Verify AWS quota is ok for cluster install",
-	Long:  "Verify AWS quota needed to create a cluster is configured as expected",
+	Long: "Verify AWS quota needed to create a cluster is configured as expected. " +
+		"The quota required is computed from the planned cluster and compared against the applied quota " +
+		"and the current usage of the region.",
 	Example: `  # Verify AWS quotas are configured correctly
   rosa verify quota
 
   # Verify AWS quotas in a different region
-  rosa verify quota --region=us-west-2`,
+  rosa verify quota --region=us-west-2
+
+  # Verify AWS quotas for a multi-AZ cluster with up to 12 r5.2xlarge compute nodes
+  rosa verify quota --multi-az --compute-machine-type=r5.2xlarge --enable-autoscaling --max-replicas=12
+
+  # Request an increase of the AWS quotas that are insufficient for the planned cluster
+  rosa verify quota --request-increase`,
 	RunE: run,
 }
 
 func init() {
 	flags := Cmd.Flags()
+	flags.SortFlags = false
 
 	arguments.AddRegionFlag(flags)
 	arguments.AddProfileFlag(flags)
+
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Verify the quota needed to deploy to multiple data centers.",
+	)
+	flags.BoolVar(
+		&args.private,
+		"private",
+		false,
+		"Verify the quota needed by a cluster with a private API endpoint.",
+	)
+	flags.StringSliceVar(
+		&args.subnetIDs,
+		"subnet-ids",
+		nil,
+		"The Subnet IDs the cluster will be installed into. When set, no quota is required for the VPC.",
+	)
+	flags.StringVar(
+		&args.computeMachineType,
+		"compute-machine-type",
+		ocm.DefaultComputeMachineType,
+		"Instance type for the compute nodes.",
+	)
+	flags.IntVar(
+		&args.computeNodes,
+		"replicas",
+		0,
+		"Number of compute nodes. Defaults to 2 for single zone clusters and 3 for multizone clusters.",
+	)
+	flags.BoolVar(
+		&args.autoscalingEnabled,
+		"enable-autoscaling",
+		false,
+		"Verify the quota needed by the maximum number of compute nodes.",
+	)
+	flags.IntVar(
+		&args.maxReplicas,
+		"max-replicas",
+		0,
+		"Maximum number of compute nodes when autoscaling is enabled.",
+	)
+	flags.IntVar(
+		&args.computeVolumeSizeGiB,
+		"compute-volume-size",
+		ocm.DefaultComputeVolumeSizeGiB,
+		"Size in GiB of the root volume of the compute nodes.",
+	)
+	flags.StringVar(
+		&args.controlPlaneMachineType,
+		"control-plane-machine-type",
+		ocm.DefaultControlPlaneMachineType,
+		"Instance type for the control plane nodes.",
+	)
+	flags.IntVar(
+		&args.controlPlaneVolumeSizeGiB,
+		"control-plane-volume-size",
+		ocm.DefaultControlPlaneVolumeSizeGiB,
+		"Size in GiB of the root volume of the control plane nodes.",
+	)
+	flags.StringVar(
+		&args.infraMachineType,
+		"infra-machine-type",
+		ocm.DefaultInfraMachineType,
+		"Instance type for the infra nodes.",
+	)
+	flags.IntVar(
+		&args.infraVolumeSizeGiB,
+		"infra-volume-size",
+		ocm.DefaultInfraVolumeSizeGiB,
+		"Size in GiB of the root volume of the infra nodes.",
+	)
+	flags.BoolVar(
+		&args.requestIncrease,
+		"request-increase",
+		false,
+		"Request an increase of the insufficient quotas to the value required by the planned cluster. "+
+			"Quotas that already have an open increase request are skipped.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+// buildClusterSpec describes the planned cluster from the command line options
+func buildClusterSpec() (*aws.QuotaClusterSpec, error) {
+	defaultNodes := ocm.GetDefaultNodes(args.multiAZ)
+	computeNodes := args.computeNodes
+	if args.autoscalingEnabled {
+		computeNodes = args.maxReplicas
+		if computeNodes == 0 {
+			return nil, fmt.Errorf("The maximum number of replicas is required when autoscaling is enabled")
+		}
+	}
+	if computeNodes == 0 {
+		computeNodes = defaultNodes
+	}
+	if computeNodes < defaultNodes {
+		return nil, fmt.Errorf("The number of compute nodes needs to be at least %d", defaultNodes)
+	}
+
+	return &aws.QuotaClusterSpec{
+		MultiAZ: args.multiAZ,
+		Private: args.private,
+		BYOVPC:  len(args.subnetIDs) > 0,
+		MachinePools: []aws.QuotaMachinePool{
+			{
+				Name:          "control-plane",
+				InstanceType:  args.controlPlaneMachineType,
+				Replicas:      ocm.ControlPlaneReplicas,
+				VolumeSizeGiB: args.controlPlaneVolumeSizeGiB,
+			},
+			{
+				Name:          "infra",
+				InstanceType:  args.infraMachineType,
+				Replicas:      defaultNodes,
+				VolumeSizeGiB: args.infraVolumeSizeGiB,
+			},
+			{
+				Name:          "compute",
+				InstanceType:  args.computeMachineType,
+				Replicas:      computeNodes,
+				VolumeSizeGiB: args.computeVolumeSizeGiB,
+			},
+		},
+	}, nil
 }
 
 func run(cmd *cobra.Command, _ []string) (err error) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
 
+	spec, err := buildClusterSpec()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		return err
+	}
+
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
@@ -71,10 +232,67 @@ func run(cmd *cobra.Command, _ []string) (err error) {
 		return err
 	}
 
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Validating AWS quota...")
+	}
+	statuses, err := r.AWSClient.GetQuotaStatus(spec)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		return err
+	}
+
+	// Find the increase requests of the insufficient quotas before printing, so that they are included
+	// in the output
+	err = aws.ValidateQuota(statuses)
+	var requests []*aws.QuotaIncreaseRequest
+	if err != nil {
+		requests = getQuotaIncreaseRequests(r, statuses)
+	}
+
+	if output.HasFlag() {
+		printErr := output.Print(statuses)
+		if printErr != nil {
+			r.Reporter.Errorf("%s", printErr)
+			return printErr
+		}
+	} else if r.Reporter.IsTerminal() {
+		printQuotaStatus(statuses)
+	}
+
+	if err != nil {
+		r.OCMClient.LogEvent("ROSAVerifyQuotaInsufficient", nil)
+		if !output.HasFlag() {
+			r.Reporter.Errorf("Insufficient AWS quotas")
+			r.Reporter.Errorf("%v", err)
+		}
+		if !output.HasFlag() && r.Reporter.IsTerminal() {
+			if len(requests) > 0 {
+				r.Reporter.Infof("AWS quota increase requests:")
+				printQuotaIncreaseRequests(requests)
+			}
+			if !args.requestIncrease {
+				r.Reporter.Infof("To request an increase of the insufficient quotas, run " +
+					"'rosa verify quota --request-increase' with the same options")
+			}
+		}
+		return err
+	}
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("AWS quota ok. " +
+			"If cluster installation fails, validate actual AWS resource usage against " +
+			"https://docs.openshift.com/rosa/rosa_getting_started/rosa-required-aws-service-quotas.html")
+	}
+	return nil
+}
+
+// ValidateMinimumQuota checks that the AWS quotas reach the minimum values needed to install clusters. It
+// is used by the commands that prepare the account, where no cluster is planned yet and the resources
+// used by existing clusters aren't taken into account.
+func ValidateMinimumQuota(r *rosa.Runtime, awsClient aws.Client) error {
 	if r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Validating AWS quota...")
 	}
-	_, err = r.AWSClient.ValidateQuota()
+	err := awsClient.ValidateMinimumQuota()
 	if err != nil {
 		r.OCMClient.LogEvent("ROSAVerifyQuotaInsufficient", nil)
 		r.Reporter.Errorf("Insufficient AWS quotas")
@@ -88,3 +306,101 @@ func run(cmd *cobra.Command, _ []string) (err error) {
 	}
 	return nil
 }
+
+// getQuotaIncreaseRequests finds the open increase requests of the insufficient quotas and sets them in
+// their statuses. When requested by the user, quotas without an open request are increased to the value
+// required by the planned cluster.
+func getQuotaIncreaseRequests(r *rosa.Runtime, statuses []*aws.QuotaStatus) []*aws.QuotaIncreaseRequest {
+	var requests []*aws.QuotaIncreaseRequest
+	requestsByService := make(map[string][]*aws.QuotaIncreaseRequest)
+	for _, status := range statuses {
+		if status.Sufficient {
+			continue
+		}
+		serviceRequests, ok := requestsByService[status.ServiceCode]
+		if !ok {
+			var err error
+			serviceRequests, err = r.AWSClient.ListQuotaIncreaseRequests(status.ServiceCode)
+			if err != nil {
+				r.Reporter.Warnf("Failed to list quota increase requests for service '%s': %v",
+					status.ServiceCode, err)
+			}
+			requestsByService[status.ServiceCode] = serviceRequests
+		}
+
+		var openRequest *aws.QuotaIncreaseRequest
+		for _, request := range serviceRequests {
+			if request.QuotaCode == status.QuotaCode && request.IsOpen() {
+				openRequest = request
+				break
+			}
+		}
+		if openRequest != nil {
+			status.IncreaseRequest = openRequest
+			requests = append(requests, openRequest)
+			continue
+		}
+		if !args.requestIncrease {
+			continue
+		}
+
+		r.Reporter.Debugf("Requesting increase of service %s quota code %s to %g",
+			status.ServiceCode, status.QuotaCode, aws.GetDesiredQuotaValue(status))
+		request, err := r.AWSClient.RequestQuotaIncrease(status)
+		if err != nil {
+			r.Reporter.Errorf("Failed to request increase of service %s quota code %s: %v",
+				status.ServiceCode, status.QuotaCode, err)
+			continue
+		}
+		r.OCMClient.LogEvent("ROSAVerifyQuotaIncreaseRequested", map[string]string{
+			"service_code": status.ServiceCode,
+			"quota_code":   status.QuotaCode,
+		})
+		status.IncreaseRequest = request
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func printQuotaIncreaseRequests(requests []*aws.QuotaIncreaseRequest) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SERVICE\tQUOTA CODE\tQUOTA NAME\tDESIRED VALUE\tSTATUS\tCASE ID\tREQUEST ID\n")
+	for _, request := range requests {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%g\t%s\t%s\t%s\n",
+			request.ServiceCode,
+			request.QuotaCode,
+			request.QuotaName,
+			request.DesiredValue,
+			request.Status,
+			request.CaseID,
+			request.ID,
+		)
+	}
+	writer.Flush()
+}
+
+func printQuotaStatus(statuses []*aws.QuotaStatus) {
+	// Create the writer that will be used to print the tabulated results:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SERVICE\tQUOTA CODE\tQUOTA NAME\tREQUIRED\tCURRENT USAGE\tAPPLIED\tSTATUS\n")
+	for _, status := range statuses {
+		usage := "N/A"
+		if status.Usage != nil {
+			usage = fmt.Sprintf("%g", *status.Usage)
+		}
+		result := "OK"
+		if !status.Sufficient {
+			result = "Insufficient"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%g\t%s\t%g\t%s\n",
+			status.ServiceCode,
+			status.QuotaCode,
+			status.QuotaName,
+			status.Required,
+			usage,
+			status.Applied,
+			result,
+		)
+	}
+	writer.Flush()
+}
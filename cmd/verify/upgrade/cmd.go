@@ -0,0 +1,352 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Verify that a cluster is ready to be upgraded",
+	Long: "Verify that a cluster is ready to be upgraded to a version. The report covers the version gates, " +
+		"the account and operator roles, the limited support reasons, the add-ons and the machine pools " +
+		"of the cluster, so that the problems can be fixed before scheduling the upgrade.",
+	Example: `  # Verify that the cluster named "mycluster" can be upgraded to 4.12.10
+  rosa verify upgrade -c mycluster --version 4.12.10`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of OpenShift that the cluster will be upgraded to.",
+	)
+	Cmd.MarkFlagRequired("version")
+
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	minorVersion, err := ocm.ParseVersion(args.version)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid version: %v", err)
+		os.Exit(1)
+	}
+
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Verifying that cluster '%s' can be upgraded to version '%s'...",
+			clusterKey, args.version)
+	}
+	checks := []*ocm.UpgradeCheck{
+		checkVersion(r, cluster),
+		checkGates(r, cluster),
+	}
+	if cluster.AWS().STS().RoleARN() != "" {
+		checks = append(checks,
+			checkAccountRolePolicies(r, cluster, minorVersion),
+			checkOperatorRolePolicies(r, cluster, minorVersion),
+			checkMissingOperatorRoles(r, cluster, minorVersion),
+		)
+	}
+	checks = append(checks,
+		checkLimitedSupport(r, cluster),
+		checkAddOns(r, cluster),
+		checkMachinePools(r, cluster),
+	)
+
+	if output.HasFlag() {
+		err = output.Print(checks)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	} else {
+		printChecks(checks)
+	}
+
+	failed := 0
+	warned := 0
+	for _, check := range checks {
+		switch check.Status {
+		case ocm.UpgradeCheckFail:
+			failed++
+		case ocm.UpgradeCheckWarn:
+			warned++
+		}
+	}
+	if failed > 0 {
+		if !output.HasFlag() {
+			r.Reporter.Errorf("Cluster '%s' isn't ready to be upgraded to version '%s': %d checks failed",
+				clusterKey, args.version, failed)
+		}
+		os.Exit(1)
+	}
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		if warned > 0 {
+			r.Reporter.Warnf("Cluster '%s' can be upgraded to version '%s', review the %d warnings before "+
+				"scheduling the upgrade", clusterKey, args.version, warned)
+		} else {
+			r.Reporter.Infof("Cluster '%s' is ready to be upgraded to version '%s'", clusterKey, args.version)
+		}
+	}
+}
+
+// newCheck creates a check that passes when there are no details
+func newCheck(name string, status ocm.UpgradeCheckStatus, details []string) *ocm.UpgradeCheck {
+	if len(details) == 0 {
+		status = ocm.UpgradeCheckPass
+	}
+	return &ocm.UpgradeCheck{
+		Name:    name,
+		Status:  status,
+		Details: details,
+	}
+}
+
+// newErrorCheck creates a check that couldn't be completed
+func newErrorCheck(name string, err error) *ocm.UpgradeCheck {
+	return &ocm.UpgradeCheck{
+		Name:    name,
+		Status:  ocm.UpgradeCheckWarn,
+		Details: []string{fmt.Sprintf("Unable to verify: %v", err)},
+	}
+}
+
+func checkVersion(r *rosa.Runtime, cluster *cmv1.Cluster) *ocm.UpgradeCheck {
+	name := "Available upgrade"
+	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	err = r.OCMClient.CheckUpgradeClusterVersion(availableUpgrades, args.version, cluster)
+	if err != nil {
+		details = append(details, fmt.Sprintf("Version '%s' isn't an available upgrade. Run "+
+			"'rosa describe upgrade-path -c %s --to %s' to find the intermediate upgrades",
+			args.version, r.ClusterKey, args.version))
+	}
+	return newCheck(name, ocm.UpgradeCheckFail, details)
+}
+
+func checkGates(r *rosa.Runtime, cluster *cmv1.Cluster) *ocm.UpgradeCheck {
+	name := "Version gates"
+	upgradePolicy, err := cmv1.NewUpgradePolicy().
+		ScheduleType("manual").
+		Version(args.version).
+		Build()
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	gates, err := r.OCMClient.GetMissingGateAgreements(cluster.ID(), upgradePolicy)
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	for _, gate := range gates {
+		// STS gates are acknowledged when the roles are upgraded
+		if gate.STSOnly() {
+			continue
+		}
+		details = append(details, fmt.Sprintf("Requires acknowledging: %s (%s)",
+			strings.TrimSpace(gate.Description()), gate.DocumentationURL()))
+	}
+	return newCheck(name, ocm.UpgradeCheckWarn, details)
+}
+
+func checkAccountRolePolicies(r *rosa.Runtime, cluster *cmv1.Cluster, minorVersion string) *ocm.UpgradeCheck {
+	name := "Account role policies"
+	isUpgradeNeeded, err := r.AWSClient.IsUpgradedNeededForAccountRolePoliciesForCluster(cluster, minorVersion)
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	if isUpgradeNeeded {
+		details = append(details, fmt.Sprintf("The policies aren't compatible with version '%s'. Run "+
+			"'rosa upgrade roles -c %s --cluster-version %s'", minorVersion, r.ClusterKey, args.version))
+	}
+	return newCheck(name, ocm.UpgradeCheckFail, details)
+}
+
+func checkOperatorRolePolicies(r *rosa.Runtime, cluster *cmv1.Cluster, minorVersion string) *ocm.UpgradeCheck {
+	name := "Operator role policies"
+	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	operatorRolePolicyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	isUpgradeNeeded, err := r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(
+		cluster,
+		r.Creator.AccountID,
+		minorVersion,
+		credRequests,
+		operatorRolePolicyPrefix,
+	)
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	if isUpgradeNeeded {
+		details = append(details, fmt.Sprintf("The policies aren't compatible with version '%s'. Run "+
+			"'rosa upgrade roles -c %s --cluster-version %s'", minorVersion, r.ClusterKey, args.version))
+	}
+	return newCheck(name, ocm.UpgradeCheckFail, details)
+}
+
+func checkMissingOperatorRoles(r *rosa.Runtime, cluster *cmv1.Cluster, minorVersion string) *ocm.UpgradeCheck {
+	name := "Operator roles"
+	missingRoles, err := r.OCMClient.FindMissingOperatorRolesForUpgrade(cluster, minorVersion)
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	for _, operator := range missingRoles {
+		details = append(details, fmt.Sprintf("Missing role for operator '%s' in namespace '%s'",
+			operator.Name(), operator.Namespace()))
+	}
+	sort.Strings(details)
+	if len(details) > 0 {
+		details = append(details, fmt.Sprintf("Run 'rosa upgrade roles -c %s --cluster-version %s'",
+			r.ClusterKey, args.version))
+	}
+	return newCheck(name, ocm.UpgradeCheckFail, details)
+}
+
+func checkLimitedSupport(r *rosa.Runtime, cluster *cmv1.Cluster) *ocm.UpgradeCheck {
+	name := "Limited support"
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	for _, reason := range reasons {
+		details = append(details, reason.Summary())
+	}
+	return newCheck(name, ocm.UpgradeCheckWarn, details)
+}
+
+func checkAddOns(r *rosa.Runtime, cluster *cmv1.Cluster) *ocm.UpgradeCheck {
+	name := "Add-ons"
+	installations, err := r.OCMClient.GetAddOnInstallations(cluster.ID())
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	status := ocm.UpgradeCheckWarn
+	var details []string
+	for _, installation := range installations {
+		addOnID := installation.Addon().ID()
+		switch installation.State() {
+		case cmv1.AddOnInstallationStateReady:
+		case cmv1.AddOnInstallationStateFailed:
+			status = ocm.UpgradeCheckFail
+			details = append(details, fmt.Sprintf("Add-on '%s' failed: %s", addOnID,
+				installation.StateDescription()))
+		default:
+			details = append(details, fmt.Sprintf("Add-on '%s' is %s", addOnID, installation.State()))
+		}
+
+		addOn, err := r.OCMClient.GetAddOn(addOnID)
+		if err != nil {
+			return newErrorCheck(name, err)
+		}
+		for _, requirement := range addOn.Requirements() {
+			if requirement.Status() == nil || requirement.Status().Fulfilled() {
+				continue
+			}
+			status = ocm.UpgradeCheckFail
+			details = append(details, fmt.Sprintf("Add-on '%s' requirement '%s' isn't fulfilled: %s",
+				addOnID, requirement.ID(), strings.Join(requirement.Status().ErrorMsgs(), ", ")))
+		}
+	}
+	return newCheck(name, status, details)
+}
+
+func checkMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster) *ocm.UpgradeCheck {
+	name := "Machine pools"
+	// The machine pools of classic clusters are upgraded with the cluster
+	if !cluster.Hypershift().Enabled() {
+		return newCheck(name, ocm.UpgradeCheckPass, nil)
+	}
+	versions, err := r.OCMClient.GetNodePoolVersions(cluster.ID(), cluster.Version().ChannelGroup())
+	if err != nil {
+		return newErrorCheck(name, err)
+	}
+	var details []string
+	for nodePoolID, version := range versions {
+		if version == "" {
+			continue
+		}
+		err = ocm.ValidateNodePoolVersionSkew(args.version, version)
+		if err != nil {
+			details = append(details, fmt.Sprintf("Machine pool '%s': %v", nodePoolID, err))
+		}
+	}
+	sort.Strings(details)
+	return newCheck(name, ocm.UpgradeCheckFail, details)
+}
+
+func printChecks(checks []*ocm.UpgradeCheck) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CHECK\tSTATUS\tDETAILS\n")
+	for _, check := range checks {
+		details := check.Details
+		if len(details) == 0 {
+			details = []string{""}
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", check.Name, strings.ToUpper(string(check.Status)), details[0])
+		for _, detail := range details[1:] {
+			fmt.Fprintf(writer, "\t\t%s\n", detail)
+		}
+	}
+	writer.Flush()
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the helpers of the admin users created with 'rosa create admin'. Admins can expire,
+// in which case the expiration is stored in the properties of the cluster and applied by the
+// 'rosa schedule run' reconciler.
+
+package admin
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+// usernameRE matches the names that are valid both as htpasswd and OpenShift users
+var usernameRE = regexp.MustCompile(`^[a-zA-Z0-9]([-._a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
+// ValidateUsername checks that the name of an admin can be used as an htpasswd user
+func ValidateUsername(username string) error {
+	if !usernameRE.MatchString(username) {
+		return fmt.Errorf("Invalid admin name '%s': it must contain only letters, digits, '-', '.' and '_', "+
+			"and start and end with a letter or a digit", username)
+	}
+	return nil
+}
+
+// HasUser returns true if the list of htpasswd users contains the given user
+func HasUser(userList *cmv1.HTPasswdUserList, username string) bool {
+	found := false
+	userList.Each(func(user *cmv1.HTPasswdUser) bool {
+		if user.Username() == username {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// Expirations returns the expiration times of the admins of a cluster, indexed by username
+func Expirations(cluster *cmv1.Cluster) map[string]time.Time {
+	result := make(map[string]time.Time)
+	for key, value := range cluster.Properties() {
+		if !strings.HasPrefix(key, properties.AdminExpirationPrefix) {
+			continue
+		}
+		expiration, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		result[strings.TrimPrefix(key, properties.AdminExpirationPrefix)] = expiration
+	}
+	return result
+}
+
+// Expired returns the sorted names of the admins of a cluster that expired at the given time
+func Expired(cluster *cmv1.Cluster, now time.Time) []string {
+	var result []string
+	for username, expiration := range Expirations(cluster) {
+		if !now.Before(expiration) {
+			result = append(result, username)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Properties returns the properties of a cluster with the expiration of an admin. A zero expiration
+// removes it.
+func Properties(clusterProperties map[string]string, username string, expiration time.Time) map[string]string {
+	result := make(map[string]string)
+	for key, value := range clusterProperties {
+		result[key] = value
+	}
+	delete(result, properties.AdminExpirationPrefix+username)
+	if !expiration.IsZero() {
+		result[properties.AdminExpirationPrefix+username] = expiration.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// GeneratePassword returns a random password of the given length that is accepted by the htpasswd
+// identity provider
+func GeneratePassword(length int) (string, error) {
+	const (
+		lowerLetters = "abcdefghijkmnopqrstuvwxyz"
+		upperLetters = "ABCDEFGHIJKLMNPQRSTUVWXYZ"
+		digits       = "23456789"
+		all          = lowerLetters + upperLetters + digits
+	)
+	var password string
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(all))))
+		if err != nil {
+			return "", err
+		}
+		newchar := string(all[n.Int64()])
+		if password == "" {
+			password = newchar
+		}
+		if i < length-1 {
+			n, err = rand.Int(rand.Reader, big.NewInt(int64(len(password)+1)))
+			if err != nil {
+				return "", err
+			}
+			j := n.Int64()
+			password = password[0:j] + newchar + password[j:]
+		}
+	}
+
+	pw := []rune(password)
+	for _, replace := range []int{5, 11, 17} {
+		pw[replace] = '-'
+	}
+
+	return string(pw), nil
+}
+
+// SavePassword writes a password to a file readable only by the user, or to the standard input of a
+// command such as a password manager. Empty destinations are ignored.
+func SavePassword(password string, file string, command string) error {
+	if file != "" {
+		err := os.WriteFile(file, []byte(password+"\n"), 0600)
+		if err != nil {
+			return fmt.Errorf("Failed to write password to file '%s': %v", file, err)
+		}
+	}
+	if command != "" {
+		fields := strings.Fields(command)
+		// #nosec G204 -- the command is given by the user to store their own password
+		cmd := exec.Command(fields[0], fields[1:]...)
+		cmd.Stdin = strings.NewReader(password + "\n")
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("Failed to pass password to command '%s': %v", fields[0], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package admin_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/admin"
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+var _ = Describe("Admin", func() {
+	now := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	buildCluster := func(props map[string]string) *cmv1.Cluster {
+		cluster, err := cmv1.NewCluster().Properties(props).Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	It("Stores and removes expirations in the cluster properties", func() {
+		props := admin.Properties(map[string]string{properties.CLIVersion: "1.2.10"}, "breakglass",
+			now.Add(8*time.Hour))
+		Expect(props).To(HaveKeyWithValue(properties.AdminExpirationPrefix+"breakglass", "2022-11-01T18:00:00Z"))
+		Expect(props).To(HaveKeyWithValue(properties.CLIVersion, "1.2.10"))
+		Expect(admin.Expirations(buildCluster(props))).To(Equal(map[string]time.Time{
+			"breakglass": now.Add(8 * time.Hour),
+		}))
+
+		props = admin.Properties(props, "breakglass", time.Time{})
+		Expect(props).To(Equal(map[string]string{properties.CLIVersion: "1.2.10"}))
+	})
+
+	It("Returns the admins that expired", func() {
+		cluster := buildCluster(map[string]string{
+			properties.AdminExpirationPrefix + "cluster-admin": "2022-11-01T10:00:00Z",
+			properties.AdminExpirationPrefix + "breakglass":    "2022-11-01T09:00:00Z",
+			properties.AdminExpirationPrefix + "oncall":        "2022-11-01T11:00:00Z",
+			properties.AdminExpirationPrefix + "invalid":       "tomorrow",
+		})
+		Expect(admin.Expired(cluster, now)).To(Equal([]string{"breakglass", "cluster-admin"}))
+	})
+
+	It("Validates the names of admins", func() {
+		Expect(admin.ValidateUsername("cluster-admin")).To(Succeed())
+		Expect(admin.ValidateUsername("break.glass_1")).To(Succeed())
+		Expect(admin.ValidateUsername("")).ToNot(Succeed())
+		Expect(admin.ValidateUsername("admin:x")).ToNot(Succeed())
+		Expect(admin.ValidateUsername("-admin")).ToNot(Succeed())
+	})
+
+	It("Generates random passwords", func() {
+		first, err := admin.GeneratePassword(23)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first).To(HaveLen(23))
+		Expect(first).To(MatchRegexp(`^[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}$`))
+		second, err := admin.GeneratePassword(23)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).ToNot(Equal(first))
+	})
+
+	It("Saves passwords to a file and to a command", func() {
+		dir := GinkgoT().TempDir()
+		file := filepath.Join(dir, "password")
+		piped := filepath.Join(dir, "piped")
+		Expect(admin.SavePassword("secret", file, "tee "+piped)).To(Succeed())
+
+		info, err := os.Stat(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		Expect(os.ReadFile(file)).To(Equal([]byte("secret\n")))
+		Expect(os.ReadFile(piped)).To(Equal([]byte("secret\n")))
+
+		Expect(admin.SavePassword("secret", "", "false")).ToNot(Succeed())
+	})
+})
@@ -37,6 +37,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
@@ -93,7 +95,12 @@ type Client interface {
 	GetSubnetIDs() ([]*ec2.Subnet, error)
 	GetSubnetAvailabilityZone(subnetID string) (string, error)
 	GetVPCPrivateSubnets(subnetID string) ([]*ec2.Subnet, error)
-	ValidateQuota() (bool, error)
+	GetSubnetDetails(subnets []*ec2.Subnet) ([]*SubnetDetail, error)
+	ValidateMinimumQuota() error
+	GetQuotaStatus(spec *QuotaClusterSpec) ([]*QuotaStatus, error)
+	GetQuotaUsage() ([]*QuotaUsage, error)
+	RequestQuotaIncrease(status *QuotaStatus) (*QuotaIncreaseRequest, error)
+	ListQuotaIncreaseRequests(serviceCode string) ([]*QuotaIncreaseRequest, error)
 	TagUserRegion(username string, region string) error
 	GetClusterRegionTagForUser(username string) (string, error)
 	EnsureRole(name string, policy string, permissionsBoundary string,
@@ -126,6 +133,8 @@ type Client interface {
 	GetAttachedPolicy(role *string) ([]PolicyDetail, error)
 	HasPermissionsBoundary(roleName string) (bool, error)
 	GetOpenIDConnectProvider(clusterID string) (string, error)
+	DescribeOpenIDConnectProvider(issuerURL string, accountID string) (*iam.GetOpenIDConnectProviderOutput, error)
+	GetRolePolicyDocuments(roleARN string) (*RolePolicies, error)
 	GetInstanceProfilesForRole(role string) ([]string, error)
 	IsUpgradedNeededForAccountRolePolicies(rolePrefix string, version string) (bool, error)
 	IsUpgradedNeededForAccountRolePoliciesForCluster(clusterID *cmv1.Cluster, version string) (bool, error)
@@ -155,6 +164,16 @@ type Client interface {
 	GetRoleARNPath(prefix string) (string, error)
 	DescribeAvailabilityZones() ([]string, error)
 	IsLocalAvailabilityZone(availabilityZoneName string) (bool, error)
+	GetAvailabilityZones(availabilityZoneNames []string) ([]*ec2.AvailabilityZone, error)
+	GetInstanceTypesInAvailabilityZone(availabilityZoneName string) ([]string, error)
+	GetVPCSubnets(subnetID string) ([]*ec2.Subnet, error)
+	ValidateKMSKey(keyARN string, region string) error
+	GetKMSKeyPolicy(keyARN string) (*PolicyDocument, error)
+	GetKMSKeyActionsDeniedByIAM(roleARN string, keyARN string, actions []string) ([]string, error)
+	CreateKMSKey(name string, description string, policy string, keyTags map[string]string) (string, error)
+	GetSpotPriceHistory(instanceType string, availabilityZones []string, since time.Time) ([]*ec2.SpotPrice, error)
+	GetSpotPriceSummaries(region string, availabilityZones []string, instanceType string,
+		days int) ([]*SpotPriceSummary, error)
 	DetachRolePolicies(roleName string) error
 }
 
@@ -173,6 +192,7 @@ type awsClient struct {
 	stsClient           stsiface.STSAPI
 	cfClient            cloudformationiface.CloudFormationAPI
 	servicequotasClient servicequotasiface.ServiceQuotasAPI
+	kmsClient           kmsiface.KMSAPI
 	awsSession          *session.Session
 	awsAccessKeys       *AccessKey
 }
@@ -202,6 +222,7 @@ func New(
 	stsClient stsiface.STSAPI,
 	cfClient cloudformationiface.CloudFormationAPI,
 	servicequotasClient servicequotasiface.ServiceQuotasAPI,
+	kmsClient kmsiface.KMSAPI,
 	awsSession *session.Session,
 	awsAccessKeys *AccessKey,
 
@@ -214,6 +235,7 @@ func New(
 		stsClient,
 		cfClient,
 		servicequotasClient,
+		kmsClient,
 		awsSession,
 		awsAccessKeys,
 	}
@@ -356,6 +378,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 		stsClient:           sts.New(sess),
 		cfClient:            cloudformation.New(sess),
 		servicequotasClient: servicequotas.New(sess),
+		kmsClient:           kms.New(sess),
 		awsSession:          sess,
 	}
 
@@ -404,6 +427,11 @@ func (c *awsClient) GetVPCPrivateSubnets(subnetID string) ([]*ec2.Subnet, error)
 	return c.filterVPCsPrivateSubnets(subnets)
 }
 
+// GetVPCSubnets fetches all the subnets that belong to the same VPC as the provided subnet.
+func (c *awsClient) GetVPCSubnets(subnetID string) ([]*ec2.Subnet, error) {
+	return c.getVPCSubnets(subnetID)
+}
+
 // getVPCSubnets gets a subnet ID and fetches all the subnets that belong to the same VPC as the provided subnet.
 func (c *awsClient) getVPCSubnets(subnetID string) ([]*ec2.Subnet, error) {
 	// Fetch the subnet details
@@ -852,7 +880,7 @@ func (c *awsClient) DescribeAvailabilityZones() ([]string, error) {
 		Filters: []*ec2.Filter{
 			{
 				Name:   aws.String("zone-type"),
-				Values: []*string{aws.String("availability-zone")},
+				Values: []*string{aws.String(AvailabilityZoneType)},
 			},
 		},
 	})
@@ -878,7 +906,44 @@ func (c *awsClient) IsLocalAvailabilityZone(availabilityZoneName string) (bool,
 		return false, fmt.Errorf("Failed to find availability zone '%s'", availabilityZoneName)
 	}
 
-	return aws.StringValue(availabilityZones.AvailabilityZones[0].ZoneType) == "local-zone", nil
+	return aws.StringValue(availabilityZones.AvailabilityZones[0].ZoneType) == LocalZoneType, nil
+}
+
+// GetAvailabilityZones fetches the given zones of any type, including the ones the account didn't opt in to
+func (c *awsClient) GetAvailabilityZones(availabilityZoneNames []string) ([]*ec2.AvailabilityZone, error) {
+	describeAvailabilityZonesOutput, err := c.ec2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            aws.StringSlice(availabilityZoneNames),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return describeAvailabilityZonesOutput.AvailabilityZones, nil
+}
+
+// GetInstanceTypesInAvailabilityZone fetches the instance types offered in the given zone
+func (c *awsClient) GetInstanceTypesInAvailabilityZone(availabilityZoneName string) ([]string, error) {
+	var instanceTypes []string
+	err := c.ec2Client.DescribeInstanceTypeOfferingsPages(&ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("location"),
+				Values: []*string{aws.String(availabilityZoneName)},
+			},
+		},
+	}, func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+		for _, offering := range page.InstanceTypeOfferings {
+			instanceTypes = append(instanceTypes, aws.StringValue(offering.InstanceType))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceTypes, nil
 }
 
 func (c *awsClient) DetachRolePolicies(roleName string) error {
@@ -37,6 +37,7 @@ var _ = Describe("Client", func() {
 			mocks.NewMockSTSAPI(mockCtrl),
 			mockCfAPI,
 			mocks.NewMockServiceQuotasAPI(mockCtrl),
+			mocks.NewMockKMSAPI(mockCtrl),
 			&session.Session{},
 			&aws.AccessKey{},
 		)
@@ -11,7 +11,9 @@ const ParamNewLineSeparator = " \\\n"
 type Service string
 
 const (
-	IAM Service = "iam"
+	IAM             Service = "iam"
+	KMS             Service = "kms"
+	NetworkFirewall Service = "network-firewall"
 )
 
 type Command string
@@ -30,6 +32,10 @@ const (
 	CreateOpenIdConnectProvider   Command = "create-open-id-connect-provider"
 	DeleteOpenIdConnectProvider   Command = "delete-open-id-connect-provider"
 	DeleteRolePermissionsBoundary Command = "delete-role-permissions-boundary"
+	CreateKey                     Command = "create-key"
+	CreateAlias                   Command = "create-alias"
+	PutKeyPolicy                  Command = "put-key-policy"
+	CreateRuleGroup               Command = "create-rule-group"
 )
 
 type Param string
@@ -48,6 +54,17 @@ const (
 	ThumbprintList           Param = "thumbprint-list"
 	OpenIdConnectProviderArn Param = "open-id-connect-provider-arn"
 	SetAsDefault             Param = "set-as-default"
+	Description              Param = "description"
+	KeyUsage                 Param = "key-usage"
+	Policy                   Param = "policy"
+	AliasName                Param = "alias-name"
+	TargetKeyId              Param = "target-key-id"
+	KeyId                    Param = "key-id"
+	RuleGroupName            Param = "rule-group-name"
+	RuleGroup                Param = "rule-group"
+	Type                     Param = "type"
+	Capacity                 Param = "capacity"
+	Region                   Param = "region"
 )
 
 type CommandBuilder struct {
@@ -120,6 +137,14 @@ func NewIAMCommandBuilder() *CommandBuilder {
 	return &CommandBuilder{service: IAM}
 }
 
+func NewKMSCommandBuilder() *CommandBuilder {
+	return &CommandBuilder{service: KMS}
+}
+
+func NewNetworkFirewallCommandBuilder() *CommandBuilder {
+	return &CommandBuilder{service: NetworkFirewall}
+}
+
 func createParamString(awsParam Param, value string) string {
 	return fmt.Sprintf("\t--%s %s", awsParam, value)
 }
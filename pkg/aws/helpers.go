@@ -454,7 +454,15 @@ func GetOIDCProviderARN(accountID string, providerURL string) string {
 
 func GetPartition() string {
 	region, err := GetRegion(arguments.GetRegion())
-	if err != nil || region == "" {
+	if err != nil {
+		return endpoints.AwsPartitionID
+	}
+	return GetRegionPartition(region)
+}
+
+// GetRegionPartition returns the partition of the region, defaulting to the commercial partition
+func GetRegionPartition(region string) string {
+	if region == "" {
 		return endpoints.AwsPartitionID
 	}
 	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
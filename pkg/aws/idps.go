@@ -97,3 +97,17 @@ func (c *awsClient) DeleteOpenIDConnectProvider(oidcProviderARN string) error {
 	}
 	return nil
 }
+
+// DescribeOpenIDConnectProvider returns the details of the OpenID Connect provider of the given issuer
+func (c *awsClient) DescribeOpenIDConnectProvider(issuerURL string,
+	accountID string) (*iam.GetOpenIDConnectProviderOutput, error) {
+	parsedIssuerURL, err := url.ParseRequestURI(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+	providerURL := fmt.Sprintf("%s%s", parsedIssuerURL.Host, parsedIssuerURL.Path)
+
+	return c.iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(GetOIDCProviderARN(accountID, providerURL)),
+	})
+}
@@ -0,0 +1,403 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KMSKeyDefaultPolicyName is the only policy name supported by KMS keys
+const KMSKeyDefaultPolicyName = "default"
+
+// Actions granted by the key policy to the roles that encrypt EBS volumes and etcd data
+var kmsKeyUsageActions = []string{
+	"kms:Encrypt",
+	"kms:Decrypt",
+	"kms:ReEncrypt*",
+	"kms:GenerateDataKey*",
+	"kms:DescribeKey",
+}
+
+// Actions granted by the key policy to the roles that attach encrypted volumes to instances
+var kmsKeyGrantActions = []string{
+	"kms:CreateGrant",
+	"kms:ListGrants",
+	"kms:RevokeGrant",
+}
+
+// requiredKMSKeyActions are the individual actions checked when validating an existing key policy
+var requiredKMSKeyActions = []string{
+	"kms:Encrypt",
+	"kms:Decrypt",
+	"kms:ReEncryptFrom",
+	"kms:ReEncryptTo",
+	"kms:GenerateDataKey",
+	"kms:GenerateDataKeyWithoutPlaintext",
+	"kms:DescribeKey",
+	"kms:CreateGrant",
+	"kms:ListGrants",
+	"kms:RevokeGrant",
+}
+
+// KMSKeyOperatorNamespaces are the namespaces of the operators whose roles use the KMS key
+var KMSKeyOperatorNamespaces = []string{
+	"openshift-cluster-csi-drivers",
+	"openshift-machine-api",
+}
+
+// KMSKeyPrincipal is a role that the key policy must allow to use the key
+type KMSKeyPrincipal struct {
+	Name    string
+	RoleARN string
+}
+
+// ValidateKMSKey checks that the key exists in the given region, is enabled and is a symmetric key
+// that can be used to encrypt and decrypt data
+func (c *awsClient) ValidateKMSKey(keyARN string, region string) error {
+	parsedARN, err := arn.Parse(keyARN)
+	if err != nil {
+		return fmt.Errorf("Invalid KMS key ARN '%s': %v", keyARN, err)
+	}
+	if parsedARN.Region != region {
+		return fmt.Errorf("KMS key '%s' is in region '%s', expected it to be in the cluster region '%s'",
+			keyARN, parsedARN.Region, region)
+	}
+
+	output, err := c.kmsClient.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyARN),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == kms.ErrCodeNotFoundException {
+			return fmt.Errorf("KMS key '%s' doesn't exist", keyARN)
+		}
+		return fmt.Errorf("Failed to describe KMS key '%s': %v", keyARN, err)
+	}
+	metadata := output.KeyMetadata
+	if aws.StringValue(metadata.KeyState) != kms.KeyStateEnabled {
+		return fmt.Errorf("KMS key '%s' is in state '%s', expected it to be enabled",
+			keyARN, aws.StringValue(metadata.KeyState))
+	}
+	if aws.StringValue(metadata.KeyUsage) != kms.KeyUsageTypeEncryptDecrypt {
+		return fmt.Errorf("KMS key '%s' has usage '%s', expected '%s'",
+			keyARN, aws.StringValue(metadata.KeyUsage), kms.KeyUsageTypeEncryptDecrypt)
+	}
+	if aws.StringValue(metadata.CustomerMasterKeySpec) != kms.CustomerMasterKeySpecSymmetricDefault {
+		return fmt.Errorf("KMS key '%s' has spec '%s', expected a symmetric key",
+			keyARN, aws.StringValue(metadata.CustomerMasterKeySpec))
+	}
+	return nil
+}
+
+// GetKMSKeyPolicy fetches the key policy of the key
+func (c *awsClient) GetKMSKeyPolicy(keyARN string) (*PolicyDocument, error) {
+	output, err := c.kmsClient.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(KMSKeyDefaultPolicyName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get policy of KMS key '%s': %v", keyARN, err)
+	}
+	return ParsePolicyDocument(aws.StringValue(output.Policy))
+}
+
+// CreateKMSKey creates a symmetric encryption key with the given policy and, when a name is given, an
+// alias for it. It returns the ARN of the key.
+func (c *awsClient) CreateKMSKey(name string, description string, policy string,
+	keyTags map[string]string) (string, error) {
+	var kmsTags []*kms.Tag
+	for key, value := range keyTags {
+		kmsTags = append(kmsTags, &kms.Tag{
+			TagKey:   aws.String(key),
+			TagValue: aws.String(value),
+		})
+	}
+	output, err := c.kmsClient.CreateKey(&kms.CreateKeyInput{
+		Description:           aws.String(description),
+		KeyUsage:              aws.String(kms.KeyUsageTypeEncryptDecrypt),
+		CustomerMasterKeySpec: aws.String(kms.CustomerMasterKeySpecSymmetricDefault),
+		Policy:                aws.String(policy),
+		Tags:                  kmsTags,
+	})
+	if err != nil {
+		return "", err
+	}
+	keyARN := aws.StringValue(output.KeyMetadata.Arn)
+
+	if name != "" {
+		_, err = c.kmsClient.CreateAlias(&kms.CreateAliasInput{
+			AliasName:   aws.String(GetKMSKeyAlias(name)),
+			TargetKeyId: output.KeyMetadata.KeyId,
+		})
+		if err != nil {
+			return keyARN, fmt.Errorf("Created KMS key '%s' but failed to create its alias: %v", keyARN, err)
+		}
+	}
+	return keyARN, nil
+}
+
+// GetKMSKeyAlias returns the alias name of a key
+func GetKMSKeyAlias(name string) string {
+	return fmt.Sprintf("alias/%s", name)
+}
+
+// GenerateKMSKeyPolicy builds a key policy that keeps the key manageable through IAM policies of the
+// account and grants the principals the actions needed to encrypt EBS volumes and etcd data
+func GenerateKMSKeyPolicy(accountID string, principals []KMSKeyPrincipal) *PolicyDocument {
+	var roleARNs []string
+	for _, principal := range principals {
+		roleARNs = append(roleARNs, principal.RoleARN)
+	}
+
+	policy := NewPolicyDocument()
+	policy.ID = "rosa-kms-key-policy"
+	policy.Statement = []PolicyStatement{
+		{
+			Sid:    "Enable IAM User Permissions",
+			Effect: "Allow",
+			Principal: &PolicyStatementPrincipal{
+				AWS: fmt.Sprintf("arn:%s:iam::%s:root", GetPartition(), accountID),
+			},
+			Action:   "kms:*",
+			Resource: "*",
+		},
+	}
+	if len(roleARNs) > 0 {
+		policy.Statement = append(policy.Statement,
+			PolicyStatement{
+				Sid:    "Allow ROSA use of the key",
+				Effect: "Allow",
+				Principal: &PolicyStatementPrincipal{
+					AWS: roleARNs,
+				},
+				Action:   kmsKeyUsageActions,
+				Resource: "*",
+			},
+			PolicyStatement{
+				Sid:    "Allow attachment of persistent resources",
+				Effect: "Allow",
+				Principal: &PolicyStatementPrincipal{
+					AWS: roleARNs,
+				},
+				Action:   kmsKeyGrantActions,
+				Resource: "*",
+				Condition: map[string]map[string]interface{}{
+					"Bool": {
+						"kms:GrantIsForAWSResource": "true",
+					},
+				},
+			},
+		)
+	}
+	return policy
+}
+
+// KMSKeyActionsCheck contains the required actions that the key policy doesn't grant to a principal
+type KMSKeyActionsCheck struct {
+	// Missing actions are denied or not allowed by the key policy
+	Missing []string
+	// Delegated actions are only allowed to the account of the principal, like the default key policy
+	// does, so they are granted only if the IAM policies of the principal allow them too
+	Delegated []string
+	// Conditional actions are allowed or denied under conditions that can't be checked
+	Conditional []string
+}
+
+// kmsKeyActionDecision is the result of evaluating the key policy for an action of a principal
+type kmsKeyActionDecision int
+
+const (
+	kmsKeyActionMissing kmsKeyActionDecision = iota
+	kmsKeyActionConditional
+	kmsKeyActionDelegated
+	kmsKeyActionAllowed
+)
+
+// CheckKMSKeyActions checks the required actions that the key policy grants to each one of the principals.
+// Principals that are allowed all the actions aren't included.
+func CheckKMSKeyActions(policy *PolicyDocument, principals []KMSKeyPrincipal) map[string]*KMSKeyActionsCheck {
+	checks := make(map[string]*KMSKeyActionsCheck)
+	for _, principal := range principals {
+		check := &KMSKeyActionsCheck{}
+		for _, action := range requiredKMSKeyActions {
+			switch getKMSKeyActionDecision(policy, principal.RoleARN, action) {
+			case kmsKeyActionMissing:
+				check.Missing = append(check.Missing, action)
+			case kmsKeyActionConditional:
+				check.Conditional = append(check.Conditional, action)
+			case kmsKeyActionDelegated:
+				check.Delegated = append(check.Delegated, action)
+			}
+		}
+		if len(check.Missing) > 0 || len(check.Conditional) > 0 || len(check.Delegated) > 0 {
+			checks[principal.RoleARN] = check
+		}
+	}
+	return checks
+}
+
+// getKMSKeyActionDecision evaluates the key policy for an action of a role. An explicit deny overrides any
+// allow, and an allow to the role itself is preferred to the delegation to IAM of an allow to its account.
+func getKMSKeyActionDecision(policy *PolicyDocument, roleARN string, action string) kmsKeyActionDecision {
+	decision := kmsKeyActionMissing
+	conditionalDeny := false
+	for _, statement := range policy.Statement {
+		if statement.Principal == nil || !statementMatchesAction(statement, action) {
+			continue
+		}
+		direct, account := false, false
+		for _, principal := range statement.GetAWSPrincipals() {
+			if principal == roleARN || principal == "*" {
+				direct = true
+			} else if isAccountPrincipalOf(principal, roleARN) {
+				account = true
+			}
+		}
+		if !direct && !account {
+			continue
+		}
+		conditional := !hasOnlyKnownKMSConditions(statement)
+		switch statement.Effect {
+		case "Deny":
+			// A deny to the account applies to all its roles
+			if !conditional {
+				return kmsKeyActionMissing
+			}
+			conditionalDeny = true
+		case "Allow":
+			statementDecision := kmsKeyActionAllowed
+			if conditional {
+				statementDecision = kmsKeyActionConditional
+			} else if !direct {
+				statementDecision = kmsKeyActionDelegated
+			}
+			if statementDecision > decision {
+				decision = statementDecision
+			}
+		}
+	}
+	if conditionalDeny && decision > kmsKeyActionConditional {
+		decision = kmsKeyActionConditional
+	}
+	return decision
+}
+
+func statementMatchesAction(statement PolicyStatement, action string) bool {
+	for _, pattern := range getStatementActions(statement) {
+		if matchesActionPattern(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOnlyKnownKMSConditions checks if the statement has no conditions other than the one of the generated key
+// policy, which requires grants to be for AWS resources like the EBS volumes of the cluster
+func hasOnlyKnownKMSConditions(statement PolicyStatement) bool {
+	for operator, conditions := range statement.Condition {
+		if operator != "Bool" {
+			return false
+		}
+		for key, value := range conditions {
+			if key != "kms:GrantIsForAWSResource" || fmt.Sprint(value) != "true" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isAccountPrincipalOf checks if the principal is the account of the role, either as the root user ARN or
+// as the account ID
+func isAccountPrincipalOf(principal string, roleARN string) bool {
+	parsedRoleARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return false
+	}
+	if principal == parsedRoleARN.AccountID {
+		return true
+	}
+	parsedPrincipal, err := arn.Parse(principal)
+	if err != nil {
+		return false
+	}
+	return parsedPrincipal.Partition == parsedRoleARN.Partition &&
+		parsedPrincipal.Service == "iam" &&
+		parsedPrincipal.AccountID == parsedRoleARN.AccountID &&
+		parsedPrincipal.Resource == "root"
+}
+
+// GetKMSKeyActionsDeniedByIAM simulates the IAM policies of the role and returns the actions on the key that
+// they don't allow
+func (c *awsClient) GetKMSKeyActionsDeniedByIAM(roleARN string, keyARN string, actions []string) ([]string,
+	error) {
+	var denied []string
+	err := c.iamClient.SimulatePrincipalPolicyPages(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(roleARN),
+		ActionNames:     aws.StringSlice(actions),
+		ResourceArns:    []*string{aws.String(keyARN)},
+	}, func(page *iam.SimulatePolicyResponse, lastPage bool) bool {
+		for _, result := range page.EvaluationResults {
+			if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, aws.StringValue(result.EvalActionName))
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to simulate the IAM policies of role '%s': %v", roleARN, err)
+	}
+	return denied, nil
+}
+
+func getStatementActions(statement PolicyStatement) []string {
+	switch action := statement.Action.(type) {
+	case string:
+		return []string{action}
+	case []string:
+		return action
+	case []interface{}:
+		var actions []string
+		for _, el := range action {
+			if a, ok := el.(string); ok {
+				actions = append(actions, a)
+			}
+		}
+		return actions
+	}
+	return nil
+}
+
+// matchesActionPattern matches the action against a policy action that may contain wildcards. Actions
+// are case insensitive.
+func matchesActionPattern(pattern string, action string) bool {
+	expression := regexp.QuoteMeta(strings.ToLower(pattern))
+	expression = strings.ReplaceAll(expression, `\*`, ".*")
+	expression = strings.ReplaceAll(expression, `\?`, ".")
+	re, err := regexp.Compile(fmt.Sprintf("^%s$", expression))
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.ToLower(action))
+}
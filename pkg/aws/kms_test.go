@@ -0,0 +1,247 @@
+package aws_test
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+const kmsKeyARN = "arn:aws:kms:us-east-1:123456789012:key/0a1b2c3d-4e5f-4a6b-8c7d-0e1f2a3b4c5d"
+
+var _ = Describe("KMS", func() {
+	principals := []aws.KMSKeyPrincipal{
+		{Name: "Installer", RoleARN: "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"},
+		{Name: "Worker", RoleARN: "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"},
+	}
+
+	Context("CheckKMSKeyActions", func() {
+		It("Accepts the generated policy", func() {
+			policy, err := aws.ParsePolicyDocument(aws.GenerateKMSKeyPolicy("123456789012", principals).String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aws.CheckKMSKeyActions(policy, principals)).To(BeEmpty())
+		})
+
+		It("Reports the actions that aren't granted to each role", func() {
+			policy, err := aws.ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"},
+						"Action": ["kms:*"],
+						"Resource": "*"
+					},
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": ["arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"]},
+						"Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*"],
+						"Resource": "*"
+					}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			checks := aws.CheckKMSKeyActions(policy, principals)
+			Expect(checks).To(HaveLen(1))
+			Expect(checks[principals[1].RoleARN].Missing).To(ConsistOf(
+				"kms:DescribeKey", "kms:CreateGrant", "kms:ListGrants", "kms:RevokeGrant"))
+		})
+
+		It("Reports the actions allowed to the account as delegated to IAM", func() {
+			for _, accountPrincipal := range []string{"arn:aws:iam::123456789012:root", "123456789012"} {
+				policy, err := aws.ParsePolicyDocument(`{
+					"Version": "2012-10-17",
+					"Statement": [
+						{
+							"Sid": "Enable IAM User Permissions",
+							"Effect": "Allow",
+							"Principal": {"AWS": "` + accountPrincipal + `"},
+							"Action": "kms:*",
+							"Resource": "*"
+						}
+					]
+				}`)
+				Expect(err).NotTo(HaveOccurred())
+				checks := aws.CheckKMSKeyActions(policy, principals)
+				Expect(checks).To(HaveLen(2))
+				Expect(checks[principals[0].RoleARN].Missing).To(BeEmpty())
+				Expect(checks[principals[0].RoleARN].Delegated).To(HaveLen(10))
+			}
+		})
+
+		It("Doesn't delegate to IAM the actions allowed to another account", func() {
+			policy, err := aws.ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": "arn:aws:iam::210987654321:root"},
+						"Action": "kms:*",
+						"Resource": "*"
+					}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			checks := aws.CheckKMSKeyActions(policy, principals)
+			Expect(checks).To(HaveLen(2))
+			Expect(checks[principals[0].RoleARN].Missing).To(HaveLen(10))
+		})
+
+		It("Applies deny statements over allow statements", func() {
+			policy, err := aws.ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": "*"},
+						"Action": "kms:*",
+						"Resource": "*"
+					},
+					{
+						"Effect": "Deny",
+						"Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+						"Action": "kms:Decrypt",
+						"Resource": "*"
+					},
+					{
+						"Effect": "Deny",
+						"Principal": {"AWS": "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"},
+						"Action": "kms:Encrypt",
+						"Resource": "*",
+						"Condition": {"StringNotEquals": {"aws:SourceVpce": "vpce-1a2b3c4d"}}
+					}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			checks := aws.CheckKMSKeyActions(policy, principals)
+			Expect(checks[principals[0].RoleARN].Missing).To(ConsistOf("kms:Decrypt"))
+			Expect(checks[principals[0].RoleARN].Conditional).To(BeEmpty())
+			Expect(checks[principals[1].RoleARN].Missing).To(ConsistOf("kms:Decrypt"))
+			Expect(checks[principals[1].RoleARN].Conditional).To(ConsistOf("kms:Encrypt"))
+		})
+
+		It("Reports the actions only allowed under unknown conditions", func() {
+			policy, err := aws.ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"},
+						"Action": "kms:*",
+						"Resource": "*",
+						"Condition": {"StringEquals": {"kms:ViaService": "ec2.us-east-1.amazonaws.com"}}
+					}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			checks := aws.CheckKMSKeyActions(policy, principals[:1])
+			Expect(checks[principals[0].RoleARN].Missing).To(BeEmpty())
+			Expect(checks[principals[0].RoleARN].Conditional).To(HaveLen(10))
+		})
+	})
+
+	Context("GetKMSKeyActionsDeniedByIAM", func() {
+		It("Returns the actions that the IAM policies of the role don't allow", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockIAMAPI := mocks.NewMockIAMAPI(mockCtrl)
+			client := aws.New(
+				logrus.New(),
+				mockIAMAPI,
+				mocks.NewMockEC2API(mockCtrl),
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mocks.NewMockServiceQuotasAPI(mockCtrl),
+				mocks.NewMockKMSAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+			mockIAMAPI.EXPECT().SimulatePrincipalPolicyPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(input *iam.SimulatePrincipalPolicyInput,
+					fn func(*iam.SimulatePolicyResponse, bool) bool) error {
+					Expect(awssdk.StringValue(input.PolicySourceArn)).To(Equal(principals[0].RoleARN))
+					Expect(awssdk.StringValueSlice(input.ResourceArns)).To(Equal([]string{kmsKeyARN}))
+					fn(&iam.SimulatePolicyResponse{
+						EvaluationResults: []*iam.EvaluationResult{
+							{
+								EvalActionName: awssdk.String("kms:Encrypt"),
+								EvalDecision:   awssdk.String(iam.PolicyEvaluationDecisionTypeAllowed),
+							},
+							{
+								EvalActionName: awssdk.String("kms:Decrypt"),
+								EvalDecision:   awssdk.String(iam.PolicyEvaluationDecisionTypeImplicitDeny),
+							},
+						},
+					}, true)
+					return nil
+				})
+			denied, err := client.GetKMSKeyActionsDeniedByIAM(principals[0].RoleARN, kmsKeyARN,
+				[]string{"kms:Encrypt", "kms:Decrypt"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(Equal([]string{"kms:Decrypt"}))
+		})
+	})
+
+	Context("ValidateKMSKey", func() {
+		var (
+			client     aws.Client
+			mockCtrl   *gomock.Controller
+			mockKMSAPI *mocks.MockKMSAPI
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKMSAPI = mocks.NewMockKMSAPI(mockCtrl)
+			client = aws.New(
+				logrus.New(),
+				mocks.NewMockIAMAPI(mockCtrl),
+				mocks.NewMockEC2API(mockCtrl),
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mocks.NewMockServiceQuotasAPI(mockCtrl),
+				mockKMSAPI,
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Accepts an enabled symmetric key", func() {
+			mockKMSAPI.EXPECT().DescribeKey(gomock.Any()).Return(&kms.DescribeKeyOutput{
+				KeyMetadata: &kms.KeyMetadata{
+					KeyState:              awssdk.String(kms.KeyStateEnabled),
+					KeyUsage:              awssdk.String(kms.KeyUsageTypeEncryptDecrypt),
+					CustomerMasterKeySpec: awssdk.String(kms.CustomerMasterKeySpecSymmetricDefault),
+				},
+			}, nil)
+			Expect(client.ValidateKMSKey(kmsKeyARN, "us-east-1")).To(Succeed())
+		})
+
+		It("Rejects a disabled key", func() {
+			mockKMSAPI.EXPECT().DescribeKey(gomock.Any()).Return(&kms.DescribeKeyOutput{
+				KeyMetadata: &kms.KeyMetadata{
+					KeyState:              awssdk.String(kms.KeyStateDisabled),
+					KeyUsage:              awssdk.String(kms.KeyUsageTypeEncryptDecrypt),
+					CustomerMasterKeySpec: awssdk.String(kms.CustomerMasterKeySpecSymmetricDefault),
+				},
+			}, nil)
+			Expect(client.ValidateKMSKey(kmsKeyARN, "us-east-1")).To(MatchError(ContainSubstring("Disabled")))
+		})
+
+		It("Rejects a key in a different region", func() {
+			Expect(client.ValidateKMSKey(kmsKeyARN, "us-west-2")).To(MatchError(ContainSubstring("us-west-2")))
+		})
+	})
+})
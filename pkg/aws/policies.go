@@ -62,6 +62,14 @@ type PolicyDetail struct {
 	PolicType  string
 }
 
+// RolePolicies contains the trust policy of a role and the documents of its permission policies, indexed by
+// the name of inline policies and the ARN of attached ones
+type RolePolicies struct {
+	RoleARN            string                     `json:"role_arn"`
+	TrustPolicy        *PolicyDocument            `json:"trust_policy"`
+	PermissionPolicies map[string]*PolicyDocument `json:"permission_policies"`
+}
+
 type Policy struct {
 	PolicyName     string         `json:"PolicyName,omitempty"`
 	PolicyDocument PolicyDocument `json:"PolicyDocument,omitempty"`
@@ -1250,6 +1258,59 @@ func (c *awsClient) GetAccountRolePolicies(roles []string) (map[string][]PolicyD
 	return roleMap, nil
 }
 
+// GetRolePolicyDocuments returns the trust policy and the permission policies of the role with the given ARN
+func (c *awsClient) GetRolePolicyDocuments(roleARN string) (*RolePolicies, error) {
+	role, err := c.GetRoleByARN(roleARN)
+	if err != nil {
+		return nil, err
+	}
+	trustPolicy, err := getPolicyDocument(role.AssumeRolePolicyDocument)
+	if err != nil {
+		return nil, err
+	}
+	result := &RolePolicies{
+		RoleARN:            roleARN,
+		TrustPolicy:        trustPolicy,
+		PermissionPolicies: make(map[string]*PolicyDocument),
+	}
+
+	inlinePolicies, err := c.listPolicies(role)
+	if err != nil {
+		return nil, err
+	}
+	for i := range inlinePolicies {
+		result.PermissionPolicies[inlinePolicies[i].PolicyName] = &inlinePolicies[i].PolicyDocument
+	}
+
+	attachedPolicies, err := c.iamClient.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{
+		RoleName: role.RoleName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range attachedPolicies.AttachedPolicies {
+		policyOutput, err := c.iamClient.GetPolicy(&iam.GetPolicyInput{
+			PolicyArn: policy.PolicyArn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		versionOutput, err := c.iamClient.GetPolicyVersion(&iam.GetPolicyVersionInput{
+			PolicyArn: policy.PolicyArn,
+			VersionId: policyOutput.Policy.DefaultVersionId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		document, err := getPolicyDocument(versionOutput.PolicyVersion.Document)
+		if err != nil {
+			return nil, err
+		}
+		result.PermissionPolicies[aws.StringValue(policy.PolicyArn)] = document
+	}
+	return result, nil
+}
+
 func (c *awsClient) GetOpenIDConnectProvider(clusterID string) (string, error) {
 	providers, err := c.iamClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
 	if err != nil {
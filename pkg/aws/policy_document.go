@@ -42,6 +42,8 @@ type PolicyStatement struct {
 	// you do not include this element, then the resource to which the action applies is the
 	// resource to which the policy is attached.
 	Resource interface{} `json:"Resource,omitempty"`
+	// Specify the circumstances under which the policy grants permission.
+	Condition map[string]map[string]interface{} `json:"Condition,omitempty"`
 }
 
 type PolicyStatementPrincipal struct {
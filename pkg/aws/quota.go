@@ -2,120 +2,657 @@ package aws
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
+
+	"github.com/openshift/rosa/pkg/helper"
 )
 
 type quota struct {
-	ServiceCode  string
-	QuotaName    string
-	QuotaCode    string
-	DesiredValue *float64
+	ServiceCode string
+	QuotaName   string
+	QuotaCode   string
 }
 
-// List of service quotas we verify for cluster installs
-// to support 5 x multi zone clusters
-var serviceQuotaServices = []quota{
-	{
-		ServiceCode:  "ec2",
-		QuotaCode:    "L-0263D0A3",
-		QuotaName:    "Number of EIPs - VPC EIPs",
-		DesiredValue: aws.Float64(5.0),
-	},
-	{
-		ServiceCode:  "ec2",
-		QuotaCode:    "L-1216C47A",
-		QuotaName:    "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
-		DesiredValue: aws.Float64(100.0),
-	},
-	{
-		ServiceCode:  "vpc",
-		QuotaCode:    "L-F678F1CE",
-		QuotaName:    "VPCs per Region",
-		DesiredValue: aws.Float64(5.0),
-	},
-	{
-		ServiceCode:  "vpc",
-		QuotaCode:    "L-A4707A72",
-		QuotaName:    "Internet gateways per Region",
-		DesiredValue: aws.Float64(5.0),
-	},
-	{
-		ServiceCode:  "vpc",
-		QuotaCode:    "L-DF5E4CA3",
-		QuotaName:    "Network interfaces per Region",
-		DesiredValue: aws.Float64(5000.0),
-	},
-	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-D18FCD1D",
-		QuotaName:    "General Purpose SSD (gp2) volume storage",
-		DesiredValue: aws.Float64(50.0),
-	},
+// Service quotas consumed by the instances of the cluster, keyed by instance family
+var (
+	standardInstancesQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1216C47A",
+		QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+	}
+	gAndVTInstancesQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-DB2E81BA",
+		QuotaName:   "Running On-Demand G and VT instances",
+	}
+	pInstancesQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-417A185B",
+		QuotaName:   "Running On-Demand P instances",
+	}
+	xInstancesQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-7295265B",
+		QuotaName:   "Running On-Demand X instances",
+	}
+	fInstancesQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-74FC7D96",
+		QuotaName:   "Running On-Demand F instances",
+	}
+	infInstancesQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1945791B",
+		QuotaName:   "Running On-Demand Inf instances",
+	}
+)
+
+// Service quotas consumed by the network and storage resources of the cluster
+var (
+	eipsQuota = quota{
+		ServiceCode: "ec2",
+		QuotaCode:   "L-0263D0A3",
+		QuotaName:   "Number of EIPs - VPC EIPs",
+	}
+	vpcsQuota = quota{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-F678F1CE",
+		QuotaName:   "VPCs per Region",
+	}
+	internetGatewaysQuota = quota{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-A4707A72",
+		QuotaName:   "Internet gateways per Region",
+	}
+	networkInterfacesQuota = quota{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-DF5E4CA3",
+		QuotaName:   "Network interfaces per Region",
+	}
+	gp3StorageQuota = quota{
+		ServiceCode: "ebs",
+		QuotaCode:   "L-7A658B76",
+		QuotaName:   "Storage for General Purpose SSD (gp3) volumes, in TiB",
+	}
+	networkLoadBalancersQuota = quota{
+		ServiceCode: "elasticloadbalancing",
+		QuotaCode:   "L-69A177A2",
+		QuotaName:   "Network Load Balancers per Region",
+	}
+	classicLoadBalancersQuota = quota{
+		ServiceCode: "elasticloadbalancing",
+		QuotaCode:   "L-E9E9831D",
+		QuotaName:   "Classic Load Balancers per Region",
+	}
+)
+
+// usageQuotas are the service quotas whose usage is counted by getQuotaUsage
+var usageQuotas = []quota{
+	standardInstancesQuota,
+	gAndVTInstancesQuota,
+	pInstancesQuota,
+	xInstancesQuota,
+	fInstancesQuota,
+	infInstancesQuota,
+	eipsQuota,
+	vpcsQuota,
+	internetGatewaysQuota,
+	networkInterfacesQuota,
+	gp3StorageQuota,
+}
+
+// minimumQuota is a service quota whose applied value must reach a minimum
+type minimumQuota struct {
+	quota
+	Minimum float64
+}
+
+// Minimum service quotas verified when preparing an account for cluster installs, enough to support
+// 5 x multi zone clusters
+var minimumQuotas = []minimumQuota{
+	{eipsQuota, 5},
+	{standardInstancesQuota, 100},
+	{vpcsQuota, 5},
+	{internetGatewaysQuota, 5},
+	{networkInterfacesQuota, 5000},
 	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-309BACF6",
-		QuotaName:    "Number of EBS snapshots",
-		DesiredValue: aws.Float64(300.0),
+		quota{
+			ServiceCode: "ebs",
+			QuotaCode:   "L-D18FCD1D",
+			QuotaName:   "General Purpose SSD (gp2) volume storage",
+		},
+		50,
 	},
 	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-B3A130E6",
-		QuotaName:    "Provisioned IOPS",
-		DesiredValue: aws.Float64(300000.0),
+		quota{
+			ServiceCode: "ebs",
+			QuotaCode:   "L-309BACF6",
+			QuotaName:   "Number of EBS snapshots",
+		},
+		300,
 	},
 	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-FD252861",
-		QuotaName:    "Provisioned IOPS SSD (io1) volume storage",
-		DesiredValue: aws.Float64(50.0),
+		quota{
+			ServiceCode: "ebs",
+			QuotaCode:   "L-B3A130E6",
+			QuotaName:   "Provisioned IOPS",
+		},
+		300000,
 	},
 	{
-		ServiceCode:  "elasticloadbalancing",
-		QuotaCode:    "L-53DA6B97",
-		QuotaName:    "Application Load Balancers per Region",
-		DesiredValue: aws.Float64(50.0),
+		quota{
+			ServiceCode: "ebs",
+			QuotaCode:   "L-FD252861",
+			QuotaName:   "Provisioned IOPS SSD (io1) volume storage",
+		},
+		50,
 	},
 	{
-		ServiceCode:  "elasticloadbalancing",
-		QuotaCode:    "L-E9E9831D",
-		QuotaName:    "Classic Load Balancers per Region",
-		DesiredValue: aws.Float64(20.0),
+		quota{
+			ServiceCode: "elasticloadbalancing",
+			QuotaCode:   "L-53DA6B97",
+			QuotaName:   "Application Load Balancers per Region",
+		},
+		50,
 	},
+	{classicLoadBalancersQuota, 20},
 }
 
-// ValidateQuota
-func (c *awsClient) ValidateQuota() (bool, error) {
-	var invalidQuotas []string
-	for _, quota := range serviceQuotaServices {
-		serviceQuotas, err := ListServiceQuotas(c, quota.ServiceCode)
+// QuotaMachinePool describes a group of instances that the cluster will run
+type QuotaMachinePool struct {
+	Name          string
+	InstanceType  string
+	Replicas      int
+	VolumeSizeGiB int
+}
+
+// QuotaClusterSpec describes the planned cluster whose quota requirements are computed
+type QuotaClusterSpec struct {
+	MultiAZ      bool
+	Private      bool
+	BYOVPC       bool
+	MachinePools []QuotaMachinePool
+}
+
+// QuotaStatus contains the value required by the planned cluster for a service quota, together with
+// the value applied to the account and the current usage when it can be determined. Insufficient quotas
+// may have a request to increase them.
+type QuotaStatus struct {
+	ServiceCode     string                `json:"service_code"`
+	QuotaCode       string                `json:"quota_code"`
+	QuotaName       string                `json:"quota_name"`
+	Required        float64               `json:"required"`
+	Applied         float64               `json:"applied"`
+	Usage           *float64              `json:"usage,omitempty"`
+	Sufficient      bool                  `json:"sufficient"`
+	IncreaseRequest *QuotaIncreaseRequest `json:"increase_request,omitempty"`
+}
+
+// QuotaUsage contains the value applied to the account for a service quota and its current usage
+type QuotaUsage struct {
+	ServiceCode string  `json:"service_code"`
+	QuotaCode   string  `json:"quota_code"`
+	QuotaName   string  `json:"quota_name"`
+	Applied     float64 `json:"applied"`
+	Usage       float64 `json:"usage"`
+}
+
+// getInstanceQuota returns the vCPU based quota that applies to the family of the instance type
+func getInstanceQuota(instanceType string) quota {
+	family := strings.Split(instanceType, ".")[0]
+	switch {
+	case strings.HasPrefix(family, "inf"):
+		return infInstancesQuota
+	case strings.HasPrefix(family, "vt"), strings.HasPrefix(family, "g"):
+		return gAndVTInstancesQuota
+	case strings.HasPrefix(family, "p"):
+		return pInstancesQuota
+	case strings.HasPrefix(family, "x"):
+		return xInstancesQuota
+	case strings.HasPrefix(family, "f"):
+		return fInstancesQuota
+	default:
+		return standardInstancesQuota
+	}
+}
+
+// ComputeQuotaRequirements derives the service quotas needed by the cluster from its spec. The number
+// of vCPUs of each instance type used by the machine pools must be provided. Only the required values
+// of the returned statuses are set.
+func ComputeQuotaRequirements(spec *QuotaClusterSpec, vCPUs map[string]int) ([]*QuotaStatus, error) {
+	availabilityZones := 1
+	if spec.MultiAZ {
+		availabilityZones = 3
+	}
+
+	var requirements []*QuotaStatus
+	add := func(q quota, value float64) {
+		if value <= 0 {
+			return
+		}
+		for _, requirement := range requirements {
+			if requirement.QuotaCode == q.QuotaCode {
+				requirement.Required += value
+				return
+			}
+		}
+		requirements = append(requirements, &QuotaStatus{
+			ServiceCode: q.ServiceCode,
+			QuotaCode:   q.QuotaCode,
+			QuotaName:   q.QuotaName,
+			Required:    value,
+		})
+	}
+
+	nodes := 0
+	storageGiB := 0
+	for _, machinePool := range spec.MachinePools {
+		cpus, ok := vCPUs[machinePool.InstanceType]
+		if !ok {
+			return nil, fmt.Errorf("Failed to find the number of vCPUs of instance type '%s'", machinePool.InstanceType)
+		}
+		add(getInstanceQuota(machinePool.InstanceType), float64(cpus*machinePool.Replicas))
+		nodes += machinePool.Replicas
+		storageGiB += machinePool.VolumeSizeGiB * machinePool.Replicas
+	}
+
+	// The API is exposed through an internal and, unless private, an external network load balancer,
+	// while the default ingress controller uses a classic load balancer. Every load balancer and NAT
+	// gateway has a network interface in each availability zone.
+	networkLoadBalancers := 2
+	if spec.Private {
+		networkLoadBalancers = 1
+	}
+	classicLoadBalancers := 1
+	natGateways := 0
+	if !spec.BYOVPC {
+		natGateways = availabilityZones
+		add(eipsQuota, float64(natGateways))
+		add(vpcsQuota, 1)
+		add(internetGatewaysQuota, 1)
+	}
+	add(networkInterfacesQuota,
+		float64(nodes+natGateways+(networkLoadBalancers+classicLoadBalancers)*availabilityZones))
+	add(gp3StorageQuota, math.Ceil(float64(storageGiB)/1024*100)/100)
+	add(networkLoadBalancersQuota, float64(networkLoadBalancers))
+	add(classicLoadBalancersQuota, float64(classicLoadBalancers))
+
+	return requirements, nil
+}
+
+// GetQuotaStatus computes the service quotas required by the planned cluster and compares them with the
+// values applied to the account and the current usage of the region.
+func (c *awsClient) GetQuotaStatus(spec *QuotaClusterSpec) ([]*QuotaStatus, error) {
+	var instanceTypes []string
+	for _, machinePool := range spec.MachinePools {
+		if !helper.Contains(instanceTypes, machinePool.InstanceType) {
+			instanceTypes = append(instanceTypes, machinePool.InstanceType)
+		}
+	}
+	vCPUs, err := c.getInstanceTypesVCPUs(instanceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting instance types: %v", err)
+	}
+
+	requirements, err := ComputeQuotaRequirements(spec, vCPUs)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := c.getQuotaUsage()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting current usage of AWS resources: %v", err)
+	}
+
+	serviceQuotasByCode := make(map[string][]*servicequotas.ServiceQuota)
+	for _, status := range requirements {
+		serviceQuotas, ok := serviceQuotasByCode[status.ServiceCode]
+		if !ok {
+			serviceQuotas, err = ListServiceQuotas(c, status.ServiceCode)
+			if err != nil {
+				return nil, fmt.Errorf("Error listing AWS service quotas: %s %v", status.ServiceCode, err)
+			}
+			serviceQuotasByCode[status.ServiceCode] = serviceQuotas
+		}
+
+		status.Applied, err = c.getAppliedQuotaValue(serviceQuotas, status.ServiceCode, status.QuotaCode)
 		if err != nil {
-			return false, fmt.Errorf("Error listing AWS service quotas: %s %v", quota.ServiceCode, err)
+			return nil, fmt.Errorf("Error getting AWS service quota: %s %v", status.ServiceCode, err)
+		}
+
+		if value, ok := usage[status.QuotaCode]; ok {
+			status.Usage = aws.Float64(value)
+			status.Sufficient = status.Applied >= status.Required+value
+		} else {
+			status.Sufficient = status.Applied >= status.Required
 		}
 
-		serviceQuota, err := GetServiceQuota(serviceQuotas, quota.QuotaCode)
-		if err != nil || serviceQuota == nil || (*serviceQuota).Value == nil {
-			return false, fmt.Errorf("Error getting AWS service quota: %s %v", quota.ServiceCode, err)
+		c.logger.Debug(fmt.Sprintf("Service %s quota code %s requires %g, applied %g",
+			status.ServiceCode, status.QuotaCode, status.Required, status.Applied))
+	}
+
+	return requirements, nil
+}
+
+// GetQuotaUsage compares the current usage of the region with the values applied to the account, for
+// the service quotas consumed by clusters whose usage can be determined
+func (c *awsClient) GetQuotaUsage() ([]*QuotaUsage, error) {
+	usage, err := c.getQuotaUsage()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting current usage of AWS resources: %v", err)
+	}
+
+	var usages []*QuotaUsage
+	serviceQuotasByCode := make(map[string][]*servicequotas.ServiceQuota)
+	for _, q := range usageQuotas {
+		serviceQuotas, ok := serviceQuotasByCode[q.ServiceCode]
+		if !ok {
+			serviceQuotas, err = ListServiceQuotas(c, q.ServiceCode)
+			if err != nil {
+				return nil, fmt.Errorf("Error listing AWS service quotas: %s %v", q.ServiceCode, err)
+			}
+			serviceQuotasByCode[q.ServiceCode] = serviceQuotas
 		}
 
-		if *serviceQuota.Value < *quota.DesiredValue {
+		applied, err := c.getAppliedQuotaValue(serviceQuotas, q.ServiceCode, q.QuotaCode)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting AWS service quota: %s %v", q.ServiceCode, err)
+		}
+
+		usages = append(usages, &QuotaUsage{
+			ServiceCode: q.ServiceCode,
+			QuotaCode:   q.QuotaCode,
+			QuotaName:   q.QuotaName,
+			Applied:     applied,
+			Usage:       usage[q.QuotaCode],
+		})
+	}
+
+	return usages, nil
+}
+
+// ValidateMinimumQuota checks that the applied quotas reach the minimum values needed to install
+// clusters, regardless of the current usage of the region
+func (c *awsClient) ValidateMinimumQuota() error {
+	var invalidQuotas []string
+	serviceQuotasByCode := make(map[string][]*servicequotas.ServiceQuota)
+	for _, minimum := range minimumQuotas {
+		serviceQuotas, ok := serviceQuotasByCode[minimum.ServiceCode]
+		if !ok {
+			var err error
+			serviceQuotas, err = ListServiceQuotas(c, minimum.ServiceCode)
+			if err != nil {
+				return fmt.Errorf("Error listing AWS service quotas: %s %v", minimum.ServiceCode, err)
+			}
+			serviceQuotasByCode[minimum.ServiceCode] = serviceQuotas
+		}
+
+		applied, err := c.getAppliedQuotaValue(serviceQuotas, minimum.ServiceCode, minimum.QuotaCode)
+		if err != nil {
+			return fmt.Errorf("Error getting AWS service quota: %s %v", minimum.ServiceCode, err)
+		}
+
+		if applied < minimum.Minimum {
 			invalidQuotas = append(invalidQuotas, fmt.Sprintf(
 				"- Service %s quota code %s %s not valid, expected quota of at least %d, but got %d",
-				quota.ServiceCode, quota.QuotaCode, quota.QuotaName,
-				int(*quota.DesiredValue), int(*serviceQuota.Value)))
+				minimum.ServiceCode, minimum.QuotaCode, minimum.QuotaName,
+				int(minimum.Minimum), int(applied)))
 		}
 
-		c.logger.Debug(fmt.Sprintf("Service %s quota code %s is ok", quota.ServiceCode, quota.QuotaCode))
+		c.logger.Debug(fmt.Sprintf("Service %s quota code %s is ok", minimum.ServiceCode, minimum.QuotaCode))
+	}
+
+	if len(invalidQuotas) > 0 {
+		return fmt.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
+			strings.Join(invalidQuotas, "\n"))
+	}
+
+	return nil
+}
+
+// getAppliedQuotaValue returns the value of the quota applied to the account. When the account has no
+// applied value, as happens with the quotas of some instance families, the AWS default value is used.
+func (c *awsClient) getAppliedQuotaValue(serviceQuotas []*servicequotas.ServiceQuota,
+	serviceCode string, quotaCode string) (float64, error) {
+	serviceQuota, err := GetServiceQuota(serviceQuotas, quotaCode)
+	if err == nil && serviceQuota.Value != nil {
+		return *serviceQuota.Value, nil
+	}
+
+	c.logger.Debug(fmt.Sprintf("Service %s quota code %s has no applied value, using the AWS default",
+		serviceCode, quotaCode))
+	output, err := c.servicequotasClient.GetAWSDefaultServiceQuota(&servicequotas.GetAWSDefaultServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if output.Quota == nil || output.Quota.Value == nil {
+		return 0, fmt.Errorf("Unable to find quota with service code: %s", quotaCode)
+	}
+	return *output.Quota.Value, nil
+}
+
+// ValidateQuota checks that the applied quotas leave room for the planned cluster on top of the
+// current usage
+func ValidateQuota(statuses []*QuotaStatus) error {
+	var invalidQuotas []string
+	for _, status := range statuses {
+		if !status.Sufficient {
+			invalidQuotas = append(invalidQuotas, fmt.Sprintf(
+				"- Service %s quota code %s %s not valid, expected quota of at least %g, but got %g",
+				status.ServiceCode, status.QuotaCode, status.QuotaName,
+				status.Required+aws.Float64Value(status.Usage), status.Applied))
+		}
 	}
 
 	if len(invalidQuotas) > 0 {
-		return false, fmt.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
+		return fmt.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
 			strings.Join(invalidQuotas, "\n"))
 	}
 
-	return true, nil
+	return nil
+}
+
+// QuotaIncreaseRequest contains the status of a request to increase the value of a service quota
+type QuotaIncreaseRequest struct {
+	ID           string     `json:"id"`
+	ServiceCode  string     `json:"service_code"`
+	QuotaCode    string     `json:"quota_code"`
+	QuotaName    string     `json:"quota_name"`
+	DesiredValue float64    `json:"desired_value"`
+	Status       string     `json:"status"`
+	CaseID       string     `json:"case_id,omitempty"`
+	Created      *time.Time `json:"created,omitempty"`
+}
+
+// IsOpen returns true while the request hasn't been approved, denied or closed
+func (r *QuotaIncreaseRequest) IsOpen() bool {
+	return r.Status == servicequotas.RequestStatusPending || r.Status == servicequotas.RequestStatusCaseOpened
+}
+
+// GetDesiredQuotaValue returns the value the quota must be increased to in order to fit the planned
+// cluster on top of the current usage
+func GetDesiredQuotaValue(status *QuotaStatus) float64 {
+	return math.Ceil(status.Required + aws.Float64Value(status.Usage))
+}
+
+// RequestQuotaIncrease files a request to increase the service quota to the value needed by the
+// planned cluster
+func (c *awsClient) RequestQuotaIncrease(status *QuotaStatus) (*QuotaIncreaseRequest, error) {
+	output, err := c.servicequotasClient.RequestServiceQuotaIncrease(&servicequotas.RequestServiceQuotaIncreaseInput{
+		ServiceCode:  aws.String(status.ServiceCode),
+		QuotaCode:    aws.String(status.QuotaCode),
+		DesiredValue: aws.Float64(GetDesiredQuotaValue(status)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newQuotaIncreaseRequest(output.RequestedQuota), nil
+}
+
+// ListQuotaIncreaseRequests lists the quota increase requests filed for the service in the region
+func (c *awsClient) ListQuotaIncreaseRequests(serviceCode string) ([]*QuotaIncreaseRequest, error) {
+	var requests []*QuotaIncreaseRequest
+	err := c.servicequotasClient.ListRequestedServiceQuotaChangeHistoryPages(
+		&servicequotas.ListRequestedServiceQuotaChangeHistoryInput{
+			ServiceCode: aws.String(serviceCode),
+		},
+		func(page *servicequotas.ListRequestedServiceQuotaChangeHistoryOutput, lastPage bool) bool {
+			for _, requestedQuota := range page.RequestedQuotas {
+				requests = append(requests, newQuotaIncreaseRequest(requestedQuota))
+			}
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func newQuotaIncreaseRequest(requestedQuota *servicequotas.RequestedServiceQuotaChange) *QuotaIncreaseRequest {
+	return &QuotaIncreaseRequest{
+		ID:           aws.StringValue(requestedQuota.Id),
+		ServiceCode:  aws.StringValue(requestedQuota.ServiceCode),
+		QuotaCode:    aws.StringValue(requestedQuota.QuotaCode),
+		QuotaName:    aws.StringValue(requestedQuota.QuotaName),
+		DesiredValue: aws.Float64Value(requestedQuota.DesiredValue),
+		Status:       aws.StringValue(requestedQuota.Status),
+		CaseID:       aws.StringValue(requestedQuota.CaseId),
+		Created:      requestedQuota.Created,
+	}
+}
+
+// getInstanceTypesVCPUs fetches the default number of vCPUs of each one of the instance types
+func (c *awsClient) getInstanceTypesVCPUs(instanceTypes []string) (map[string]int, error) {
+	vCPUs := make(map[string]int)
+	if len(instanceTypes) == 0 {
+		return vCPUs, nil
+	}
+	err := c.ec2Client.DescribeInstanceTypesPages(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice(instanceTypes),
+	}, func(page *ec2.DescribeInstanceTypesOutput, lastPage bool) bool {
+		for _, instanceType := range page.InstanceTypes {
+			vCPUs[aws.StringValue(instanceType.InstanceType)] = int(aws.Int64Value(instanceType.VCpuInfo.DefaultVCpus))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vCPUs, nil
+}
+
+// getQuotaUsage counts the resources of the region that consume the quotas verified for cluster installs.
+// The usage of load balancer quotas isn't computed.
+func (c *awsClient) getQuotaUsage() (map[string]float64, error) {
+	usage := make(map[string]float64)
+
+	// Count the vCPUs of the on-demand instances of each family
+	for _, instanceQuota := range []quota{standardInstancesQuota, gAndVTInstancesQuota, pInstancesQuota,
+		xInstancesQuota, fInstancesQuota, infInstancesQuota} {
+		usage[instanceQuota.QuotaCode] = 0
+	}
+	err := c.ec2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				// Spot instances have their own quotas
+				if aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot {
+					continue
+				}
+				cpus := 0.0
+				if instance.CpuOptions != nil {
+					cpus = float64(aws.Int64Value(instance.CpuOptions.CoreCount) *
+						aws.Int64Value(instance.CpuOptions.ThreadsPerCore))
+				}
+				usage[getInstanceQuota(aws.StringValue(instance.InstanceType)).QuotaCode] += cpus
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := c.ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("domain"),
+				Values: []*string{aws.String(ec2.DomainTypeVpc)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	usage[eipsQuota.QuotaCode] = float64(len(addresses.Addresses))
+
+	vpcs := 0
+	err = c.ec2Client.DescribeVpcsPages(&ec2.DescribeVpcsInput{},
+		func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+			vpcs += len(page.Vpcs)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	usage[vpcsQuota.QuotaCode] = float64(vpcs)
+
+	internetGateways := 0
+	err = c.ec2Client.DescribeInternetGatewaysPages(&ec2.DescribeInternetGatewaysInput{},
+		func(page *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
+			internetGateways += len(page.InternetGateways)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	usage[internetGatewaysQuota.QuotaCode] = float64(internetGateways)
+
+	networkInterfaces := 0
+	err = c.ec2Client.DescribeNetworkInterfacesPages(&ec2.DescribeNetworkInterfacesInput{},
+		func(page *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+			networkInterfaces += len(page.NetworkInterfaces)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	usage[networkInterfacesQuota.QuotaCode] = float64(networkInterfaces)
+
+	var gp3StorageGiB int64
+	err = c.ec2Client.DescribeVolumesPages(&ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("volume-type"),
+				Values: []*string{aws.String(ec2.VolumeTypeGp3)},
+			},
+		},
+	}, func(page *ec2.DescribeVolumesOutput, lastPage bool) bool {
+		for _, volume := range page.Volumes {
+			gp3StorageGiB += aws.Int64Value(volume.Size)
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	usage[gp3StorageQuota.QuotaCode] = math.Ceil(float64(gp3StorageGiB)/1024*100) / 100
+
+	return usage, nil
 }
 
 // ListServiceQuotas list available quotas for service
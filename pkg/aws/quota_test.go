@@ -0,0 +1,319 @@
+package aws_test
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+var _ = Describe("Quota", func() {
+	Context("ComputeQuotaRequirements", func() {
+		var spec *aws.QuotaClusterSpec
+
+		BeforeEach(func() {
+			spec = &aws.QuotaClusterSpec{
+				MultiAZ: true,
+				MachinePools: []aws.QuotaMachinePool{
+					{Name: "control-plane", InstanceType: "m5.2xlarge", Replicas: 3, VolumeSizeGiB: 350},
+					{Name: "infra", InstanceType: "r5.xlarge", Replicas: 3, VolumeSizeGiB: 300},
+					{Name: "compute", InstanceType: "g4dn.xlarge", Replicas: 6, VolumeSizeGiB: 300},
+				},
+			}
+		})
+
+		It("Computes the vCPUs per instance family and the network resources", func() {
+			requirements, err := aws.ComputeQuotaRequirements(spec, map[string]int{
+				"m5.2xlarge":  8,
+				"r5.xlarge":   4,
+				"g4dn.xlarge": 4,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			values := map[string]float64{}
+			for _, requirement := range requirements {
+				values[requirement.QuotaCode] = requirement.Required
+			}
+			// Standard instances
+			Expect(values["L-1216C47A"]).To(Equal(36.0))
+			// G and VT instances
+			Expect(values["L-DB2E81BA"]).To(Equal(24.0))
+			// One EIP per NAT gateway
+			Expect(values["L-0263D0A3"]).To(Equal(3.0))
+			Expect(values["L-F678F1CE"]).To(Equal(1.0))
+			// 12 nodes, 3 NAT gateways and 3 load balancers in each zone
+			Expect(values["L-DF5E4CA3"]).To(Equal(24.0))
+			Expect(values["L-69A177A2"]).To(Equal(2.0))
+		})
+
+		It("Doesn't require VPC resources when installing into an existing VPC", func() {
+			spec.BYOVPC = true
+			spec.Private = true
+			requirements, err := aws.ComputeQuotaRequirements(spec, map[string]int{
+				"m5.2xlarge":  8,
+				"r5.xlarge":   4,
+				"g4dn.xlarge": 4,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			values := map[string]float64{}
+			for _, requirement := range requirements {
+				values[requirement.QuotaCode] = requirement.Required
+			}
+			Expect(values).NotTo(HaveKey("L-0263D0A3"))
+			Expect(values).NotTo(HaveKey("L-F678F1CE"))
+			Expect(values["L-69A177A2"]).To(Equal(1.0))
+		})
+
+		It("Fails when the vCPUs of an instance type are unknown", func() {
+			_, err := aws.ComputeQuotaRequirements(spec, map[string]int{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Quota increase requests", func() {
+		var (
+			client               aws.Client
+			mockCtrl             *gomock.Controller
+			mockServiceQuotasAPI *mocks.MockServiceQuotasAPI
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockServiceQuotasAPI = mocks.NewMockServiceQuotasAPI(mockCtrl)
+			client = aws.New(
+				logrus.New(),
+				mocks.NewMockIAMAPI(mockCtrl),
+				mocks.NewMockEC2API(mockCtrl),
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mockServiceQuotasAPI,
+				mocks.NewMockKMSAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Requests the value required on top of the current usage", func() {
+			mockServiceQuotasAPI.EXPECT().RequestServiceQuotaIncrease(
+				&servicequotas.RequestServiceQuotaIncreaseInput{
+					ServiceCode:  awssdk.String("ec2"),
+					QuotaCode:    awssdk.String("L-1216C47A"),
+					DesiredValue: awssdk.Float64(120),
+				}).Return(&servicequotas.RequestServiceQuotaIncreaseOutput{
+				RequestedQuota: &servicequotas.RequestedServiceQuotaChange{
+					Id:           awssdk.String("request-1"),
+					ServiceCode:  awssdk.String("ec2"),
+					QuotaCode:    awssdk.String("L-1216C47A"),
+					DesiredValue: awssdk.Float64(120),
+					Status:       awssdk.String(servicequotas.RequestStatusPending),
+				},
+			}, nil)
+
+			request, err := client.RequestQuotaIncrease(&aws.QuotaStatus{
+				ServiceCode: "ec2",
+				QuotaCode:   "L-1216C47A",
+				Required:    100,
+				Applied:     64,
+				Usage:       awssdk.Float64(19.5),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.ID).To(Equal("request-1"))
+			Expect(request.IsOpen()).To(BeTrue())
+		})
+
+		It("Lists the requests of the service", func() {
+			mockServiceQuotasAPI.EXPECT().ListRequestedServiceQuotaChangeHistoryPages(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(input *servicequotas.ListRequestedServiceQuotaChangeHistoryInput,
+					fn func(*servicequotas.ListRequestedServiceQuotaChangeHistoryOutput, bool) bool) error {
+					Expect(*input.ServiceCode).To(Equal("vpc"))
+					fn(&servicequotas.ListRequestedServiceQuotaChangeHistoryOutput{
+						RequestedQuotas: []*servicequotas.RequestedServiceQuotaChange{
+							{
+								Id:        awssdk.String("request-1"),
+								QuotaCode: awssdk.String("L-F678F1CE"),
+								Status:    awssdk.String(servicequotas.RequestStatusCaseOpened),
+								CaseId:    awssdk.String("case-1"),
+							},
+							{
+								Id:        awssdk.String("request-2"),
+								QuotaCode: awssdk.String("L-A4707A72"),
+								Status:    awssdk.String(servicequotas.RequestStatusApproved),
+							},
+						},
+					}, true)
+					return nil
+				})
+
+			requests, err := client.ListQuotaIncreaseRequests("vpc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[0].IsOpen()).To(BeTrue())
+			Expect(requests[0].CaseID).To(Equal("case-1"))
+			Expect(requests[1].IsOpen()).To(BeFalse())
+		})
+	})
+
+	Context("ValidateMinimumQuota", func() {
+		var (
+			client               aws.Client
+			mockCtrl             *gomock.Controller
+			mockServiceQuotasAPI *mocks.MockServiceQuotasAPI
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockServiceQuotasAPI = mocks.NewMockServiceQuotasAPI(mockCtrl)
+			client = aws.New(
+				logrus.New(),
+				mocks.NewMockIAMAPI(mockCtrl),
+				mocks.NewMockEC2API(mockCtrl),
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mockServiceQuotasAPI,
+				mocks.NewMockKMSAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		// expectAppliedQuotas lists a large applied value for every quota, except the ones given, which
+		// have no applied value
+		expectAppliedQuotas := func(withoutValue ...string) {
+			codes := map[string][]string{
+				"ec2":                  {"L-0263D0A3", "L-1216C47A"},
+				"vpc":                  {"L-F678F1CE", "L-A4707A72", "L-DF5E4CA3"},
+				"ebs":                  {"L-D18FCD1D", "L-309BACF6", "L-B3A130E6", "L-FD252861"},
+				"elasticloadbalancing": {"L-53DA6B97", "L-E9E9831D"},
+			}
+			mockServiceQuotasAPI.EXPECT().ListServiceQuotasPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(input *servicequotas.ListServiceQuotasInput,
+					fn func(*servicequotas.ListServiceQuotasOutput, bool) bool) error {
+					page := &servicequotas.ListServiceQuotasOutput{}
+					for _, code := range codes[*input.ServiceCode] {
+						serviceQuota := &servicequotas.ServiceQuota{QuotaCode: awssdk.String(code)}
+						if !helper.Contains(withoutValue, code) {
+							serviceQuota.Value = awssdk.Float64(1000000)
+						}
+						page.Quotas = append(page.Quotas, serviceQuota)
+					}
+					fn(page, true)
+					return nil
+				}).Times(len(codes))
+		}
+
+		It("Accepts applied quotas above the minimum", func() {
+			expectAppliedQuotas()
+			Expect(client.ValidateMinimumQuota()).To(Succeed())
+		})
+
+		It("Uses the AWS default value of quotas without applied value", func() {
+			expectAppliedQuotas("L-1216C47A")
+			mockServiceQuotasAPI.EXPECT().GetAWSDefaultServiceQuota(&servicequotas.GetAWSDefaultServiceQuotaInput{
+				ServiceCode: awssdk.String("ec2"),
+				QuotaCode:   awssdk.String("L-1216C47A"),
+			}).Return(&servicequotas.GetAWSDefaultServiceQuotaOutput{
+				Quota: &servicequotas.ServiceQuota{
+					QuotaCode: awssdk.String("L-1216C47A"),
+					Value:     awssdk.Float64(5),
+				},
+			}, nil)
+			err := client.ValidateMinimumQuota()
+			Expect(err).To(MatchError(ContainSubstring(
+				"quota code L-1216C47A Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances not valid, " +
+					"expected quota of at least 100, but got 5")))
+		})
+	})
+	Context("GetQuotaUsage", func() {
+		var (
+			client               aws.Client
+			mockCtrl             *gomock.Controller
+			mockEC2API           *mocks.MockEC2API
+			mockServiceQuotasAPI *mocks.MockServiceQuotasAPI
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockEC2API = mocks.NewMockEC2API(mockCtrl)
+			mockServiceQuotasAPI = mocks.NewMockServiceQuotasAPI(mockCtrl)
+			client = aws.New(
+				logrus.New(),
+				mocks.NewMockIAMAPI(mockCtrl),
+				mockEC2API,
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mockServiceQuotasAPI,
+				mocks.NewMockKMSAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Reports the usage of the region without cluster requirements", func() {
+			mockEC2API.EXPECT().DescribeInstancesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+					fn(&ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{{
+							Instances: []*ec2.Instance{{
+								InstanceType: awssdk.String("m5.xlarge"),
+								CpuOptions: &ec2.CpuOptions{
+									CoreCount:      awssdk.Int64(2),
+									ThreadsPerCore: awssdk.Int64(2),
+								},
+							}},
+						}},
+					}, true)
+					return nil
+				})
+			mockEC2API.EXPECT().DescribeAddresses(gomock.Any()).Return(&ec2.DescribeAddressesOutput{}, nil)
+			mockEC2API.EXPECT().DescribeVpcsPages(gomock.Any(), gomock.Any()).Return(nil)
+			mockEC2API.EXPECT().DescribeInternetGatewaysPages(gomock.Any(), gomock.Any()).Return(nil)
+			mockEC2API.EXPECT().DescribeNetworkInterfacesPages(gomock.Any(), gomock.Any()).Return(nil)
+			mockEC2API.EXPECT().DescribeVolumesPages(gomock.Any(), gomock.Any()).Return(nil)
+			mockServiceQuotasAPI.EXPECT().ListServiceQuotasPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(input *servicequotas.ListServiceQuotasInput,
+					fn func(*servicequotas.ListServiceQuotasOutput, bool) bool) error {
+					fn(&servicequotas.ListServiceQuotasOutput{}, true)
+					return nil
+				}).Times(3)
+			mockServiceQuotasAPI.EXPECT().GetAWSDefaultServiceQuota(gomock.Any()).Return(
+				&servicequotas.GetAWSDefaultServiceQuotaOutput{
+					Quota: &servicequotas.ServiceQuota{Value: awssdk.Float64(5)},
+				}, nil).AnyTimes()
+
+			usages, err := client.GetQuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(HaveLen(11))
+			Expect(*usages[0]).To(Equal(aws.QuotaUsage{
+				ServiceCode: "ec2",
+				QuotaCode:   "L-1216C47A",
+				QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+				Applied:     5,
+				Usage:       4,
+			}))
+		})
+	})
+})
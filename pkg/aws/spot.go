@@ -0,0 +1,235 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/openshift/rosa/pkg/cost"
+)
+
+// SpotProductDescription is the product whose spot prices apply to cluster nodes
+const SpotProductDescription = "Linux/UNIX"
+
+// Estimated risk of spot instances being interrupted
+const (
+	SpotInterruptionRiskLow    = "Low"
+	SpotInterruptionRiskMedium = "Medium"
+	SpotInterruptionRiskHigh   = "High"
+)
+
+// SpotMaxPricePercentile is the percentile of the price history used to recommend a spot max price
+const SpotMaxPricePercentile = 90
+
+// SpotPriceSummary summarizes the spot price history of an instance type in an availability zone
+type SpotPriceSummary struct {
+	InstanceType     string   `json:"instance_type"`
+	AvailabilityZone string   `json:"availability_zone"`
+	Current          float64  `json:"current"`
+	P50              float64  `json:"p50"`
+	P90              float64  `json:"p90"`
+	Max              float64  `json:"max"`
+	Samples          int      `json:"samples"`
+	OnDemand         *float64 `json:"on_demand,omitempty"`
+	InterruptionRisk string   `json:"interruption_risk"`
+}
+
+// GetSpotPriceHistory fetches the spot prices of the instance type in the given availability zones, or
+// in all the zones of the region when none are given, since the given time
+func (c *awsClient) GetSpotPriceHistory(instanceType string, availabilityZones []string,
+	since time.Time) ([]*ec2.SpotPrice, error) {
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		InstanceTypes:       []*string{aws.String(instanceType)},
+		ProductDescriptions: []*string{aws.String(SpotProductDescription)},
+		StartTime:           aws.Time(since),
+	}
+	if len(availabilityZones) > 0 {
+		input.Filters = []*ec2.Filter{
+			{
+				Name:   aws.String("availability-zone"),
+				Values: aws.StringSlice(availabilityZones),
+			},
+		}
+	}
+
+	var spotPrices []*ec2.SpotPrice
+	err := c.ec2Client.DescribeSpotPriceHistoryPages(input,
+		func(page *ec2.DescribeSpotPriceHistoryOutput, lastPage bool) bool {
+			spotPrices = append(spotPrices, page.SpotPriceHistory...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, err
+	}
+	return spotPrices, nil
+}
+
+// GetSpotPriceSummaries summarizes the spot price history of the instance type in the availability zones
+// over the given number of days. The on-demand price is taken from the pricing table when available.
+func (c *awsClient) GetSpotPriceSummaries(region string, availabilityZones []string, instanceType string,
+	days int) ([]*SpotPriceSummary, error) {
+	spotPrices, err := c.GetSpotPriceHistory(instanceType, availabilityZones, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	var onDemand *float64
+	pricing, err := cost.LoadPricing("")
+	if err == nil {
+		price, err := pricing.GetInstancePrice(region, instanceType)
+		if err == nil {
+			onDemand = &price
+		} else {
+			c.logger.Debug(err)
+		}
+	}
+
+	return SummarizeSpotPrices(spotPrices, onDemand), nil
+}
+
+// GetCurrentSpotPrice returns the highest of the latest spot prices of each availability zone, which is
+// the price the nodes of a machine pool spread across those zones may be charged
+func GetCurrentSpotPrice(spotPrices []*ec2.SpotPrice) (float64, bool) {
+	latest := make(map[string]*ec2.SpotPrice)
+	for _, spotPrice := range spotPrices {
+		zone := aws.StringValue(spotPrice.AvailabilityZone)
+		current, ok := latest[zone]
+		if !ok || aws.TimeValue(spotPrice.Timestamp).After(aws.TimeValue(current.Timestamp)) {
+			latest[zone] = spotPrice
+		}
+	}
+
+	found := false
+	price := 0.0
+	for _, spotPrice := range latest {
+		value, err := strconv.ParseFloat(aws.StringValue(spotPrice.SpotPrice), 64)
+		if err != nil {
+			continue
+		}
+		if !found || value > price {
+			price = value
+			found = true
+		}
+	}
+	return price, found
+}
+
+// SummarizeSpotPrices computes the percentiles of the spot price history of each availability zone. The
+// on-demand price, when known, is used to estimate the interruption risk.
+func SummarizeSpotPrices(spotPrices []*ec2.SpotPrice, onDemand *float64) []*SpotPriceSummary {
+	pricesByZone := make(map[string][]*ec2.SpotPrice)
+	var zones []string
+	for _, spotPrice := range spotPrices {
+		zone := aws.StringValue(spotPrice.AvailabilityZone)
+		if _, ok := pricesByZone[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		pricesByZone[zone] = append(pricesByZone[zone], spotPrice)
+	}
+	sort.Strings(zones)
+
+	var summaries []*SpotPriceSummary
+	for _, zone := range zones {
+		var values []float64
+		for _, spotPrice := range pricesByZone[zone] {
+			value, err := strconv.ParseFloat(aws.StringValue(spotPrice.SpotPrice), 64)
+			if err != nil {
+				continue
+			}
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		current, _ := GetCurrentSpotPrice(pricesByZone[zone])
+		sort.Float64s(values)
+		summary := &SpotPriceSummary{
+			InstanceType:     aws.StringValue(pricesByZone[zone][0].InstanceType),
+			AvailabilityZone: zone,
+			Current:          current,
+			P50:              percentile(values, 50),
+			P90:              percentile(values, 90),
+			Max:              values[len(values)-1],
+			Samples:          len(values),
+			OnDemand:         onDemand,
+		}
+		summary.InterruptionRisk = getSpotInterruptionRisk(summary)
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// RecommendSpotMaxPrice picks the 90th percentile of the price history of the most expensive availability
+// zone, so that most of the price changes seen recently don't interrupt the instances. The boolean is
+// false when the recommended price reaches the on-demand price, in which case the on-demand price should
+// be used as the max price.
+func RecommendSpotMaxPrice(summaries []*SpotPriceSummary) (float64, bool) {
+	price := 0.0
+	for _, summary := range summaries {
+		if summary.P90 > price {
+			price = summary.P90
+		}
+	}
+	if price == 0 {
+		return 0, false
+	}
+	// Round up to the precision of the prices reported by AWS
+	price = math.Ceil(price*10000) / 10000
+	for _, summary := range summaries {
+		if summary.OnDemand != nil && price >= *summary.OnDemand {
+			return 0, false
+		}
+	}
+	return price, true
+}
+
+// getSpotInterruptionRisk estimates the risk of interruption from how close the spot price gets to the
+// on-demand price and from how much it changes. Prices rise when spare capacity becomes scarce, which is
+// also when instances are reclaimed.
+func getSpotInterruptionRisk(summary *SpotPriceSummary) string {
+	volatility := 0.0
+	if summary.P50 > 0 {
+		volatility = (summary.Max - summary.P50) / summary.P50
+	}
+	ratio := 0.0
+	if summary.OnDemand != nil && *summary.OnDemand > 0 {
+		ratio = summary.P90 / *summary.OnDemand
+	}
+	switch {
+	case ratio >= 0.8 || volatility >= 0.5:
+		return SpotInterruptionRiskHigh
+	case ratio >= 0.5 || volatility >= 0.2:
+		return SpotInterruptionRiskMedium
+	default:
+		return SpotInterruptionRiskLow
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sortedValues []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sortedValues))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sortedValues[rank-1]
+}
@@ -0,0 +1,78 @@
+package aws_test
+
+import (
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+func newSpotPrices(zone string, prices ...float64) []*ec2.SpotPrice {
+	var spotPrices []*ec2.SpotPrice
+	start := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	for i, price := range prices {
+		spotPrices = append(spotPrices, &ec2.SpotPrice{
+			AvailabilityZone: awssdk.String(zone),
+			InstanceType:     awssdk.String("r5.2xlarge"),
+			SpotPrice:        awssdk.String(fmt.Sprintf("%f", price)),
+			Timestamp:        awssdk.Time(start.Add(time.Duration(i) * time.Hour)),
+		})
+	}
+	return spotPrices
+}
+
+var _ = Describe("Spot prices", func() {
+	Context("SummarizeSpotPrices", func() {
+		It("Computes the percentiles of each zone", func() {
+			spotPrices := append(
+				newSpotPrices("us-east-1b", 0.10, 0.11, 0.12, 0.13, 0.14, 0.15, 0.16, 0.17, 0.18, 0.19),
+				newSpotPrices("us-east-1a", 0.10, 0.10, 0.10)...,
+			)
+			onDemand := 0.504
+			summaries := aws.SummarizeSpotPrices(spotPrices, &onDemand)
+			Expect(summaries).To(HaveLen(2))
+
+			Expect(summaries[0].AvailabilityZone).To(Equal("us-east-1a"))
+			Expect(summaries[0].InterruptionRisk).To(Equal(aws.SpotInterruptionRiskLow))
+
+			Expect(summaries[1].AvailabilityZone).To(Equal("us-east-1b"))
+			Expect(summaries[1].Current).To(Equal(0.19))
+			Expect(summaries[1].P50).To(Equal(0.14))
+			Expect(summaries[1].P90).To(Equal(0.18))
+			Expect(summaries[1].Max).To(Equal(0.19))
+			Expect(summaries[1].Samples).To(Equal(10))
+			Expect(summaries[1].InterruptionRisk).To(Equal(aws.SpotInterruptionRiskMedium))
+		})
+
+		It("Reports a high risk when the price gets close to the on-demand price", func() {
+			onDemand := 0.2
+			summaries := aws.SummarizeSpotPrices(newSpotPrices("us-east-1a", 0.18, 0.18), &onDemand)
+			Expect(summaries[0].InterruptionRisk).To(Equal(aws.SpotInterruptionRiskHigh))
+		})
+	})
+
+	Context("RecommendSpotMaxPrice", func() {
+		It("Picks the p90 of the most expensive zone", func() {
+			spotPrices := append(
+				newSpotPrices("us-east-1a", 0.10, 0.11, 0.12),
+				newSpotPrices("us-east-1b", 0.13, 0.14, 0.15)...,
+			)
+			price, ok := aws.RecommendSpotMaxPrice(aws.SummarizeSpotPrices(spotPrices, nil))
+			Expect(ok).To(BeTrue())
+			Expect(price).To(Equal(0.15))
+		})
+
+		It("Falls back to the on-demand price", func() {
+			onDemand := 0.12
+			price, ok := aws.RecommendSpotMaxPrice(
+				aws.SummarizeSpotPrices(newSpotPrices("us-east-1a", 0.11, 0.13), &onDemand))
+			Expect(ok).To(BeFalse())
+			Expect(price).To(BeZero())
+		})
+	})
+})
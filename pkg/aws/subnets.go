@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	LocalZoneType        = "local-zone"
+	WavelengthZoneType   = "wavelength-zone"
+	AvailabilityZoneType = "availability-zone"
+)
+
+// SubnetDetail contains the attributes of a subnet that help the user tell subnets apart
+type SubnetDetail struct {
+	SubnetID         string
+	VpcID            string
+	Name             string
+	AvailabilityZone string
+	CIDR             string
+	Public           bool
+	AvailableIPs     int64
+	LocalZone        bool
+}
+
+// GetSubnetDetails resolves the VPC routing and availability zone type of each one of the given subnets
+func (c *awsClient) GetSubnetDetails(subnets []*ec2.Subnet) ([]*SubnetDetail, error) {
+	routeTables := make(map[string][]*ec2.RouteTable)
+	var zoneNames []*string
+	for _, subnet := range subnets {
+		vpcID := aws.StringValue(subnet.VpcId)
+		if _, ok := routeTables[vpcID]; !ok {
+			describeRouteTablesOutput, err := c.ec2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+				Filters: []*ec2.Filter{
+					{
+						Name:   aws.String("vpc-id"),
+						Values: []*string{subnet.VpcId},
+					},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			routeTables[vpcID] = describeRouteTablesOutput.RouteTables
+		}
+		if !containsStringPointer(zoneNames, aws.StringValue(subnet.AvailabilityZone)) {
+			zoneNames = append(zoneNames, subnet.AvailabilityZone)
+		}
+	}
+
+	zoneTypes := make(map[string]string)
+	if len(zoneNames) > 0 {
+		describeAvailabilityZonesOutput, err := c.ec2Client.DescribeAvailabilityZones(
+			&ec2.DescribeAvailabilityZonesInput{ZoneNames: zoneNames})
+		if err != nil {
+			return nil, err
+		}
+		for _, az := range describeAvailabilityZonesOutput.AvailabilityZones {
+			zoneTypes[aws.StringValue(az.ZoneName)] = aws.StringValue(az.ZoneType)
+		}
+	}
+
+	details := make([]*SubnetDetail, 0, len(subnets))
+	for _, subnet := range subnets {
+		isPublic, err := c.isPublicSubnet(subnet.SubnetId, routeTables[aws.StringValue(subnet.VpcId)])
+		if err != nil {
+			return nil, err
+		}
+		availabilityZone := aws.StringValue(subnet.AvailabilityZone)
+		details = append(details, &SubnetDetail{
+			SubnetID:         aws.StringValue(subnet.SubnetId),
+			VpcID:            aws.StringValue(subnet.VpcId),
+			Name:             getSubnetTag(subnet, "Name"),
+			AvailabilityZone: availabilityZone,
+			CIDR:             aws.StringValue(subnet.CidrBlock),
+			Public:           isPublic,
+			AvailableIPs:     aws.Int64Value(subnet.AvailableIpAddressCount),
+			LocalZone:        zoneTypes[availabilityZone] == LocalZoneType,
+		})
+	}
+
+	return details, nil
+}
+
+// SetSubnetDetailOptions formats the subnets as the rows of a table so that they can be used as
+// options of an interactive prompt. The header of the table is returned separately. Each option
+// starts with the subnet ID so that it can be parsed back using `ParseSubnet`.
+func SetSubnetDetailOptions(details []*SubnetDetail) (string, []string) {
+	var b bytes.Buffer
+	writer := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SUBNET ID\tVPC ID\tNAME\tAVAILABILITY ZONE\tCIDR\tTYPE\tAVAILABLE IPS\tLOCAL ZONE\n")
+	for _, detail := range details {
+		subnetType := "private"
+		if detail.Public {
+			subnetType = "public"
+		}
+		localZone := "No"
+		if detail.LocalZone {
+			localZone = "Yes"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			detail.SubnetID,
+			detail.VpcID,
+			detail.Name,
+			detail.AvailabilityZone,
+			detail.CIDR,
+			subnetType,
+			detail.AvailableIPs,
+			localZone,
+		)
+	}
+	writer.Flush()
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	options := make([]string, 0, len(details))
+	for _, line := range lines[1:] {
+		options = append(options, strings.TrimRight(line, " "))
+	}
+	return strings.TrimRight(lines[0], " "), options
+}
+
+// ParseSubnetSelector parses a comma-separated list of 'key=value' tag selectors. Values may contain
+// '*' and '?' wildcards, for example 'Name=prod-*'.
+func ParseSubnetSelector(selector string) (map[string]*regexp.Regexp, error) {
+	selectors := make(map[string]*regexp.Regexp)
+	if strings.TrimSpace(selector) == "" {
+		return selectors, nil
+	}
+	for _, item := range strings.Split(selector, ",") {
+		tokens := strings.SplitN(item, "=", 2)
+		if len(tokens) != 2 || strings.TrimSpace(tokens[0]) == "" {
+			return nil, fmt.Errorf("Expected key=value format for subnet selector, got '%s'", item)
+		}
+		pattern := regexp.QuoteMeta(strings.TrimSpace(tokens[1]))
+		pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+		pattern = strings.ReplaceAll(pattern, `\?`, ".")
+		re, err := regexp.Compile(fmt.Sprintf("^%s$", pattern))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid subnet selector value '%s': %v", tokens[1], err)
+		}
+		selectors[strings.TrimSpace(tokens[0])] = re
+	}
+	return selectors, nil
+}
+
+// FilterSubnetsBySelector returns the subnets whose tags match all the given selectors
+func FilterSubnetsBySelector(subnets []*ec2.Subnet, selectors map[string]*regexp.Regexp) []*ec2.Subnet {
+	if len(selectors) == 0 {
+		return subnets
+	}
+	var filtered []*ec2.Subnet
+	for _, subnet := range subnets {
+		matches := true
+		for key, re := range selectors {
+			value, ok := getSubnetTagValue(subnet, key)
+			if !ok || !re.MatchString(value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, subnet)
+		}
+	}
+	return filtered
+}
+
+func getSubnetTag(subnet *ec2.Subnet, key string) string {
+	value, _ := getSubnetTagValue(subnet, key)
+	return value
+}
+
+func getSubnetTagValue(subnet *ec2.Subnet, key string) (string, bool) {
+	for _, tag := range subnet.Tags {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value), true
+		}
+	}
+	return "", false
+}
+
+func containsStringPointer(values []*string, value string) bool {
+	for _, v := range values {
+		if aws.StringValue(v) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package aws_test
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+func newSubnet(id string, vpcID string, zone string, name string) *ec2.Subnet {
+	return &ec2.Subnet{
+		SubnetId:                awssdk.String(id),
+		VpcId:                   awssdk.String(vpcID),
+		AvailabilityZone:        awssdk.String(zone),
+		CidrBlock:               awssdk.String("10.0.0.0/24"),
+		AvailableIpAddressCount: awssdk.Int64(250),
+		Tags: []*ec2.Tag{
+			{Key: awssdk.String("Name"), Value: awssdk.String(name)},
+		},
+	}
+}
+
+var _ = Describe("Subnets", func() {
+	Context("ParseSubnetSelector", func() {
+		It("Matches wildcards", func() {
+			selectors, err := aws.ParseSubnetSelector("Name=prod-*")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selectors).To(HaveKey("Name"))
+			Expect(selectors["Name"].MatchString("prod-private-1a")).To(BeTrue())
+			Expect(selectors["Name"].MatchString("dev-private-1a")).To(BeFalse())
+		})
+		It("Fails on invalid format", func() {
+			_, err := aws.ParseSubnetSelector("Name")
+			Expect(err).To(HaveOccurred())
+		})
+		It("Returns no selectors on empty input", func() {
+			selectors, err := aws.ParseSubnetSelector("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selectors).To(BeEmpty())
+		})
+	})
+
+	Context("FilterSubnetsBySelector", func() {
+		It("Keeps the subnets matching all the selectors", func() {
+			subnets := []*ec2.Subnet{
+				newSubnet("subnet-1", "vpc-1", "us-east-1a", "prod-a"),
+				newSubnet("subnet-2", "vpc-1", "us-east-1b", "dev-b"),
+			}
+			selectors, err := aws.ParseSubnetSelector("Name=prod-?")
+			Expect(err).NotTo(HaveOccurred())
+			filtered := aws.FilterSubnetsBySelector(subnets, selectors)
+			Expect(filtered).To(HaveLen(1))
+			Expect(*filtered[0].SubnetId).To(Equal("subnet-1"))
+		})
+	})
+
+	Context("GetSubnetDetails", func() {
+		var (
+			client     aws.Client
+			mockCtrl   *gomock.Controller
+			mockEC2API *mocks.MockEC2API
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockEC2API = mocks.NewMockEC2API(mockCtrl)
+			client = aws.New(
+				logrus.New(),
+				mocks.NewMockIAMAPI(mockCtrl),
+				mockEC2API,
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mocks.NewMockServiceQuotasAPI(mockCtrl),
+				mocks.NewMockKMSAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Classifies public and local zone subnets", func() {
+			mockEC2API.EXPECT().DescribeRouteTables(gomock.Any()).Return(&ec2.DescribeRouteTablesOutput{
+				RouteTables: []*ec2.RouteTable{
+					{
+						Associations: []*ec2.RouteTableAssociation{{SubnetId: awssdk.String("subnet-1")}},
+						Routes:       []*ec2.Route{{GatewayId: awssdk.String("igw-1")}},
+					},
+					{
+						Associations: []*ec2.RouteTableAssociation{{Main: awssdk.Bool(true)}},
+						Routes:       []*ec2.Route{{NatGatewayId: awssdk.String("nat-1")}},
+					},
+				},
+			}, nil)
+			mockEC2API.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []*ec2.AvailabilityZone{
+					{ZoneName: awssdk.String("us-east-1a"), ZoneType: awssdk.String(aws.AvailabilityZoneType)},
+					{ZoneName: awssdk.String("us-east-1-nyc-1a"), ZoneType: awssdk.String(aws.LocalZoneType)},
+				},
+			}, nil)
+
+			details, err := client.GetSubnetDetails([]*ec2.Subnet{
+				newSubnet("subnet-1", "vpc-1", "us-east-1a", "prod-public"),
+				newSubnet("subnet-2", "vpc-1", "us-east-1-nyc-1a", "prod-edge"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(details).To(HaveLen(2))
+			Expect(details[0].Public).To(BeTrue())
+			Expect(details[0].LocalZone).To(BeFalse())
+			Expect(details[0].Name).To(Equal("prod-public"))
+			Expect(details[1].Public).To(BeFalse())
+			Expect(details[1].LocalZone).To(BeTrue())
+
+			header, options := aws.SetSubnetDetailOptions(details)
+			Expect(header).To(ContainSubstring("AVAILABLE IPS"))
+			Expect(options).To(HaveLen(2))
+			Expect(aws.ParseSubnet(options[1])).To(Equal("subnet-2"))
+		})
+	})
+})
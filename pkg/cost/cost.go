@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to estimate the AWS infrastructure cost of a cluster
+// from an embedded pricing table. The table lists on-demand prices per region and can be refreshed by
+// editing 'pricing.json', or replaced at run time by passing a file with the same format.
+
+package cost
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+)
+
+// HoursPerMonth is the number of hours AWS uses to compute monthly prices
+const HoursPerMonth = 730
+
+//go:embed pricing.json
+var embeddedPricing []byte
+
+// RegionPricing contains the prices of the resources used by a cluster in a region
+type RegionPricing struct {
+	InstanceHourly            map[string]float64 `json:"instance_hourly"`
+	NATGatewayHourly          float64            `json:"nat_gateway_hourly"`
+	EBSGp3GiBMonthly          float64            `json:"ebs_gp3_gib_monthly"`
+	NetworkLoadBalancerHourly float64            `json:"network_load_balancer_hourly"`
+	ClassicLoadBalancerHourly float64            `json:"classic_load_balancer_hourly"`
+}
+
+// Pricing is the pricing table of all the supported regions
+type Pricing struct {
+	Currency string                    `json:"currency"`
+	Updated  string                    `json:"updated"`
+	Regions  map[string]*RegionPricing `json:"regions"`
+}
+
+// MachinePool describes a group of instances that the cluster will run. When SpotPrice is set, the
+// instances are priced at that hourly value instead of the on-demand price.
+type MachinePool struct {
+	Name          string
+	InstanceType  string
+	Replicas      int
+	VolumeSizeGiB int
+	SpotPrice     *float64
+}
+
+// ClusterSpec describes the planned cluster whose cost is estimated
+type ClusterSpec struct {
+	Region       string
+	MultiAZ      bool
+	Private      bool
+	BYOVPC       bool
+	MachinePools []MachinePool
+}
+
+// LineItem is the cost of one of the resources of the cluster
+type LineItem struct {
+	Name      string  `json:"name"`
+	Resource  string  `json:"resource"`
+	Quantity  float64 `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Unit      string  `json:"unit"`
+	Hourly    float64 `json:"hourly"`
+	Monthly   float64 `json:"monthly"`
+}
+
+// Estimate is the infrastructure cost of the cluster
+type Estimate struct {
+	Region        string      `json:"region"`
+	Currency      string      `json:"currency"`
+	PricesUpdated string      `json:"prices_updated"`
+	Items         []*LineItem `json:"items"`
+	Hourly        float64     `json:"hourly"`
+	Monthly       float64     `json:"monthly"`
+}
+
+// LoadPricing reads the pricing table from the given file, or the embedded table when no file is given
+func LoadPricing(path string) (*Pricing, error) {
+	data := embeddedPricing
+	if path != "" {
+		var err error
+		data, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read pricing file: %v", err)
+		}
+	}
+	pricing := &Pricing{}
+	err := json.Unmarshal(data, pricing)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse pricing table: %v", err)
+	}
+	return pricing, nil
+}
+
+// GetRegion returns the prices of the region
+func (p *Pricing) GetRegion(region string) (*RegionPricing, error) {
+	regionPricing, ok := p.Regions[region]
+	if !ok {
+		return nil, fmt.Errorf("No prices are available for region '%s'", region)
+	}
+	return regionPricing, nil
+}
+
+// GetInstancePrice returns the on-demand hourly price of the instance type in the region
+func (p *Pricing) GetInstancePrice(region string, instanceType string) (float64, error) {
+	regionPricing, err := p.GetRegion(region)
+	if err != nil {
+		return 0, err
+	}
+	price, ok := regionPricing.InstanceHourly[instanceType]
+	if !ok {
+		return 0, fmt.Errorf("No price is available for instance type '%s' in region '%s'",
+			instanceType, region)
+	}
+	return price, nil
+}
+
+// Estimate computes the hourly and monthly cost of the instances, volumes, NAT gateways and load
+// balancers of the planned cluster. Data transfer and load balancer capacity units aren't included.
+func (p *Pricing) Estimate(spec *ClusterSpec) (*Estimate, error) {
+	regionPricing, err := p.GetRegion(spec.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &Estimate{
+		Region:        spec.Region,
+		Currency:      p.Currency,
+		PricesUpdated: p.Updated,
+	}
+	addHourly := func(name string, resource string, quantity float64, unitPrice float64) {
+		if quantity <= 0 {
+			return
+		}
+		hourly := quantity * unitPrice
+		estimate.Items = append(estimate.Items, &LineItem{
+			Name:      name,
+			Resource:  resource,
+			Quantity:  quantity,
+			UnitPrice: unitPrice,
+			Unit:      "hour",
+			Hourly:    hourly,
+			Monthly:   hourly * HoursPerMonth,
+		})
+	}
+
+	storageGiB := 0
+	for _, machinePool := range spec.MachinePools {
+		price := 0.0
+		if machinePool.SpotPrice != nil {
+			price = *machinePool.SpotPrice
+		} else {
+			price, err = p.GetInstancePrice(spec.Region, machinePool.InstanceType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		addHourly(machinePool.Name, machinePool.InstanceType, float64(machinePool.Replicas), price)
+		storageGiB += machinePool.VolumeSizeGiB * machinePool.Replicas
+	}
+
+	if storageGiB > 0 {
+		monthly := float64(storageGiB) * regionPricing.EBSGp3GiBMonthly
+		estimate.Items = append(estimate.Items, &LineItem{
+			Name:      "storage",
+			Resource:  "gp3 volumes (GiB)",
+			Quantity:  float64(storageGiB),
+			UnitPrice: regionPricing.EBSGp3GiBMonthly,
+			Unit:      "month",
+			Hourly:    monthly / HoursPerMonth,
+			Monthly:   monthly,
+		})
+	}
+
+	// The same network resources are counted by the quota verification
+	availabilityZones := 1
+	if spec.MultiAZ {
+		availabilityZones = 3
+	}
+	if !spec.BYOVPC {
+		addHourly("network", "NAT gateway", float64(availabilityZones), regionPricing.NATGatewayHourly)
+	}
+	networkLoadBalancers := 2
+	if spec.Private {
+		networkLoadBalancers = 1
+	}
+	addHourly("network", "Network load balancer", float64(networkLoadBalancers),
+		regionPricing.NetworkLoadBalancerHourly)
+	addHourly("network", "Classic load balancer", 1, regionPricing.ClassicLoadBalancerHourly)
+
+	for _, item := range estimate.Items {
+		estimate.Hourly += item.Hourly
+		estimate.Monthly += item.Monthly
+	}
+	estimate.Hourly = round(estimate.Hourly)
+	estimate.Monthly = round(estimate.Monthly)
+
+	return estimate, nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,73 @@
+package cost_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/cost"
+)
+
+var _ = Describe("Cost", func() {
+	var (
+		pricing *cost.Pricing
+		spec    *cost.ClusterSpec
+	)
+
+	BeforeEach(func() {
+		var err error
+		pricing, err = cost.LoadPricing("")
+		Expect(err).NotTo(HaveOccurred())
+		spec = &cost.ClusterSpec{
+			Region:  "us-east-1",
+			MultiAZ: true,
+			MachinePools: []cost.MachinePool{
+				{Name: "control-plane", InstanceType: "m5.2xlarge", Replicas: 3, VolumeSizeGiB: 350},
+				{Name: "infra", InstanceType: "r5.xlarge", Replicas: 3, VolumeSizeGiB: 300},
+				{Name: "compute", InstanceType: "m5.xlarge", Replicas: 3, VolumeSizeGiB: 300},
+			},
+		}
+	})
+
+	It("Loads the embedded pricing table", func() {
+		price, err := pricing.GetInstancePrice("us-east-1", "m5.xlarge")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(price).To(Equal(0.192))
+	})
+
+	It("Estimates the instances, storage and network resources", func() {
+		estimate, err := pricing.Estimate(spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		resources := map[string]float64{}
+		for _, item := range estimate.Items {
+			resources[item.Resource] = item.Quantity
+		}
+		Expect(resources["NAT gateway"]).To(Equal(3.0))
+		Expect(resources["Network load balancer"]).To(Equal(2.0))
+		Expect(resources["gp3 volumes (GiB)"]).To(Equal(2850.0))
+
+		// 3 x 0.384 + 3 x 0.252 + 3 x 0.192 for instances, 3 x 0.045 for NAT gateways,
+		// 2 x 0.0225 + 0.025 for load balancers and 2850 x 0.08 / 730 for storage
+		Expect(estimate.Hourly).To(Equal(3.0))
+	})
+
+	It("Prices spot instances at the given price and skips NAT gateways in existing VPCs", func() {
+		spotPrice := 0.05
+		spec.BYOVPC = true
+		spec.MachinePools[2].SpotPrice = &spotPrice
+		estimate, err := pricing.Estimate(spec)
+		Expect(err).NotTo(HaveOccurred())
+		for _, item := range estimate.Items {
+			Expect(item.Resource).NotTo(Equal("NAT gateway"))
+			if item.Name == "compute" {
+				Expect(item.Hourly).To(BeNumerically("~", 0.15, 0.0001))
+			}
+		}
+	})
+
+	It("Fails for regions without prices", func() {
+		spec.Region = "mars-north-1"
+		_, err := pricing.Estimate(spec)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,255 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to validate the standard five field cron expressions accepted by
+// the automatic upgrade policies, and to compute the times when they run. Expressions are evaluated in
+// UTC, the same as the service does, unless a different location is given.
+
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search of the next run of expressions that never match, like '0 0 30 2 *'
+const maxSearchYears = 5
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	// Both 0 and 7 are Sunday
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	expression  string
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+	// When both days are restricted, a day matches when it matches either of them
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+	location      *time.Location
+}
+
+// Parse parses a cron expression with the minute, hour, day of month, month and day of week fields
+func Parse(expression string) (*Schedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("Invalid cron expression '%s': expected %d fields, got %d",
+			expression, len(fields), len(parts))
+	}
+	values := make([]map[int]bool, len(fields))
+	for i, part := range parts {
+		value, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid cron expression '%s': %v", expression, err)
+		}
+		values[i] = value
+	}
+	// Sunday can be written as 7
+	if values[4][7] {
+		delete(values[4], 7)
+		values[4][0] = true
+	}
+	return &Schedule{
+		expression:    strings.Join(parts, " "),
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: strings.HasPrefix(parts[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(parts[4], "*"),
+		location:      time.UTC,
+	}, nil
+}
+
+// In returns a copy of the schedule that is evaluated in the given location
+func (s *Schedule) In(location *time.Location) *Schedule {
+	result := *s
+	result.location = location
+	return &result
+}
+
+// String returns the normalized expression of the schedule
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// Next returns the first run of the schedule strictly after the given time, or the zero time when the
+// schedule never runs
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := s.location
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Prev returns the last run of the schedule at or before the given time, or the zero time when the
+// schedule didn't run
+func (s *Schedule) Prev(before time.Time) time.Time {
+	loc := s.location
+	t := before.In(loc).Truncate(time.Minute)
+	limit := t.AddDate(-maxSearchYears, 0, 0)
+	for t.After(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(-time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// NextRuns returns up to the given number of runs of the schedule after the given time
+func (s *Schedule) NextRuns(after time.Time, count int) []time.Time {
+	var runs []time.Time
+	for len(runs) < count {
+		after = s.Next(after)
+		if after.IsZero() {
+			break
+		}
+		runs = append(runs, after)
+	}
+	return runs
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth[t.Day()]
+	dayOfWeek := s.daysOfWeek[int(t.Weekday())]
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// parseField parses a comma separated list of values, ranges and steps
+func parseField(value string, f field) (map[int]bool, error) {
+	result := make(map[int]bool)
+	for _, item := range strings.Split(value, ",") {
+		rangePart := item
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step '%s' in %s field", item[i+1:], f.name)
+			}
+			rangePart = item[:i]
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			start, err = parseValue(bounds[0], f)
+			if err != nil {
+				return nil, err
+			}
+			end, err = parseValue(bounds[1], f)
+			if err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid range '%s' in %s field", rangePart, f.name)
+			}
+		default:
+			var err error
+			start, err = parseValue(rangePart, f)
+			if err != nil {
+				return nil, err
+			}
+			end = start
+			// A single value with a step runs from the value to the end of the range
+			if strings.Contains(item, "/") {
+				end = f.max
+			}
+		}
+		for v := start; v <= end; v += step {
+			result[v] = true
+		}
+	}
+	return result, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	if n, ok := f.names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s' in %s field", value, f.name)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d] in %s field", n, f.min, f.max, f.name)
+	}
+	return n, nil
+}
@@ -0,0 +1,92 @@
+package cron_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/cron"
+)
+
+// Wednesday
+var now = time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+
+func date(month time.Month, day int, hour int, minute int) time.Time {
+	return time.Date(2022, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+var _ = Describe("Cron", func() {
+	DescribeTable("Computes the next runs",
+		func(expression string, expected ...time.Time) {
+			schedule, err := cron.Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.NextRuns(now, len(expected))).To(Equal(expected))
+		},
+		Entry("weekly by name", "0 2 * * SUN",
+			date(time.June, 5, 2, 0), date(time.June, 12, 2, 0)),
+		Entry("Sunday as 7", "0 2 * * 7",
+			date(time.June, 5, 2, 0)),
+		Entry("steps", "*/20 11 * * *",
+			date(time.June, 1, 11, 0), date(time.June, 1, 11, 20), date(time.June, 1, 11, 40),
+			date(time.June, 2, 11, 0)),
+		Entry("lists and ranges", "15 1,13 * * MON-FRI",
+			date(time.June, 1, 13, 15), date(time.June, 2, 1, 15), date(time.June, 2, 13, 15)),
+		Entry("either day when both are restricted", "0 0 15 * SAT",
+			date(time.June, 4, 0, 0), date(time.June, 11, 0, 0), date(time.June, 15, 0, 0)),
+		Entry("month names", "0 0 1 JAN,JUL *",
+			date(time.July, 1, 0, 0)),
+		Entry("start with step", "30 10/6 * * *",
+			date(time.June, 1, 16, 30), date(time.June, 1, 22, 30), date(time.June, 2, 10, 30)),
+	)
+
+	It("Doesn't return runs for schedules that never run", func() {
+		schedule, err := cron.Parse("0 0 30 FEB *")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schedule.Next(now).IsZero()).To(BeTrue())
+		Expect(schedule.NextRuns(now, 3)).To(BeEmpty())
+	})
+
+	DescribeTable("Computes the previous run",
+		func(expression string, expected time.Time) {
+			schedule, err := cron.Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(now)).To(Equal(expected))
+		},
+		Entry("same minute", "30 10 * * *", date(time.June, 1, 10, 30)),
+		Entry("earlier today", "0 7 * * *", date(time.June, 1, 7, 0)),
+		Entry("previous weekday", "0 20 * * MON-FRI", date(time.May, 31, 20, 0)),
+		Entry("previous month", "0 0 15 * *", date(time.May, 15, 0, 0)),
+	)
+
+	It("Evaluates the schedule in a location", func() {
+		location, err := time.LoadLocation("America/Chicago")
+		Expect(err).NotTo(HaveOccurred())
+		schedule, err := cron.Parse("0 7 * * *")
+		Expect(err).NotTo(HaveOccurred())
+		schedule = schedule.In(location)
+		// 07:00 CDT is 12:00 UTC
+		Expect(schedule.Next(now).UTC()).To(Equal(date(time.June, 1, 12, 0)))
+		Expect(schedule.Prev(now).UTC()).To(Equal(date(time.May, 31, 12, 0)))
+	})
+
+	It("Normalizes the spacing of the expression", func() {
+		schedule, err := cron.Parse("  0  2 * *   SUN ")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schedule.String()).To(Equal("0 2 * * SUN"))
+	})
+
+	DescribeTable("Rejects invalid expressions",
+		func(expression string) {
+			_, err := cron.Parse(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "0 2 * *"),
+		Entry("too many fields", "0 0 2 * * SUN"),
+		Entry("minute out of range", "60 2 * * *"),
+		Entry("unknown name", "0 2 * * FUN"),
+		Entry("reversed range", "0 5-2 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+		Entry("macro", "@daily"),
+	)
+})
@@ -0,0 +1,259 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to compute the outbound endpoints that a cluster must
+// be able to reach, so that they can be allowed by firewalls that filter the egress traffic of the VPC.
+
+package egress
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	"github.com/openshift/rosa/pkg/fedramp"
+)
+
+// Environment of the OpenShift Cluster Manager instance that manages FedRAMP clusters
+const fedRAMPEnvironment = "production"
+
+// Categories of the endpoints
+const (
+	CategoryRegistry  = "registry"
+	CategoryTelemetry = "telemetry"
+	CategoryOCM       = "ocm"
+	CategorySRE       = "sre"
+	CategoryAWS       = "aws"
+	CategoryCluster   = "cluster"
+)
+
+var categoryOrder = map[string]int{
+	CategoryRegistry:  0,
+	CategoryTelemetry: 1,
+	CategoryOCM:       2,
+	CategorySRE:       3,
+	CategoryAWS:       4,
+	CategoryCluster:   5,
+}
+
+// Requirement is an endpoint that the cluster must be able to reach. Wildcard requirements allow all the
+// subdomains of the host.
+type Requirement struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Wildcard bool   `json:"wildcard"`
+	Category string `json:"category"`
+	Purpose  string `json:"purpose"`
+}
+
+// Options describe the cluster whose egress requirements are computed
+type Options struct {
+	Region      string
+	FedRAMP     bool
+	PrivateLink bool
+	// URLs of the API and the console of an existing cluster. Empty URLs, which OCM returns until the
+	// cluster is installed, are ignored.
+	ClusterURLs []string
+}
+
+// Address returns the requirement in 'host:port' form
+func (r *Requirement) Address() string {
+	return net.JoinHostPort(r.Host, strconv.Itoa(r.Port))
+}
+
+// Domain returns the requirement in the form used by domain allow lists, where a leading dot allows all
+// the subdomains
+func (r *Requirement) Domain() string {
+	if r.Wildcard {
+		return fmt.Sprintf(".%s", r.Host)
+	}
+	return r.Host
+}
+
+var registryHosts = []string{
+	"registry.redhat.io",
+	"registry.access.redhat.com",
+	"quay.io",
+	"cdn.quay.io",
+	"cdn01.quay.io",
+	"cdn02.quay.io",
+	"cdn03.quay.io",
+	"quay-registry.s3.amazonaws.com",
+	"quayio-production-s3.s3.amazonaws.com",
+}
+
+var telemetryHosts = []string{
+	"cert-api.access.redhat.com",
+	"api.access.redhat.com",
+	"infogw.api.openshift.com",
+	"console.redhat.com",
+}
+
+var ocmHosts = []string{
+	"api.openshift.com",
+	"mirror.openshift.com",
+	"sso.redhat.com",
+}
+
+var sreHosts = []string{
+	"api.pagerduty.com",
+	"events.pagerduty.com",
+	"api.deadmanssnitch.com",
+	"nosnch.in",
+	"http-inputs-osdsecuritylogs.splunkcloud.com",
+	"sftp.access.redhat.com",
+}
+
+// awsServices are the AWS services called by the installer and the cluster operators
+var awsServices = []string{
+	"ec2",
+	"elasticloadbalancing",
+	"iam",
+	"route53",
+	"s3",
+	"servicequotas",
+	"sts",
+	"tagging",
+}
+
+// GetRequirements returns the endpoints that a cluster with the given options must be able to reach,
+// sorted by category and host
+func GetRequirements(options *Options) ([]*Requirement, error) {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), options.Region)
+	if !ok {
+		return nil, fmt.Errorf("Unknown region '%s'", options.Region)
+	}
+
+	var requirements []*Requirement
+	add := func(category string, purpose string, hosts ...string) {
+		for _, host := range hosts {
+			requirements = append(requirements, &Requirement{
+				Host:     host,
+				Port:     443,
+				Category: category,
+				Purpose:  purpose,
+			})
+		}
+	}
+
+	add(CategoryRegistry, "Container images", registryHosts...)
+	if options.FedRAMP {
+		// FedRAMP clusters are managed by a separate OCM instance and don't send telemetry
+		for _, fedRAMPURL := range []string{fedramp.URLAliases[fedRAMPEnvironment],
+			fedramp.TokenURLs[fedRAMPEnvironment]} {
+			host, err := getHost(fedRAMPURL)
+			if err != nil {
+				return nil, err
+			}
+			add(CategoryOCM, "OpenShift Cluster Manager", host)
+		}
+	} else {
+		add(CategoryTelemetry, "Telemetry and insights", telemetryHosts...)
+		add(CategoryOCM, "OpenShift Cluster Manager", ocmHosts...)
+	}
+	add(CategorySRE, "Monitoring and logging by Red Hat SRE", sreHosts...)
+
+	for _, service := range awsServices {
+		// Operators of STS clusters call the regional STS endpoint
+		endpoint, err := partition.EndpointFor(service, options.Region,
+			endpoints.ResolveUnknownServiceOption, endpoints.STSRegionalEndpointOption)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to resolve endpoint of service '%s' in region '%s': %v",
+				service, options.Region, err)
+		}
+		host, err := getHost(endpoint.URL)
+		if err != nil {
+			return nil, err
+		}
+		add(CategoryAWS, fmt.Sprintf("AWS %s API", service), host)
+	}
+	// Buckets of the image registry and of the installer are reached with virtual hosted style URLs
+	requirements = append(requirements, &Requirement{
+		Host:     fmt.Sprintf("s3.%s.%s", options.Region, partition.DNSSuffix()),
+		Port:     443,
+		Wildcard: true,
+		Category: CategoryAWS,
+		Purpose:  "AWS S3 buckets",
+	})
+
+	// The API and the console of PrivateLink clusters are only reachable from the VPC
+	if !options.PrivateLink {
+		for _, clusterURL := range options.ClusterURLs {
+			if clusterURL == "" {
+				continue
+			}
+			parsed, err := url.Parse(clusterURL)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid cluster URL '%s': %v", clusterURL, err)
+			}
+			if parsed.Hostname() == "" {
+				return nil, fmt.Errorf("Invalid cluster URL '%s': it doesn't contain a host", clusterURL)
+			}
+			port := 443
+			if parsed.Port() != "" {
+				port, err = strconv.Atoi(parsed.Port())
+				if err != nil {
+					return nil, fmt.Errorf("Invalid port in cluster URL '%s'", clusterURL)
+				}
+			}
+			requirements = append(requirements, &Requirement{
+				Host:     parsed.Hostname(),
+				Port:     port,
+				Category: CategoryCluster,
+				Purpose:  "Cluster API and console",
+			})
+		}
+	}
+
+	requirements = deduplicate(requirements)
+	sort.SliceStable(requirements, func(i, j int) bool {
+		if requirements[i].Category != requirements[j].Category {
+			return categoryOrder[requirements[i].Category] < categoryOrder[requirements[j].Category]
+		}
+		return requirements[i].Address() < requirements[j].Address()
+	})
+	return requirements, nil
+}
+
+func getHost(value string) (string, error) {
+	if !strings.Contains(value, "://") {
+		value = fmt.Sprintf("https://%s", value)
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("Invalid URL '%s': %v", value, err)
+	}
+	return parsed.Hostname(), nil
+}
+
+func deduplicate(requirements []*Requirement) []*Requirement {
+	seen := make(map[string]bool)
+	var result []*Requirement
+	for _, requirement := range requirements {
+		key := fmt.Sprintf("%s/%t", requirement.Address(), requirement.Wildcard)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, requirement)
+	}
+	return result
+}
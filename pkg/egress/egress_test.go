@@ -0,0 +1,121 @@
+package egress_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/egress"
+)
+
+func getDomains(requirements []*egress.Requirement) []string {
+	var domains []string
+	for _, requirement := range requirements {
+		domains = append(domains, requirement.Domain())
+	}
+	return domains
+}
+
+var _ = Describe("Egress", func() {
+	Context("GetRequirements", func() {
+		It("Resolves the regional AWS endpoints of the commercial partition", func() {
+			requirements, err := egress.GetRequirements(&egress.Options{Region: "us-west-2"})
+			Expect(err).NotTo(HaveOccurred())
+			domains := getDomains(requirements)
+			Expect(domains).To(ContainElements(
+				"quay.io",
+				"api.openshift.com",
+				"console.redhat.com",
+				"ec2.us-west-2.amazonaws.com",
+				"sts.us-west-2.amazonaws.com",
+				"iam.amazonaws.com",
+				".s3.us-west-2.amazonaws.com",
+			))
+		})
+
+		It("Resolves the AWS endpoints of other partitions", func() {
+			requirements, err := egress.GetRequirements(&egress.Options{Region: "cn-north-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getDomains(requirements)).To(ContainElements(
+				"ec2.cn-north-1.amazonaws.com.cn",
+				".s3.cn-north-1.amazonaws.com.cn",
+			))
+		})
+
+		It("Uses the FedRAMP OCM instance and doesn't send telemetry in FedRAMP mode", func() {
+			requirements, err := egress.GetRequirements(&egress.Options{
+				Region:  "us-gov-west-1",
+				FedRAMP: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			domains := getDomains(requirements)
+			Expect(domains).To(ContainElement("api.openshiftusgov.com"))
+			Expect(domains).NotTo(ContainElement("api.openshift.com"))
+			for _, requirement := range requirements {
+				Expect(requirement.Category).NotTo(Equal(egress.CategoryTelemetry))
+			}
+		})
+
+		It("Includes the hosts of the cluster unless it uses PrivateLink", func() {
+			options := &egress.Options{
+				Region: "us-east-1",
+				ClusterURLs: []string{
+					"https://api.mycluster.abcd.p1.openshiftapps.com:6443",
+					"https://console-openshift-console.apps.mycluster.abcd.p1.openshiftapps.com",
+				},
+			}
+			requirements, err := egress.GetRequirements(options)
+			Expect(err).NotTo(HaveOccurred())
+			var addresses []string
+			for _, requirement := range requirements {
+				addresses = append(addresses, requirement.Address())
+			}
+			Expect(addresses).To(ContainElements(
+				"api.mycluster.abcd.p1.openshiftapps.com:6443",
+				"console-openshift-console.apps.mycluster.abcd.p1.openshiftapps.com:443",
+			))
+
+			options.PrivateLink = true
+			requirements, err = egress.GetRequirements(options)
+			Expect(err).NotTo(HaveOccurred())
+			for _, requirement := range requirements {
+				Expect(requirement.Category).NotTo(Equal(egress.CategoryCluster))
+			}
+		})
+
+		It("Ignores empty cluster URLs and rejects the ones without a host", func() {
+			options := &egress.Options{
+				Region:      "us-east-1",
+				ClusterURLs: []string{"", ""},
+			}
+			requirements, err := egress.GetRequirements(options)
+			Expect(err).NotTo(HaveOccurred())
+			for _, requirement := range requirements {
+				Expect(requirement.Category).NotTo(Equal(egress.CategoryCluster))
+			}
+
+			options.ClusterURLs = []string{"api.mycluster.abcd.p1.openshiftapps.com"}
+			_, err = egress.GetRequirements(options)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Fails for unknown regions", func() {
+			_, err := egress.GetRequirements(&egress.Options{Region: "mars-east-1"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("NewNetworkFirewallRuleGroup", func() {
+		It("Allows each domain once", func() {
+			ruleGroup := egress.NewNetworkFirewallRuleGroup([]*egress.Requirement{
+				{Host: "quay.io", Port: 443},
+				{Host: "s3.us-east-1.amazonaws.com", Port: 443, Wildcard: true},
+				{Host: "api.example.com", Port: 443},
+				{Host: "api.example.com", Port: 6443},
+			})
+			list := ruleGroup.RulesSource.RulesSourceList
+			Expect(list.Targets).To(Equal([]string{"quay.io", ".s3.us-east-1.amazonaws.com", "api.example.com"}))
+			Expect(list.GeneratedRulesType).To(Equal("ALLOWLIST"))
+			Expect(ruleGroup.Capacity()).To(Equal(6))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types used to export the egress requirements as an AWS Network Firewall rule
+// group.
+
+package egress
+
+// NetworkFirewallRuleGroup is the rule group accepted by 'aws network-firewall create-rule-group'
+type NetworkFirewallRuleGroup struct {
+	RulesSource NetworkFirewallRulesSource `json:"RulesSource"`
+}
+
+// NetworkFirewallRulesSource contains the stateful domain list of the rule group
+type NetworkFirewallRulesSource struct {
+	RulesSourceList NetworkFirewallRulesSourceList `json:"RulesSourceList"`
+}
+
+// NetworkFirewallRulesSourceList is a domain list that allows the traffic to the targets
+type NetworkFirewallRulesSourceList struct {
+	Targets            []string `json:"Targets"`
+	TargetTypes        []string `json:"TargetTypes"`
+	GeneratedRulesType string   `json:"GeneratedRulesType"`
+}
+
+// networkFirewallTargetTypes inspects both plain HTTP and TLS traffic
+var networkFirewallTargetTypes = []string{"HTTP_HOST", "TLS_SNI"}
+
+// NewNetworkFirewallRuleGroup returns a stateful rule group that allows the domains of the requirements
+func NewNetworkFirewallRuleGroup(requirements []*Requirement) *NetworkFirewallRuleGroup {
+	var targets []string
+	seen := make(map[string]bool)
+	for _, requirement := range requirements {
+		domain := requirement.Domain()
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		targets = append(targets, domain)
+	}
+	return &NetworkFirewallRuleGroup{
+		RulesSource: NetworkFirewallRulesSource{
+			RulesSourceList: NetworkFirewallRulesSourceList{
+				Targets:            targets,
+				TargetTypes:        networkFirewallTargetTypes,
+				GeneratedRulesType: "ALLOWLIST",
+			},
+		},
+	}
+}
+
+// Capacity returns the capacity that the rule group needs, one unit per target and target type
+func (g *NetworkFirewallRuleGroup) Capacity() int {
+	list := g.RulesSource.RulesSourceList
+	return len(list.Targets) * len(list.TargetTypes)
+}
@@ -1,9 +1,11 @@
 package helper
 
 import (
+	"fmt"
 	"math/rand"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -179,3 +181,29 @@ func HandleEmptyStringOnSlice(slice []string) []string {
 	}
 	return r
 }
+
+// ParseDuration parses a duration like time.ParseDuration, but also accepts a leading number of days,
+// like 7d or 1d12h. Negative durations aren't accepted.
+func ParseDuration(s string) (time.Duration, error) {
+	value := s
+	days := 0
+	if i := strings.Index(value, "d"); i >= 0 {
+		var err error
+		days, err = strconv.Atoi(value[:i])
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", s)
+		}
+		value = value[i+1:]
+		if value == "" {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration < 0 || strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("invalid duration '%s'", s)
+	}
+	return time.Duration(days)*24*time.Hour + duration, nil
+}
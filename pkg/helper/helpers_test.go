@@ -0,0 +1,43 @@
+package helper_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+var _ = Describe("ParseDuration", func() {
+	DescribeTable("Parses durations",
+		func(value string, expected time.Duration) {
+			duration, err := helper.ParseDuration(value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(duration).To(Equal(expected))
+		},
+		Entry("days", "7d", 7*24*time.Hour),
+		Entry("days and hours", "1d12h", 36*time.Hour),
+		Entry("zero days", "0d", time.Duration(0)),
+		Entry("zero days and minutes", "0d30m", 30*time.Minute),
+		Entry("hours", "12h", 12*time.Hour),
+		Entry("hours and minutes", "1h30m", 90*time.Minute),
+	)
+
+	DescribeTable("Rejects invalid durations",
+		func(value string) {
+			_, err := helper.ParseDuration(value)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("negative days", "-1d"),
+		Entry("negative hours", "-12h"),
+		Entry("negative hours after days", "1d-12h"),
+		Entry("days without number", "d"),
+		Entry("days after hours", "12h1d"),
+		Entry("fractional days", "1.5d"),
+		Entry("unknown unit", "7w"),
+		Entry("missing unit", "7"),
+		Entry("text", "week"),
+	)
+})
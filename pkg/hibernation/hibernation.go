@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the hibernation schedules of clusters. The schedules are stored in the properties of
+// the cluster, and applied by a reconciler that brings the cluster to the state given by each run of the
+// schedules once, so that clusters hibernated or resumed by hand stay so until the next run.
+
+package hibernation
+
+import (
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/cron"
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+// Action is the change that the reconciler applies to a cluster
+type Action string
+
+const (
+	ActionNone      Action = ""
+	ActionHibernate Action = "hibernate"
+	ActionResume    Action = "resume"
+)
+
+// Schedule contains when a cluster hibernates and when it resumes
+type Schedule struct {
+	Hibernate *cron.Schedule
+	Resume    *cron.Schedule
+	Location  *time.Location
+}
+
+// NewSchedule parses the cron expressions of the schedule, which are evaluated in the given time zone
+func NewSchedule(hibernate string, resume string, timezone string) (*Schedule, error) {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid time zone '%s': %v", timezone, err)
+	}
+	hibernateSchedule, err := cron.Parse(hibernate)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid hibernate schedule: %v", err)
+	}
+	resumeSchedule, err := cron.Parse(resume)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid resume schedule: %v", err)
+	}
+	return &Schedule{
+		Hibernate: hibernateSchedule.In(location),
+		Resume:    resumeSchedule.In(location),
+		Location:  location,
+	}, nil
+}
+
+// GetSchedule returns the hibernation schedule stored in the properties of a cluster, or nil if the cluster
+// doesn't have one
+func GetSchedule(cluster *cmv1.Cluster) (*Schedule, error) {
+	props := cluster.Properties()
+	hibernate := props[properties.HibernateSchedule]
+	resume := props[properties.ResumeSchedule]
+	if hibernate == "" && resume == "" {
+		return nil, nil
+	}
+	return NewSchedule(hibernate, resume, props[properties.ScheduleTimezone])
+}
+
+// Properties returns the properties of a cluster with the schedule. A nil schedule removes it. The last
+// applied run is removed too, so that the last run of a new schedule is applied.
+func (s *Schedule) Properties(clusterProperties map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range clusterProperties {
+		result[key] = value
+	}
+	delete(result, properties.HibernateSchedule)
+	delete(result, properties.ResumeSchedule)
+	delete(result, properties.ScheduleTimezone)
+	delete(result, properties.ScheduleLastRun)
+	if s != nil {
+		result[properties.HibernateSchedule] = s.Hibernate.String()
+		result[properties.ResumeSchedule] = s.Resume.String()
+		result[properties.ScheduleTimezone] = s.Location.String()
+	}
+	return result
+}
+
+// Action returns the change needed to bring a cluster to the state given by the last run of the schedules,
+// and when that run happened. Runs that aren't newer than the last applied one are ignored, so that clusters
+// hibernated or resumed by hand since then are left alone, and the returned time is zero. The returned time
+// is also zero for clusters that are changing state, which are checked again later. Otherwise it should be
+// saved as the last applied run once the action succeeds, even when there is nothing to do.
+func (s *Schedule) Action(state cmv1.ClusterState, lastRun time.Time, now time.Time) (Action, time.Time) {
+	lastHibernate := s.Hibernate.Prev(now)
+	lastResume := s.Resume.Prev(now)
+	shouldHibernate := lastHibernate.After(lastResume)
+	run := lastResume
+	if shouldHibernate {
+		run = lastHibernate
+	}
+	if run.IsZero() || !run.After(lastRun) {
+		return ActionNone, time.Time{}
+	}
+	switch {
+	case shouldHibernate && state == cmv1.ClusterStateReady:
+		return ActionHibernate, run
+	case !shouldHibernate && state == cmv1.ClusterStateHibernating:
+		return ActionResume, run
+	case shouldHibernate && state == cmv1.ClusterStateHibernating,
+		!shouldHibernate && state == cmv1.ClusterStateReady:
+		return ActionNone, run
+	}
+	return ActionNone, time.Time{}
+}
+
+// GetLastRun returns the last run of the schedule that was applied to a cluster, or the zero time if none
+// was applied yet
+func GetLastRun(cluster *cmv1.Cluster) time.Time {
+	lastRun, err := time.Parse(time.RFC3339, cluster.Properties()[properties.ScheduleLastRun])
+	if err != nil {
+		return time.Time{}
+	}
+	return lastRun
+}
+
+// LastRunProperties returns a copy of the properties of a cluster with the last applied run of the schedule
+func LastRunProperties(clusterProperties map[string]string, lastRun time.Time) map[string]string {
+	result := make(map[string]string)
+	for key, value := range clusterProperties {
+		result[key] = value
+	}
+	result[properties.ScheduleLastRun] = lastRun.UTC().Format(time.RFC3339)
+	return result
+}
+
+// Next returns the next action of the schedule after the given time, and when it runs
+func (s *Schedule) Next(now time.Time) (Action, time.Time) {
+	nextHibernate := s.Hibernate.Next(now)
+	nextResume := s.Resume.Next(now)
+	switch {
+	case nextHibernate.IsZero() && nextResume.IsZero():
+		return ActionNone, time.Time{}
+	case nextResume.IsZero() || (!nextHibernate.IsZero() && nextHibernate.Before(nextResume)):
+		return ActionHibernate, nextHibernate
+	}
+	return ActionResume, nextResume
+}
@@ -0,0 +1,117 @@
+package hibernation_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+var _ = Describe("Hibernation schedule", func() {
+	var schedule *hibernation.Schedule
+	var chicago *time.Location
+
+	BeforeEach(func() {
+		var err error
+		chicago, err = time.LoadLocation("America/Chicago")
+		Expect(err).ToNot(HaveOccurred())
+		schedule, err = hibernation.NewSchedule("0 20 * * 1-5", "0 7 * * 1-5", "America/Chicago")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Rejects invalid schedules", func() {
+		_, err := hibernation.NewSchedule("0 20 * *", "0 7 * * 1-5", "UTC")
+		Expect(err).To(HaveOccurred())
+		_, err = hibernation.NewSchedule("0 20 * * 1-5", "0 7 * * 1-5", "Mars/Olympus")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Hibernates ready clusters after the hibernate schedule runs", func() {
+		// Tuesday at 21:00 in Chicago
+		now := time.Date(2022, 11, 1, 21, 0, 0, 0, chicago)
+		tuesdayEvening := time.Date(2022, 11, 1, 20, 0, 0, 0, chicago)
+		action, run := schedule.Action(cmv1.ClusterStateReady, time.Time{}, now)
+		Expect(action).To(Equal(hibernation.ActionHibernate))
+		Expect(run).To(BeTemporally("==", tuesdayEvening))
+
+		action, run = schedule.Action(cmv1.ClusterStateHibernating, time.Time{}, now)
+		Expect(action).To(Equal(hibernation.ActionNone))
+		Expect(run).To(BeTemporally("==", tuesdayEvening))
+	})
+
+	It("Keeps clusters hibernating over the weekend", func() {
+		// Saturday at noon in Chicago
+		now := time.Date(2022, 11, 5, 12, 0, 0, 0, chicago)
+		action, _ := schedule.Action(cmv1.ClusterStateReady, time.Time{}, now)
+		Expect(action).To(Equal(hibernation.ActionHibernate))
+	})
+
+	It("Resumes hibernating clusters after the resume schedule runs", func() {
+		// Monday at 8:00 in Chicago
+		now := time.Date(2022, 11, 7, 8, 0, 0, 0, chicago)
+		action, _ := schedule.Action(cmv1.ClusterStateHibernating, time.Time{}, now)
+		Expect(action).To(Equal(hibernation.ActionResume))
+		action, _ = schedule.Action(cmv1.ClusterStateReady, time.Time{}, now)
+		Expect(action).To(Equal(hibernation.ActionNone))
+		action, run := schedule.Action(cmv1.ClusterStateResuming, time.Time{}, now)
+		Expect(action).To(Equal(hibernation.ActionNone))
+		Expect(run.IsZero()).To(BeTrue())
+	})
+
+	It("Leaves alone the clusters changed by hand since the last applied run", func() {
+		// Tuesday at 21:00 in Chicago, after the cluster was resumed by hand
+		now := time.Date(2022, 11, 1, 21, 0, 0, 0, chicago)
+		lastRun := time.Date(2022, 11, 1, 20, 0, 0, 0, chicago)
+		action, run := schedule.Action(cmv1.ClusterStateReady, lastRun, now)
+		Expect(action).To(Equal(hibernation.ActionNone))
+		Expect(run.IsZero()).To(BeTrue())
+
+		// Wednesday at 8:00 in Chicago, after the cluster was hibernated by hand again
+		now = time.Date(2022, 11, 2, 8, 0, 0, 0, chicago)
+		action, _ = schedule.Action(cmv1.ClusterStateHibernating, lastRun, now)
+		Expect(action).To(Equal(hibernation.ActionResume))
+	})
+
+	It("Stores the last applied run in the cluster properties", func() {
+		lastRun := time.Date(2022, 11, 1, 20, 0, 0, 0, chicago)
+		props := hibernation.LastRunProperties(map[string]string{"owner": "alice"}, lastRun)
+		Expect(props).To(HaveKeyWithValue("owner", "alice"))
+		cluster, err := cmv1.NewCluster().Properties(props).Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hibernation.GetLastRun(cluster)).To(BeTemporally("==", lastRun))
+
+		Expect(schedule.Properties(props)).ToNot(HaveKey(properties.ScheduleLastRun))
+	})
+
+	It("Returns the next action", func() {
+		// Friday at 21:00 in Chicago
+		now := time.Date(2022, 11, 4, 21, 0, 0, 0, chicago)
+		action, next := schedule.Next(now)
+		Expect(action).To(Equal(hibernation.ActionResume))
+		Expect(next).To(BeTemporally("==", time.Date(2022, 11, 7, 7, 0, 0, 0, chicago)))
+	})
+
+	It("Stores the schedule in the cluster properties", func() {
+		props := schedule.Properties(map[string]string{"owner": "alice"})
+		Expect(props).To(Equal(map[string]string{
+			"owner":                      "alice",
+			properties.HibernateSchedule: "0 20 * * 1-5",
+			properties.ResumeSchedule:    "0 7 * * 1-5",
+			properties.ScheduleTimezone:  "America/Chicago",
+		}))
+
+		cluster, err := cmv1.NewCluster().Properties(props).Build()
+		Expect(err).ToNot(HaveOccurred())
+		stored, err := hibernation.GetSchedule(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored.Location.String()).To(Equal("America/Chicago"))
+
+		var noSchedule *hibernation.Schedule
+		Expect(noSchedule.Properties(props)).To(Equal(map[string]string{"owner": "alice"}))
+	})
+})
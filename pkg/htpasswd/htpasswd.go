@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the parser of the files that list the users of htpasswd identity providers, and the
+// computation of the changes needed to make an identity provider match a file.
+
+package htpasswd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// bcryptRE matches the bcrypt hashes generated by 'htpasswd -B'
+var bcryptRE = regexp.MustCompile(`^\$2[aby]\$\d{2}\$[./A-Za-z0-9]{53}$`)
+
+// ValidateUsername checks that a username is accepted by the htpasswd identity provider
+func ValidateUsername(username string) error {
+	if username == "" {
+		return fmt.Errorf("username can't be empty")
+	}
+	if strings.ContainsAny(username, "/:%") {
+		return fmt.Errorf("invalid username '%s': username must not contain /, :, or %%", username)
+	}
+	return nil
+}
+
+// ValidatePassword checks that a plain text password is accepted by the htpasswd identity provider
+func ValidatePassword(password string) error {
+	notAsciiOnly, _ := regexp.MatchString(`[^\x20-\x7E]`, password)
+	containsSpace := strings.Contains(password, " ")
+	tooShort := len(password) < 14
+	if notAsciiOnly || containsSpace || tooShort {
+		return fmt.Errorf(
+			"password must be at least 14 characters (ASCII-standard) without whitespaces")
+	}
+	hasUppercase, _ := regexp.MatchString(`[A-Z]`, password)
+	hasLowercase, _ := regexp.MatchString(`[a-z]`, password)
+	hasNumberOrSymbol, _ := regexp.MatchString(`[^a-zA-Z]`, password)
+	if !hasUppercase || !hasLowercase || !hasNumberOrSymbol {
+		return fmt.Errorf(
+			"password must include uppercase letters, lowercase letters, and numbers " +
+				"or symbols (ASCII-standard characters only)")
+	}
+	return nil
+}
+
+// ParseFile reads the users of the given file, see Parse
+func ParseFile(path string) ([]*ocm.HTPasswdUserSpec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	users, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read users from '%s': %v", path, err)
+	}
+	return users, nil
+}
+
+// Parse reads users from lines in htpasswd format with bcrypt hashes, as generated by 'htpasswd -B', or
+// from CSV lines with a username and a plain text password. Empty lines, lines starting with '#' and a
+// 'username,password' CSV header are ignored.
+func Parse(reader io.Reader) ([]*ocm.HTPasswdUserSpec, error) {
+	var users []*ocm.HTPasswdUserSpec
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(reader)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", number, err)
+		}
+		if user == nil {
+			continue
+		}
+		if seen[user.Username] {
+			return nil, fmt.Errorf("line %d: duplicated user '%s'", number, user.Username)
+		}
+		seen[user.Username] = true
+		users = append(users, user)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no users found")
+	}
+	return users, nil
+}
+
+func parseLine(line string) (*ocm.HTPasswdUserSpec, error) {
+	// Lines without commas are in htpasswd format
+	if !strings.Contains(line, ",") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected 'username:hash' or 'username,password'")
+		}
+		if !bcryptRE.MatchString(parts[1]) {
+			return nil, fmt.Errorf("the password of user '%s' isn't hashed with bcrypt, "+
+				"generate it with 'htpasswd -B'", parts[0])
+		}
+		user := &ocm.HTPasswdUserSpec{Username: parts[0], HashedPassword: parts[1]}
+		return user, ValidateUsername(user.Username)
+	}
+
+	fields, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("expected 'username,password' but found %d fields", len(fields))
+	}
+	if strings.EqualFold(fields[0], "username") && strings.EqualFold(fields[1], "password") {
+		return nil, nil
+	}
+	err = ValidateUsername(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	err = ValidatePassword(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid password of user '%s': %v", fields[0], err)
+	}
+	return &ocm.HTPasswdUserSpec{Username: fields[0], Password: fields[1]}, nil
+}
+
+// Changes are the users to add, update and remove to make an identity provider match a file
+type Changes struct {
+	Add    []*ocm.HTPasswdUserSpec
+	Update []*ocm.HTPasswdUserSpec
+	Remove []string
+}
+
+// Empty returns true if there is nothing to change
+func (c *Changes) Empty() bool {
+	return len(c.Add) == 0 && len(c.Update) == 0 && len(c.Remove) == 0
+}
+
+// Diff computes the changes that make the existing users match the desired ones. The passwords of the
+// identity provider can't be read, so the existing users that are desired are always updated, which is
+// idempotent. Existing users for which keep returns true, such as admins, are never removed.
+func Diff(existing []string, desired []*ocm.HTPasswdUserSpec, keep func(username string) bool) *Changes {
+	changes := &Changes{}
+	existingSet := make(map[string]bool)
+	for _, username := range existing {
+		existingSet[username] = true
+	}
+	desiredSet := make(map[string]bool)
+	for _, user := range desired {
+		desiredSet[user.Username] = true
+		if existingSet[user.Username] {
+			changes.Update = append(changes.Update, user)
+		} else {
+			changes.Add = append(changes.Add, user)
+		}
+	}
+	for _, username := range existing {
+		if !desiredSet[username] && (keep == nil || !keep(username)) {
+			changes.Remove = append(changes.Remove, username)
+		}
+	}
+	sort.Strings(changes.Remove)
+	return changes
+}
@@ -0,0 +1,13 @@
+package htpasswd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTPasswd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTPasswd Suite")
+}
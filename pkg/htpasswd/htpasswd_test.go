@@ -0,0 +1,74 @@
+package htpasswd_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/htpasswd"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const hash = "$2y$05$c4WoMPo3SXsafkva.HHa6uXQZWr7oboPiC2bT/r7q1BB8I2s0BRqC"
+
+var _ = Describe("HTPasswd", func() {
+	Context("Parse", func() {
+		It("Reads bcrypt htpasswd lines and CSV lines", func() {
+			users, err := htpasswd.Parse(strings.NewReader(`# Team users
+alice:` + hash + `
+
+username,password
+bob,"Secret,Password1"
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal([]*ocm.HTPasswdUserSpec{
+				{Username: "alice", HashedPassword: hash},
+				{Username: "bob", Password: "Secret,Password1"},
+			}))
+		})
+
+		It("Rejects hashes that aren't bcrypt", func() {
+			_, err := htpasswd.Parse(strings.NewReader("alice:$apr1$xyz$0123456789abcdef\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 1: the password of user 'alice' isn't hashed " +
+				"with bcrypt")))
+		})
+
+		It("Rejects invalid passwords and usernames", func() {
+			_, err := htpasswd.Parse(strings.NewReader("alice,short\n"))
+			Expect(err).To(MatchError(ContainSubstring("invalid password of user 'alice'")))
+			_, err = htpasswd.Parse(strings.NewReader("team/alice,Secret-Password1\n"))
+			Expect(err).To(MatchError(ContainSubstring("must not contain /, :, or %")))
+		})
+
+		It("Rejects duplicated users and empty files", func() {
+			_, err := htpasswd.Parse(strings.NewReader("alice:" + hash + "\nalice,Secret-Password1\n"))
+			Expect(err).To(MatchError("line 2: duplicated user 'alice'"))
+			_, err = htpasswd.Parse(strings.NewReader("# nobody\n"))
+			Expect(err).To(MatchError("no users found"))
+		})
+	})
+
+	Context("Diff", func() {
+		desired := []*ocm.HTPasswdUserSpec{
+			{Username: "alice", HashedPassword: hash},
+			{Username: "bob", Password: "Secret-Password1"},
+		}
+
+		It("Adds, updates and removes users", func() {
+			changes := htpasswd.Diff([]string{"cluster-admin", "carol", "bob", "breakglass"}, desired,
+				func(username string) bool {
+					return username == "cluster-admin" || username == "breakglass"
+				})
+			Expect(changes.Add).To(Equal(desired[:1]))
+			Expect(changes.Update).To(Equal(desired[1:]))
+			Expect(changes.Remove).To(Equal([]string{"carol"}))
+			Expect(changes.Empty()).To(BeFalse())
+		})
+
+		It("Removes the users that are not kept and has nothing to do without users", func() {
+			Expect(htpasswd.Diff([]string{"cluster-admin"}, nil, nil).Remove).To(Equal([]string{"cluster-admin"}))
+			Expect(htpasswd.Diff(nil, nil, nil).Empty()).To(BeTrue())
+		})
+	})
+})
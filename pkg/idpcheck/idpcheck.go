@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the checks that verify that the configuration of an identity provider works, by
+// connecting to the servers that it uses.
+
+package idpcheck
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Result is the outcome of one of the checks of an identity provider
+type Result struct {
+	Check  string
+	Detail string
+	Err    error
+}
+
+// Failed returns true if any of the checks failed
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// TLSConfig returns the TLS settings that trust the given PEM encoded CA bundle, or the system CAs when it
+// is empty
+func TLSConfig(ca string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, errors.New("the CA bundle doesn't contain any PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// HTTPClient returns an HTTP client that trusts the given PEM encoded CA bundle, or the system CAs when it
+// is empty
+func HTTPClient(ca string, timeout time.Duration) (*http.Client, error) {
+	config, err := TLSConfig(ca)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: config,
+		},
+	}, nil
+}
+
+// getJSON fetches a JSON document
+func getJSON(client *http.Client, url string, body interface{}) error {
+	response, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' returned status %d", url, response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, 1024*1024))
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, body)
+	if err != nil {
+		return fmt.Errorf("'%s' didn't return valid JSON: %v", url, err)
+	}
+	return nil
+}
+
+// missing returns the wanted values that aren't in the list
+func missing(list []string, wanted []string) []string {
+	present := make(map[string]bool)
+	for _, value := range list {
+		present[value] = true
+	}
+	var result []string
+	for _, value := range wanted {
+		if !present[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// OpenIDConfig contains the settings of an OpenID identity provider that are checked
+type OpenIDConfig struct {
+	IssuerURL   string
+	Claims      []string
+	ExtraScopes []string
+}
+
+type openIDDiscovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	ClaimsSupported       []string `json:"claims_supported"`
+	ScopesSupported       []string `json:"scopes_supported"`
+}
+
+// VerifyOpenID fetches the discovery document and the signing keys of an OpenID provider, and checks that
+// the provider supports the claims and scopes of the identity provider. Providers that don't advertise the
+// claims or scopes they support aren't checked for them.
+func VerifyOpenID(client *http.Client, config *OpenIDConfig) []Result {
+	var results []Result
+	discoveryURL := strings.TrimSuffix(config.IssuerURL, "/") + "/.well-known/openid-configuration"
+	var discovery openIDDiscovery
+	err := getJSON(client, discoveryURL, &discovery)
+	if err == nil && discovery.Issuer != config.IssuerURL {
+		err = fmt.Errorf("the discovery document has issuer '%s' instead of '%s'", discovery.Issuer,
+			config.IssuerURL)
+	}
+	if err == nil && (discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "") {
+		err = errors.New("the discovery document doesn't contain the authorization and token endpoints")
+	}
+	results = append(results, Result{Check: "Discovery document", Detail: discoveryURL, Err: err})
+	if err != nil {
+		return results
+	}
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if discovery.JWKSURI == "" {
+		err = errors.New("the discovery document doesn't contain 'jwks_uri'")
+	} else {
+		err = getJSON(client, discovery.JWKSURI, &jwks)
+		if err == nil && len(jwks.Keys) == 0 {
+			err = fmt.Errorf("'%s' doesn't contain any key", discovery.JWKSURI)
+		}
+	}
+	results = append(results, Result{Check: "Signing keys", Detail: discovery.JWKSURI, Err: err})
+
+	if len(discovery.ClaimsSupported) > 0 {
+		err = nil
+		if unsupported := missing(discovery.ClaimsSupported, config.Claims); len(unsupported) > 0 {
+			err = fmt.Errorf("the provider doesn't advertise claims %s", strings.Join(unsupported, ", "))
+		}
+		results = append(results, Result{Check: "Claims", Detail: strings.Join(config.Claims, ", "), Err: err})
+	}
+	if len(discovery.ScopesSupported) > 0 {
+		scopes := append([]string{"openid"}, config.ExtraScopes...)
+		err = nil
+		if unsupported := missing(discovery.ScopesSupported, scopes); len(unsupported) > 0 {
+			err = fmt.Errorf("the provider doesn't advertise scopes %s", strings.Join(unsupported, ", "))
+		}
+		results = append(results, Result{Check: "Scopes", Detail: strings.Join(scopes, ", "), Err: err})
+	}
+	return results
+}
+
+// VerifyHost checks that the server of a hosted GitLab or GitHub Enterprise instance can be reached, and
+// that its certificate is trusted
+func VerifyHost(client *http.Client, hostURL string) []Result {
+	response, err := client.Get(hostURL)
+	if err == nil {
+		response.Body.Close()
+		if response.StatusCode >= 500 {
+			err = fmt.Errorf("'%s' returned status %d", hostURL, response.StatusCode)
+		}
+	}
+	return []Result{{Check: "Connection", Detail: hostURL, Err: err}}
+}
+
+// LDAPConfig contains the settings of an LDAP identity provider that are checked
+type LDAPConfig struct {
+	URL          string
+	Insecure     bool
+	CA           string
+	BindDN       string
+	BindPassword string
+	// TestUser is the login of a user that is searched like OpenShift does when the user logs in
+	TestUser string
+}
+
+// VerifyLDAP connects to an LDAP server, binds with the configured DN and password and searches for the
+// test user. Without test user, it checks that the base DN of the search exists.
+func VerifyLDAP(config *LDAPConfig, timeout time.Duration) []Result {
+	var results []Result
+	u, err := parseLDAPURL(config.URL)
+	if err == nil {
+		_, err = ldap.CompileFilter(u.filter)
+	}
+	results = append(results, Result{Check: "URL", Detail: config.URL, Err: err})
+	if err != nil {
+		return results
+	}
+
+	tlsConfig, err := TLSConfig(config.CA)
+	var conn *ldap.Conn
+	if err == nil {
+		tlsConfig.ServerName, _, err = net.SplitHostPort(u.host)
+	}
+	if err == nil {
+		conn, err = dialLDAP(u, config.Insecure, tlsConfig, timeout)
+	}
+	results = append(results, Result{Check: "Connection", Detail: u.host, Err: err})
+	if err != nil {
+		return results
+	}
+	defer conn.Close()
+
+	detail := config.BindDN
+	if detail == "" {
+		detail = "anonymous"
+	}
+	err = bindLDAP(conn, config.BindDN, config.BindPassword)
+	results = append(results, Result{Check: "Bind", Detail: detail, Err: err})
+	if err != nil {
+		return results
+	}
+
+	if config.TestUser == "" {
+		_, err = searchLDAP(conn, u.baseDN, "base", "(objectClass=*)")
+		results = append(results, Result{Check: "Base DN", Detail: u.baseDN, Err: err})
+		return results
+	}
+	filter := fmt.Sprintf("(&%s(%s=%s))", u.filter, u.attribute, ldap.EscapeFilter(config.TestUser))
+	dns, err := searchLDAP(conn, u.baseDN, u.scope, filter, u.attribute)
+	switch {
+	case err != nil:
+	case len(dns) == 0:
+		err = fmt.Errorf("no entry under '%s' matches '%s'", u.baseDN, filter)
+	case len(dns) > 1:
+		err = fmt.Errorf("several entries match '%s', logins must match exactly one", filter)
+	default:
+		detail = dns[0]
+	}
+	if err != nil {
+		detail = config.TestUser
+	}
+	results = append(results, Result{Check: "Search", Detail: detail, Err: err})
+	return results
+}
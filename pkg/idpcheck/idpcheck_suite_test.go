@@ -0,0 +1,13 @@
+package idpcheck
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIdpcheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "IDP Check Suite")
+}
@@ -0,0 +1,111 @@
+package idpcheck
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func certificatePEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+var _ = Describe("OpenID", func() {
+	var server *httptest.Server
+	var client *http.Client
+	var issuer string
+	var discovery map[string]interface{}
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(discovery)
+		})
+		mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"keys": [{"kty": "RSA", "kid": "key"}]}`))
+		})
+		server = httptest.NewTLSServer(mux)
+		issuer = server.URL
+		discovery = map[string]interface{}{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/authorize",
+			"token_endpoint":         issuer + "/token",
+			"jwks_uri":               issuer + "/keys",
+			"claims_supported":       []string{"sub", "email", "name", "preferred_username"},
+			"scopes_supported":       []string{"openid", "email", "profile"},
+		}
+		var err error
+		client, err = HTTPClient(certificatePEM(server), 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Checks the discovery document, keys, claims and scopes", func() {
+		results := VerifyOpenID(client, &OpenIDConfig{
+			IssuerURL:   issuer,
+			Claims:      []string{"email", "preferred_username"},
+			ExtraScopes: []string{"email"},
+		})
+		Expect(results).To(HaveLen(4))
+		Expect(Failed(results)).To(BeFalse(), "%v", results)
+	})
+
+	It("Reports claims and scopes that aren't advertised", func() {
+		results := VerifyOpenID(client, &OpenIDConfig{
+			IssuerURL:   issuer,
+			Claims:      []string{"email", "groups"},
+			ExtraScopes: []string{"groups"},
+		})
+		Expect(results[2].Err).To(MatchError("the provider doesn't advertise claims groups"))
+		Expect(results[3].Err).To(MatchError("the provider doesn't advertise scopes groups"))
+	})
+
+	It("Reports an issuer that doesn't match", func() {
+		discovery["issuer"] = "https://other.example.com"
+		results := VerifyOpenID(client, &OpenIDConfig{IssuerURL: issuer})
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Err).To(MatchError(ContainSubstring("has issuer 'https://other.example.com'")))
+	})
+
+	It("Reports providers without keys", func() {
+		discovery["jwks_uri"] = issuer + "/missing"
+		results := VerifyOpenID(client, &OpenIDConfig{IssuerURL: issuer})
+		Expect(results[1].Check).To(Equal("Signing keys"))
+		Expect(results[1].Err).To(MatchError(ContainSubstring("returned status 404")))
+	})
+
+	It("Reports untrusted certificates", func() {
+		untrusted, err := HTTPClient("", 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		results := VerifyOpenID(untrusted, &OpenIDConfig{IssuerURL: issuer})
+		Expect(results[0].Err).To(MatchError(ContainSubstring("certificate")))
+	})
+})
+
+var _ = Describe("Host", func() {
+	It("Reaches the host with the CA", func() {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		defer server.Close()
+
+		client, err := HTTPClient(certificatePEM(server), 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(Failed(VerifyHost(client, server.URL))).To(BeFalse())
+
+		client, err = HTTPClient("", 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(Failed(VerifyHost(client, server.URL))).To(BeTrue())
+	})
+
+	It("Rejects CA bundles without certificates", func() {
+		_, err := HTTPClient("not a certificate", time.Second)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the LDAP connection used to check the LDAP identity provider of OpenShift.
+
+package idpcheck
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var searchScopes = map[string]int{
+	"base": ldap.ScopeBaseObject,
+	"one":  ldap.ScopeSingleLevel,
+	"sub":  ldap.ScopeWholeSubtree,
+}
+
+// ldapURL is an RFC 2255 URL, as used by the LDAP identity provider: ldap://host:port/basedn?attribute?scope?filter
+type ldapURL struct {
+	scheme    string
+	host      string
+	baseDN    string
+	attribute string
+	scope     string
+	filter    string
+}
+
+func parseLDAPURL(value string) (*ldapURL, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP URL: %v", err)
+	}
+	result := &ldapURL{
+		scheme:    parsed.Scheme,
+		host:      parsed.Host,
+		attribute: "uid",
+		scope:     "sub",
+		filter:    "(objectClass=*)",
+	}
+	switch parsed.Scheme {
+	case "ldap":
+		if parsed.Port() == "" {
+			result.host = net.JoinHostPort(parsed.Hostname(), "389")
+		}
+	case "ldaps":
+		if parsed.Port() == "" {
+			result.host = net.JoinHostPort(parsed.Hostname(), "636")
+		}
+	default:
+		return nil, errors.New("expected LDAP URL to have an ldap:// or ldaps:// scheme")
+	}
+	result.baseDN = strings.TrimPrefix(parsed.Path, "/")
+	query := strings.Split(parsed.RawQuery, "?")
+	for i, part := range query {
+		part, err = url.QueryUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LDAP URL: %v", err)
+		}
+		if part == "" {
+			continue
+		}
+		switch i {
+		case 0:
+			result.attribute = strings.Split(part, ",")[0]
+		case 1:
+			result.scope = part
+		case 2:
+			result.filter = part
+		}
+	}
+	if _, ok := searchScopes[result.scope]; !ok {
+		return nil, fmt.Errorf("invalid LDAP search scope '%s'", result.scope)
+	}
+	if !strings.HasPrefix(result.filter, "(") {
+		result.filter = "(" + result.filter + ")"
+	}
+	return result, nil
+}
+
+// dialLDAP connects to an LDAP server. Secure connections use TLS for ldaps:// URLs and StartTLS for
+// ldap:// URLs, like OpenShift does.
+func dialLDAP(u *ldapURL, insecure bool, config *tls.Config, timeout time.Duration) (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(fmt.Sprintf("%s://%s", u.scheme, u.host),
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(config),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+	if u.scheme == "ldap" && !insecure {
+		err = conn.StartTLS(config)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("StartTLS failed: %v", err)
+		}
+	}
+	return conn, nil
+}
+
+// bindLDAP authenticates with a simple bind. An empty DN and password bind anonymously.
+func bindLDAP(conn *ldap.Conn, dn string, password string) error {
+	_, err := conn.SimpleBind(&ldap.SimpleBindRequest{
+		Username:           dn,
+		Password:           password,
+		AllowEmptyPassword: true,
+	})
+	return err
+}
+
+// searchLDAP returns the DNs of the entries that match a filter
+func searchLDAP(conn *ldap.Conn, baseDN string, scope string, filter string, attributes ...string) ([]string,
+	error) {
+	// The size limit is only there to detect several matches
+	result, err := conn.Search(ldap.NewSearchRequest(baseDN, searchScopes[scope], ldap.NeverDerefAliases, 2, 10,
+		false, filter, attributes, nil))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, err
+	}
+	var dns []string
+	for _, entry := range result.Entries {
+		dns = append(dns, entry.DN)
+	}
+	return dns, nil
+}
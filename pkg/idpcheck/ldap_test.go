@@ -0,0 +1,250 @@
+package idpcheck
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// ldapServer is a stand-in for an LDAP server, that accepts one bind DN and contains entries whose DN is
+// 'uid=<name>,<base DN>'
+type ldapServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	bindDN    string
+	password  string
+	baseDN    string
+	users     []string
+}
+
+func (s *ldapServer) start() string {
+	var err error
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+	go func() {
+		for {
+			conn, err := s.listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *ldapServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(id *ber.Packet, op *ber.Packet) {
+		message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		message.AppendChild(id)
+		message.AppendChild(op)
+		conn.Write(message.Bytes())
+	}
+	ldapResult := func(tag ber.Tag, code int64) *ber.Packet {
+		op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+		op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		return op
+	}
+	for {
+		message, err := ber.ReadPacket(reader)
+		if err != nil || len(message.Children) < 2 {
+			return
+		}
+		id, op := message.Children[0], message.Children[1]
+		if op.ClassType != ber.ClassApplication {
+			return
+		}
+		switch op.Tag {
+		case ldap.ApplicationExtendedRequest:
+			if s.tlsConfig == nil {
+				reply(id, ldapResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError))
+				continue
+			}
+			reply(id, ldapResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+		case ldap.ApplicationBindRequest:
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			if op.Children[1].Data.String() == s.bindDN && op.Children[2].Data.String() == s.password {
+				code = ldap.LDAPResultSuccess
+			}
+			reply(id, ldapResult(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			if !strings.HasSuffix(op.Children[0].Data.String(), s.baseDN) {
+				reply(id, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject))
+				continue
+			}
+			for _, user := range s.users {
+				if matches(op.Children[6], user) {
+					entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed,
+						ldap.ApplicationSearchResultEntry, nil, "")
+					entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString,
+						"uid="+user+","+s.baseDN, ""))
+					entry.AppendChild(ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, ""))
+					reply(id, entry)
+				}
+			}
+			reply(id, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+// matches evaluates the filters that the checks send, for entries that only have an uid
+func matches(filter *ber.Packet, uid string) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(child, uid) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterEqualityMatch:
+		return filter.Children[0].Data.String() != "uid" || filter.Children[1].Data.String() == uid
+	case ldap.FilterPresent:
+		return true
+	}
+	return false
+}
+
+func (s *ldapServer) close() {
+	if s.listener != nil {
+		s.listener.Close()
+	}
+}
+
+var _ = Describe("LDAP", func() {
+	var server *ldapServer
+	var serverURL string
+
+	BeforeEach(func() {
+		server = &ldapServer{
+			bindDN:   "cn=admin,dc=example,dc=com",
+			password: "secret",
+			baseDN:   "ou=users,dc=example,dc=com",
+			users:    []string{"alice", "bob"},
+		}
+	})
+
+	AfterEach(func() {
+		server.close()
+	})
+
+	start := func() {
+		serverURL = server.start()
+	}
+
+	It("Parses the URL of the identity provider", func() {
+		u, err := parseLDAPURL("ldap://ldap.example.com/ou=users,dc=example,dc=com?mail?one?(objectClass=person)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*u).To(Equal(ldapURL{
+			scheme:    "ldap",
+			host:      "ldap.example.com:389",
+			baseDN:    "ou=users,dc=example,dc=com",
+			attribute: "mail",
+			scope:     "one",
+			filter:    "(objectClass=person)",
+		}))
+
+		u, err = parseLDAPURL("ldaps://ldap.example.com/dc=example,dc=com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.host).To(Equal("ldap.example.com:636"))
+		Expect(u.attribute).To(Equal("uid"))
+		Expect(u.scope).To(Equal("sub"))
+
+		_, err = parseLDAPURL("ldap://ldap.example.com/dc=example?uid?tree")
+		Expect(err).To(MatchError("invalid LDAP search scope 'tree'"))
+
+		results := VerifyLDAP(&LDAPConfig{URL: "ldap://ldap.example.com/dc=example?uid?sub?(uid=alice"},
+			5*time.Second)
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Check).To(Equal("URL"))
+		Expect(results[0].Err).To(HaveOccurred())
+	})
+
+	It("Binds and finds the test user", func() {
+		start()
+		results := VerifyLDAP(&LDAPConfig{
+			URL:          serverURL + "/ou=users,dc=example,dc=com?uid",
+			Insecure:     true,
+			BindDN:       "cn=admin,dc=example,dc=com",
+			BindPassword: "secret",
+			TestUser:     "alice",
+		}, 5*time.Second)
+		Expect(Failed(results)).To(BeFalse(), "%v", results)
+		Expect(results[len(results)-1]).To(Equal(Result{Check: "Search",
+			Detail: "uid=alice,ou=users,dc=example,dc=com"}))
+	})
+
+	It("Reports invalid credentials", func() {
+		start()
+		results := VerifyLDAP(&LDAPConfig{
+			URL:          serverURL + "/ou=users,dc=example,dc=com",
+			Insecure:     true,
+			BindDN:       "cn=admin,dc=example,dc=com",
+			BindPassword: "wrong",
+		}, 5*time.Second)
+		Expect(results[len(results)-1].Check).To(Equal("Bind"))
+		Expect(ldap.IsErrorWithCode(results[len(results)-1].Err, ldap.LDAPResultInvalidCredentials)).To(BeTrue())
+	})
+
+	It("Reports missing users and base DNs", func() {
+		start()
+		config := &LDAPConfig{
+			URL:          serverURL + "/ou=users,dc=example,dc=com",
+			Insecure:     true,
+			BindDN:       "cn=admin,dc=example,dc=com",
+			BindPassword: "secret",
+			TestUser:     "carol",
+		}
+		results := VerifyLDAP(config, 5*time.Second)
+		Expect(results[len(results)-1].Err).To(MatchError(ContainSubstring("no entry under")))
+
+		config.URL = serverURL + "/ou=groups,dc=example,dc=com"
+		config.TestUser = ""
+		results = VerifyLDAP(config, 5*time.Second)
+		Expect(results[len(results)-1].Check).To(Equal("Base DN"))
+		Expect(ldap.IsErrorWithCode(results[len(results)-1].Err, ldap.LDAPResultNoSuchObject)).To(BeTrue())
+	})
+
+	It("Uses StartTLS with the CA of the identity provider", func() {
+		// Borrow the certificate of an HTTPS test server, which is valid for 127.0.0.1
+		https := httptest.NewTLSServer(nil)
+		defer https.Close()
+		server.tlsConfig = https.TLS
+		start()
+
+		config := &LDAPConfig{
+			URL:          serverURL + "/ou=users,dc=example,dc=com",
+			CA:           certificatePEM(https),
+			BindDN:       "cn=admin,dc=example,dc=com",
+			BindPassword: "secret",
+			TestUser:     "bob",
+		}
+		results := VerifyLDAP(config, 5*time.Second)
+		Expect(Failed(results)).To(BeFalse(), "%v", results)
+
+		config.CA = ""
+		results = VerifyLDAP(config, 5*time.Second)
+		Expect(results[len(results)-1].Check).To(Equal("Connection"))
+		Expect(results[len(results)-1].Err).To(MatchError(ContainSubstring("StartTLS failed")))
+	})
+})
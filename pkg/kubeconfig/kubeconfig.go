@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to add the credentials of clusters to kubeconfig files. Files are
+// handled as generic documents, so that the fields that aren't known here are preserved.
+
+package kubeconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+)
+
+// Entry contains the cluster, user and context that give access to a cluster, all with the same name
+type Entry struct {
+	Name   string
+	Server string
+	Token  string
+}
+
+// Path returns the kubeconfig file that is written to, which is the first file of the KUBECONFIG
+// environment variable, or ~/.kube/config when it isn't set
+func Path() (string, error) {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)[0], nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// Merge adds the entry to the kubeconfig file, replacing the cluster, user and context with the same name,
+// and makes it the current context. The file is created if it doesn't exist.
+func Merge(path string, entry *Entry) error {
+	config := map[string]interface{}{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) > 0 {
+		err = yaml.Unmarshal(data, &config)
+		if err != nil {
+			return fmt.Errorf("Failed to parse kubeconfig file '%s': %v", path, err)
+		}
+	}
+	if config["apiVersion"] == nil {
+		config["apiVersion"] = "v1"
+	}
+	if config["kind"] == nil {
+		config["kind"] = "Config"
+	}
+
+	config["clusters"] = setNamed(config["clusters"], entry.Name, "cluster", map[string]interface{}{
+		"server": entry.Server,
+	})
+	config["users"] = setNamed(config["users"], entry.Name, "user", map[string]interface{}{
+		"token": entry.Token,
+	})
+	config["contexts"] = setNamed(config["contexts"], entry.Name, "context", map[string]interface{}{
+		"cluster": entry.Name,
+		"user":    entry.Name,
+	})
+	config["current-context"] = entry.Name
+
+	data, err = yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// setNamed replaces or appends the item with the given name to a list of named items, like the clusters
+// of a kubeconfig file
+func setNamed(list interface{}, name string, key string, value map[string]interface{}) []interface{} {
+	items, _ := list.([]interface{})
+	item := map[string]interface{}{
+		"name": name,
+		key:    value,
+	}
+	for i, existing := range items {
+		if existingMap, ok := existing.(map[string]interface{}); ok && existingMap["name"] == name {
+			items[i] = item
+			return items
+		}
+	}
+	return append(items, item)
+}
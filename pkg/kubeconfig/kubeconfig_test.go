@@ -0,0 +1,81 @@
+package kubeconfig_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/kubeconfig"
+)
+
+var _ = Describe("Kubeconfig", func() {
+	It("Merges a context into an existing file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config")
+		Expect(os.WriteFile(path, []byte(`apiVersion: v1
+kind: Config
+current-context: other
+clusters:
+- name: other
+  cluster:
+    server: https://other:6443
+    certificate-authority-data: ZGF0YQ==
+- name: mycluster
+  cluster:
+    server: https://old:6443
+users:
+- name: other
+  user:
+    token: other-token
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+`), 0600)).To(Succeed())
+
+		err := kubeconfig.Merge(path, &kubeconfig.Entry{
+			Name:   "mycluster",
+			Server: "https://api.mycluster:6443",
+			Token:  "sha256~token",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		config := map[string]interface{}{}
+		Expect(yaml.Unmarshal(data, &config)).To(Succeed())
+		Expect(config["current-context"]).To(Equal("mycluster"))
+		Expect(config["clusters"]).To(ConsistOf(
+			map[string]interface{}{
+				"name": "other",
+				"cluster": map[string]interface{}{
+					"server":                     "https://other:6443",
+					"certificate-authority-data": "ZGF0YQ==",
+				},
+			},
+			map[string]interface{}{
+				"name":    "mycluster",
+				"cluster": map[string]interface{}{"server": "https://api.mycluster:6443"},
+			},
+		))
+		Expect(config["users"]).To(ContainElement(map[string]interface{}{
+			"name": "mycluster",
+			"user": map[string]interface{}{"token": "sha256~token"},
+		}))
+		Expect(config["contexts"]).To(HaveLen(2))
+	})
+
+	It("Creates the file when it doesn't exist", func() {
+		path := filepath.Join(GinkgoT().TempDir(), ".kube", "config")
+		err := kubeconfig.Merge(path, &kubeconfig.Entry{
+			Name:   "mycluster",
+			Server: "https://api.mycluster:6443",
+			Token:  "sha256~token",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,235 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to obtain access tokens from the OAuth server of a cluster.
+
+package kubeconfig
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// ChallengingClient is the OAuth client that obtains tokens with the credentials of users of
+	// identity providers that support challenges, like htpasswd
+	ChallengingClient = "openshift-challenging-client"
+
+	// CLIClient is the OAuth client that obtains tokens with the authorization code flow, for any
+	// identity provider
+	CLIClient = "openshift-cli-client"
+)
+
+// OAuthMetadata contains the endpoints of the OAuth server of a cluster
+type OAuthMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// Discover returns the endpoints of the OAuth server of the cluster with the given API URL
+func Discover(client *http.Client, apiURL string) (*OAuthMetadata, error) {
+	response, err := client.Get(strings.TrimSuffix(apiURL, "/") + "/.well-known/oauth-authorization-server")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to discover the OAuth server: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to discover the OAuth server: %s", response.Status)
+	}
+	metadata := &OAuthMetadata{}
+	err = json.NewDecoder(response.Body).Decode(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the OAuth server metadata: %v", err)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" {
+		return nil, fmt.Errorf("The OAuth server metadata doesn't contain its endpoints")
+	}
+	return metadata, nil
+}
+
+// RequestTokenWithPassword obtains a token for a user of an identity provider that supports challenges
+func RequestTokenWithPassword(client *http.Client, metadata *OAuthMetadata, idp string, username string,
+	password string) (string, error) {
+	query := url.Values{
+		"client_id":     {ChallengingClient},
+		"response_type": {"token"},
+	}
+	if idp != "" {
+		query.Set("idp", idp)
+	}
+	request, err := http.NewRequest(http.MethodGet, metadata.AuthorizationEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	request.SetBasicAuth(username, password)
+	// The OAuth server rejects challenges without this header to protect against CSRF
+	request.Header.Set("X-CSRF-Token", "1")
+
+	// The token is in the fragment of the redirect, which must not be followed
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	response, err := noRedirect.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("Failed to request token: %v", err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusFound, http.StatusSeeOther:
+	case http.StatusUnauthorized:
+		return "", fmt.Errorf("Invalid credentials for user '%s'", username)
+	default:
+		return "", fmt.Errorf("Failed to request token: %s", response.Status)
+	}
+
+	location, err := response.Location()
+	if err != nil {
+		return "", fmt.Errorf("Failed to request token: %v", err)
+	}
+	fragment, err := url.ParseQuery(location.Fragment)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read token: %v", err)
+	}
+	if fragment.Get("error") != "" {
+		return "", fmt.Errorf("Failed to request token: %s", fragment.Get("error_description"))
+	}
+	token := fragment.Get("access_token")
+	if token == "" {
+		return "", fmt.Errorf("The OAuth server didn't return a token")
+	}
+	return token, nil
+}
+
+// RequestTokenWithBrowser obtains a token with the authorization code flow, for any identity provider. The
+// given function opens the authorization URL in a browser, and the browser is redirected to a local listener
+// that receives the authorization code.
+func RequestTokenWithBrowser(client *http.Client, metadata *OAuthMetadata, idp string,
+	open func(string) error, timeout time.Duration) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("Failed to listen for the authorization code: %v", err)
+	}
+	defer listener.Close()
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+	query := url.Values{
+		"client_id":             {CLIClient},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if idp != "" {
+		query.Set("idp", idp)
+	}
+
+	codes := make(chan string, 1)
+	failures := make(chan error, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			values := r.URL.Query()
+			switch {
+			case values.Get("state") != state:
+				http.Error(w, "Invalid state", http.StatusBadRequest)
+				return
+			case values.Get("error") != "":
+				fmt.Fprintf(w, "Login failed, you can close this window.")
+				failures <- fmt.Errorf("Login failed: %s", values.Get("error_description"))
+			default:
+				fmt.Fprintf(w, "Login succeeded, you can close this window.")
+				codes <- values.Get("code")
+			}
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	err = open(metadata.AuthorizationEndpoint + "?" + query.Encode())
+	if err != nil {
+		return "", err
+	}
+
+	var code string
+	select {
+	case code = <-codes:
+	case err = <-failures:
+		return "", err
+	case <-time.After(timeout):
+		return "", fmt.Errorf("Timed out waiting for the login to complete")
+	}
+
+	response, err := client.PostForm(metadata.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {CLIClient},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to exchange the authorization code: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(response.Body)
+		return "", fmt.Errorf("Failed to exchange the authorization code: %s: %s", response.Status, body)
+	}
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&result)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read token: %v", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("The OAuth server didn't return a token")
+	}
+	return result.AccessToken, nil
+}
+
+func randomString() (string, error) {
+	data := make([]byte, 32)
+	_, err := rand.Read(data)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
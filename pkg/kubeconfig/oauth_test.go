@@ -0,0 +1,112 @@
+package kubeconfig_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/kubeconfig"
+)
+
+// newOAuthServer starts a stand-in for the OAuth server of a cluster, that accepts the given user and
+// issues the given token
+func newOAuthServer(username string, password string, token string) *httptest.Server {
+	var challenge string
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/oauth/authorize",
+			"token_endpoint":         server.URL + "/oauth/token",
+		})
+	})
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch query.Get("client_id") {
+		case kubeconfig.ChallengingClient:
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != username || pass != password || r.Header.Get("X-CSRF-Token") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.Redirect(w, r, server.URL+"/oauth/token/implicit#access_token="+token+"&token_type=Bearer",
+				http.StatusFound)
+		case kubeconfig.CLIClient:
+			challenge = query.Get("code_challenge")
+			redirect := query.Get("redirect_uri") + "?" + url.Values{
+				"code":  {"code"},
+				"state": {query.Get("state")},
+			}.Encode()
+			http.Redirect(w, r, redirect, http.StatusFound)
+		}
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != "code" ||
+			base64.RawURLEncoding.EncodeToString(verifier[:]) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": token,
+			"token_type":   "Bearer",
+		})
+	})
+	server = httptest.NewServer(mux)
+	return server
+}
+
+var _ = Describe("OAuth", func() {
+	var server *httptest.Server
+	var metadata *kubeconfig.OAuthMetadata
+
+	BeforeEach(func() {
+		server = newOAuthServer("cluster-admin", "secret", "sha256~token")
+		var err error
+		metadata, err = kubeconfig.Discover(server.Client(), server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metadata.TokenEndpoint).To(Equal(server.URL + "/oauth/token"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Requests a token with a password", func() {
+		token, err := kubeconfig.RequestTokenWithPassword(server.Client(), metadata, "htpasswd",
+			"cluster-admin", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token).To(Equal("sha256~token"))
+	})
+
+	It("Rejects invalid passwords", func() {
+		_, err := kubeconfig.RequestTokenWithPassword(server.Client(), metadata, "htpasswd",
+			"cluster-admin", "wrong")
+		Expect(err).To(MatchError(ContainSubstring("Invalid credentials")))
+	})
+
+	It("Requests a token with the browser", func() {
+		// The browser follows the redirects of the OAuth server to the local listener
+		browse := func(url string) error {
+			go func() {
+				defer GinkgoRecover()
+				response, err := http.Get(url)
+				Expect(err).ToNot(HaveOccurred())
+				response.Body.Close()
+			}()
+			return nil
+		}
+		token, err := kubeconfig.RequestTokenWithBrowser(server.Client(), metadata, "github", browse,
+			10*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token).To(Equal("sha256~token"))
+	})
+})
@@ -22,11 +22,13 @@ package logging
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -324,16 +326,110 @@ func (d *RoundTripper) dumpBytes(what string, data []byte) {
 
 // redactSensitive replaces sensitive fields within a response with redactionStr.
 func (d *RoundTripper) redactSensitive(body *ordered.OrderedMap) {
-	iterator := body.EntriesIter()
-	for {
-		pair, ok := iterator()
-		if !ok {
-			break
+	redact(body, d.redact)
+}
+
+// redact replaces the values of the given fields with redactedReplacement, also in nested objects and
+// arrays.
+func redact(value interface{}, fields map[string]bool) {
+	switch typed := value.(type) {
+	case *ordered.OrderedMap:
+		iterator := typed.EntriesIter()
+		for {
+			pair, ok := iterator()
+			if !ok {
+				break
+			}
+			if fields[pair.Key] {
+				typed.Set(pair.Key, redactedReplacement)
+			} else {
+				redact(pair.Value, fields)
+			}
+		}
+	case []interface{}:
+		for _, item := range typed {
+			redact(item, fields)
+		}
+	}
+}
+
+// SensitiveFields are the names of the fields of the OCM and AWS APIs whose values are secrets.
+var SensitiveFields = map[string]bool{
+	"access_key_id":     true,
+	"access_token":      true,
+	"bind_password":     true,
+	"client_secret":     true,
+	"id_token":          true,
+	"password":          true,
+	"refresh_token":     true,
+	"secret_access_key": true,
+	"token":             true,
+}
+
+// RedactJSON returns the given JSON document indented, with the values of the given fields replaced, also
+// in nested objects and arrays.
+func RedactJSON(data []byte, fields map[string]bool) ([]byte, error) {
+	parsed, err := decodeOrdered(data)
+	if err != nil {
+		return nil, err
+	}
+	redact(parsed, fields)
+	return json.MarshalIndent(parsed, "", "  ")
+}
+
+// decodeOrdered decodes a JSON value keeping the order of the fields of the objects, which are only
+// decoded by ordered.OrderedMap when they aren't inside arrays.
+func decodeOrdered(data []byte) (interface{}, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("empty JSON document")
+	}
+	switch trimmed[0] {
+	case '{':
+		object := ordered.NewOrderedMap()
+		err := json.Unmarshal(trimmed, object)
+		if err != nil {
+			return nil, err
 		}
-		if d.redact[pair.Key] {
-			body.Set(pair.Key, redactedReplacement)
+		return object, nil
+	case '[':
+		var items []json.RawMessage
+		err := json.Unmarshal(trimmed, &items)
+		if err != nil {
+			return nil, err
 		}
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			list[i], err = decodeOrdered(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	}
+	var value interface{}
+	err := json.Unmarshal(trimmed, &value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// RedactLines returns the given text with the values of the given fields replaced in every line. It is
+// meant for logs, where the fields appear as 'name: value' or 'name=value', quoted or not, and with the
+// words of the name in snake, kebab or camel case.
+func RedactLines(data []byte, fields map[string]bool) []byte {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, strings.ReplaceAll(regexp.QuoteMeta(field), "_", "[_-]?"))
 	}
+	// Longer names go first so that 'bind_password' is matched before 'password':
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+	expression := regexp.MustCompile(`(?i)(\b(?:` + strings.Join(names, "|") + `)["']?\s*[:=]\s*)` +
+		`("[^"]*"|'[^']*'|[^\s,;]+)`)
+	return expression.ReplaceAll(data, []byte("${1}"+redactedReplacement))
 }
 
 // String that replaces redactedReplacement fields in messages sent to the log:
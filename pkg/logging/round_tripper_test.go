@@ -0,0 +1,59 @@
+package logging_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/logging"
+)
+
+var _ = Describe("Redaction", func() {
+	It("Redacts nested fields of objects", func() {
+		redacted, err := logging.RedactJSON([]byte(`{
+			"id": "idp",
+			"github": {"client_id": "abc", "client_secret": "secret"}
+		}`), logging.SensitiveFields)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(redacted).To(MatchJSON(`{
+			"id": "idp",
+			"github": {"client_id": "abc", "client_secret": "***"}
+		}`))
+	})
+
+	It("Redacts fields of the items of lists", func() {
+		redacted, err := logging.RedactJSON([]byte(`[
+			{"id": "first", "ldap": {"bind_password": "secret"}},
+			{"id": "second", "htpasswd": {"users": {"items": [{"username": "admin", "password": "secret"}]}}}
+		]`), logging.SensitiveFields)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(redacted).To(MatchJSON(`[
+			{"id": "first", "ldap": {"bind_password": "***"}},
+			{"id": "second", "htpasswd": {"users": {"items": [{"username": "admin", "password": "***"}]}}}
+		]`))
+	})
+
+	It("Keeps the items of lists that aren't objects", func() {
+		redacted, err := logging.RedactJSON([]byte(`[
+			"first",
+			42,
+			[{"id": "nested", "token": "secret"}, null]
+		]`), logging.SensitiveFields)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(redacted).To(MatchJSON(`[
+			"first",
+			42,
+			[{"id": "nested", "token": "***"}, null]
+		]`))
+	})
+
+	It("Redacts fields of the lines of logs", func() {
+		redacted := logging.RedactLines([]byte("level=info msg=\"Creating user\" password=secret\n"+
+			"\"client_secret\": \"secret\", \"client_id\": \"abc\"\n"+
+			"bindPassword: 'secret'\n"+
+			"Access-Key-ID=AKIAEXAMPLE; region=us-east-1\n"), logging.SensitiveFields)
+		Expect(string(redacted)).To(Equal("level=info msg=\"Creating user\" password=***\n" +
+			"\"client_secret\": ***, \"client_id\": \"abc\"\n" +
+			"bindPassword: ***\n" +
+			"Access-Key-ID=***; region=us-east-1\n"))
+	})
+})
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the command line options shared by the commands that print install and uninstall logs.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// MaxBackups is the number of rotated output files that are kept
+const MaxBackups = 5
+
+var args struct {
+	since             string
+	grep              string
+	level             string
+	timestamps        bool
+	outputFile        string
+	outputFileMaxSize int
+}
+
+// AddFlags adds the flags that filter the lines of a log and select where they are written to
+func AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"Show only the lines newer than a relative duration like 30m or 2h.",
+	)
+	flags.StringVar(
+		&args.grep,
+		"grep",
+		"",
+		"Show only the lines that match the given regular expression.",
+	)
+	flags.StringVar(
+		&args.level,
+		"level",
+		"",
+		"Show only the lines with the given level or a more severe one, like 'warning' or 'error'.",
+	)
+	flags.BoolVar(
+		&args.timestamps,
+		"timestamps",
+		false,
+		"Prefix each line with its timestamp.",
+	)
+	flags.StringVar(
+		&args.outputFile,
+		"output-file",
+		"",
+		"Write the lines to the given file instead of the standard output.",
+	)
+	flags.IntVar(
+		&args.outputFileMaxSize,
+		"output-file-max-size",
+		10,
+		fmt.Sprintf("Maximum size in MiB of the output file before it's rotated. "+
+			"The last %d rotated files are kept.", MaxBackups),
+	)
+}
+
+// NewPrinterFromFlags returns a printer configured by the flags. It needs to be closed to close the
+// output file.
+func NewPrinterFromFlags() (*Printer, error) {
+	filter := &Filter{}
+	if args.since != "" {
+		duration, err := helper.ParseDuration(args.since)
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid duration for 'since': %v", err)
+		}
+		filter.Since = time.Now().Add(-duration)
+	}
+	if args.grep != "" {
+		pattern, err := regexp.Compile(args.grep)
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid regular expression for 'grep': %v", err)
+		}
+		filter.Pattern = pattern
+	}
+	if args.level != "" {
+		level, err := logrus.ParseLevel(args.level)
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid level for 'level': %v", err)
+		}
+		filter.Level = &level
+	}
+
+	printer := &Printer{
+		Writer:     os.Stdout,
+		Filter:     filter,
+		Timestamps: args.timestamps,
+	}
+	if args.outputFile != "" {
+		if args.outputFileMaxSize <= 0 {
+			return nil, fmt.Errorf("Expected a positive size for 'output-file-max-size'")
+		}
+		file, err := NewRotatingFile(args.outputFile, int64(args.outputFileMaxSize)*1024*1024, MaxBackups)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to open output file: %v", err)
+		}
+		printer.Writer = file
+	}
+	return printer, nil
+}
+
+// OutputFile returns the file that the lines are written to, or an empty string for the standard output
+func OutputFile() string {
+	return args.outputFile
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to print the install and uninstall logs of clusters. The logs
+// are fetched by line offset, so that following them only prints the lines that haven't been printed yet.
+
+package logs
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	timeRE  = regexp.MustCompile(`\btime="([^"]+)"`)
+	levelRE = regexp.MustCompile(`\blevel=(\w+)`)
+)
+
+// Filter selects the lines of a log. Lines without a time or a level are only selected when the filter
+// doesn't use them.
+type Filter struct {
+	Since   time.Time
+	Pattern *regexp.Regexp
+	Level   *logrus.Level
+}
+
+// Match returns true if the line is selected by the filter
+func (f *Filter) Match(line string) bool {
+	if f == nil {
+		return true
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(line) {
+		return false
+	}
+	if !f.Since.IsZero() {
+		lineTime, ok := parseTime(line)
+		if !ok || lineTime.Before(f.Since) {
+			return false
+		}
+	}
+	if f.Level != nil {
+		lineLevel, ok := parseLevel(line)
+		// Lower levels are more severe
+		if !ok || lineLevel > *f.Level {
+			return false
+		}
+	}
+	return true
+}
+
+// Printer writes the lines of a log that match its filter. It keeps the offset of the next line of the
+// log, which is the offset to fetch the log from when following it.
+type Printer struct {
+	Writer     io.Writer
+	Filter     *Filter
+	Timestamps bool
+	offset     int
+}
+
+// Offset returns the line offset of the next line of the log
+func (p *Printer) Offset() int {
+	return p.offset
+}
+
+// Print writes the complete lines of the given content, which starts at the offset of the printer. It
+// returns true if any line was written.
+func (p *Printer) Print(content string) (bool, error) {
+	return p.PrintLast(content, -1)
+}
+
+// PrintLast is like Print, but only writes the last n complete lines of the content. A negative n
+// writes all of them.
+func (p *Printer) PrintLast(content string, n int) (bool, error) {
+	lines := strings.Split(content, "\n")
+	// The last element is either empty or a line that hasn't been completely written yet, and that will be
+	// fetched again
+	lines = lines[:len(lines)-1]
+	p.offset += len(lines)
+	if n >= 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	printed := false
+	for _, line := range lines {
+		if !p.Filter.Match(line) {
+			continue
+		}
+		if p.Timestamps {
+			lineTime, ok := parseTime(line)
+			if !ok {
+				lineTime = time.Now()
+			}
+			line = fmt.Sprintf("%s %s", lineTime.UTC().Format(time.RFC3339), line)
+		}
+		_, err := fmt.Fprintln(p.Writer, line)
+		if err != nil {
+			return printed, err
+		}
+		printed = true
+	}
+	return printed, nil
+}
+
+// Close closes the writer of the printer when it's a file opened by the printer
+func (p *Printer) Close() error {
+	if file, ok := p.Writer.(*RotatingFile); ok {
+		return file.Close()
+	}
+	return nil
+}
+
+func parseTime(line string) (time.Time, bool) {
+	match := timeRE.FindStringSubmatch(line)
+	if match == nil {
+		return time.Time{}, false
+	}
+	lineTime, err := time.Parse(time.RFC3339Nano, match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return lineTime, true
+}
+
+func parseLevel(line string) (logrus.Level, bool) {
+	match := levelRE.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+	level, err := logrus.ParseLevel(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return level, true
+}
@@ -0,0 +1,99 @@
+package logs_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/logs"
+)
+
+const content = `time="2022-11-01T10:00:00Z" level=info msg="Creating infrastructure resources..."
+time="2022-11-01T10:05:00Z" level=warning msg="Retrying bootstrap"
+time="2022-11-01T10:10:00Z" level=error msg="Bootstrap failed"
+time="2022-11-01T10:11:00Z" level=info msg="Gathering`
+
+var _ = Describe("Printer", func() {
+	var output *bytes.Buffer
+	var printer *logs.Printer
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+		printer = &logs.Printer{Writer: output}
+	})
+
+	It("Prints only complete lines and tracks the offset", func() {
+		printed, err := printer.Print(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(printed).To(BeTrue())
+		Expect(printer.Offset()).To(Equal(3))
+		Expect(output.String()).ToNot(ContainSubstring("Gathering"))
+
+		printed, err = printer.Print("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(printed).To(BeFalse())
+		Expect(printer.Offset()).To(Equal(3))
+	})
+
+	It("Prints the last lines", func() {
+		_, err := printer.PrintLast(content, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(printer.Offset()).To(Equal(3))
+		Expect(output.String()).To(Equal(
+			"time=\"2022-11-01T10:10:00Z\" level=error msg=\"Bootstrap failed\"\n"))
+	})
+
+	It("Filters lines by time, level and pattern", func() {
+		level := logrus.WarnLevel
+		printer.Filter = &logs.Filter{
+			Since: time.Date(2022, 11, 1, 10, 1, 0, 0, time.UTC),
+			Level: &level,
+		}
+		_, err := printer.Print(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output.String()).To(ContainSubstring("Retrying bootstrap"))
+		Expect(output.String()).To(ContainSubstring("Bootstrap failed"))
+		Expect(output.String()).ToNot(ContainSubstring("Creating"))
+
+		output.Reset()
+		printer = &logs.Printer{
+			Writer: output,
+			Filter: &logs.Filter{Pattern: regexp.MustCompile("(?i)infrastructure")},
+		}
+		_, err = printer.Print(content)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output.String()).To(ContainSubstring("Creating infrastructure resources"))
+		Expect(output.String()).ToNot(ContainSubstring("Bootstrap"))
+	})
+
+	It("Prefixes lines with their timestamps", func() {
+		printer.Timestamps = true
+		_, err := printer.PrintLast(content, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output.String()).To(HavePrefix("2022-11-01T10:10:00Z time="))
+	})
+})
+
+var _ = Describe("Rotating file", func() {
+	It("Rotates the file when it's full", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "install.log")
+		file, err := logs.NewRotatingFile(path, 10, 2)
+		Expect(err).ToNot(HaveOccurred())
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err = file.Write([]byte(line))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(file.Close()).To(Succeed())
+
+		Expect(os.ReadFile(path)).To(Equal([]byte("fourth\n")))
+		Expect(os.ReadFile(path + ".1")).To(Equal([]byte("third\n")))
+		Expect(os.ReadFile(path + ".2")).To(Equal([]byte("second\n")))
+		Expect(path + ".3").ToNot(BeAnExistingFile())
+	})
+})
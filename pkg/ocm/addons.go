@@ -132,6 +132,20 @@ func (c *Client) GetAddOnInstallation(clusterID, addOnID string) (*cmv1.AddOnIns
 	return response.Body(), nil
 }
 
+func (c *Client) GetAddOnInstallations(clusterID string) ([]*cmv1.AddOnInstallation, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().
+		Cluster(clusterID).
+		Addons().
+		List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
 func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []AddOnParam) error {
 	addOnInstallationBuilder := cmv1.NewAddOnInstallation().
 		Addon(cmv1.NewAddOn().ID(addOnID))
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ClusterAdminsGroup is the group of the admin users created with 'rosa create admin'
+const ClusterAdminsGroup = "cluster-admins"
+
+// GetHTPasswdIDP returns the htpasswd identity provider of a cluster, or nil if it doesn't have one
+func (c *Client) GetHTPasswdIDP(clusterID string) (*cmv1.IdentityProvider, error) {
+	idps, err := c.GetIdentityProviders(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	var htpasswdIDP *cmv1.IdentityProvider
+	for _, item := range idps {
+		if IdentityProviderType(item) == HTPasswdIDPType {
+			htpasswdIDP = item
+		}
+	}
+	return htpasswdIDP, nil
+}
+
+// DeleteAdmin removes an admin user from the cluster-admins group and from the htpasswd identity provider.
+// The identity provider is deleted when it was created by older versions of rosa for the admin, or when
+// the admin was its last user.
+func (c *Client) DeleteAdmin(clusterID string, htpasswdIDP *cmv1.IdentityProvider, username string) error {
+	err := c.DeleteUser(clusterID, ClusterAdminsGroup, username)
+	if err != nil {
+		return fmt.Errorf("Failed to delete '%s' user from %s group: %v", username, ClusterAdminsGroup, err)
+	}
+
+	htpasswd, ok := htpasswdIDP.GetHtpasswd()
+	if !ok {
+		return fmt.Errorf("Identity provider '%s' isn't an htpasswd identity provider", htpasswdIDP.Name())
+	}
+	if htpasswd.Username() == username {
+		return c.DeleteIdentityProvider(clusterID, htpasswdIDP.ID())
+	}
+
+	err = c.DeleteHTPasswdUser(username, clusterID, htpasswdIDP)
+	if err != nil {
+		return fmt.Errorf("Failed to delete '%s' user from htpasswd idp users list: %v", username, err)
+	}
+	users, err := c.GetHTPasswdUserList(clusterID, htpasswdIDP.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to list htpasswd idp users: %v", err)
+	}
+	if users.Len() == 0 && htpasswd.Username() == "" {
+		return c.DeleteIdentityProvider(clusterID, htpasswdIDP.ID())
+	}
+	return nil
+}
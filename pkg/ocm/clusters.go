@@ -528,6 +528,50 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 	return nil
 }
 
+// UpdateClusterProperties replaces the properties of the cluster with the ones returned by update. OCM
+// replaces the whole map, so the properties are read right before saving them and update should only change
+// the keys that the caller owns, which keeps the ones changed by others in the meantime.
+func (c *Client) UpdateClusterProperties(clusterID string,
+	update func(properties map[string]string) map[string]string) error {
+	getResponse, err := c.ocm.ClustersMgmt().V1().Clusters().
+		Cluster(clusterID).
+		Get().
+		Send()
+	if err != nil {
+		return handleErr(getResponse.Error(), err)
+	}
+	clusterSpec, err := cmv1.NewCluster().
+		Properties(update(getResponse.Body().Properties())).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().
+		Cluster(clusterID).
+		Update().
+		Body(clusterSpec).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// ClearClusterExpiration removes the expiration timestamp of the cluster, so that it isn't deleted
+// automatically. The SDK can't send an empty timestamp, so the request is sent directly.
+func (c *Client) ClearClusterExpiration(clusterID string) error {
+	return c.sendRaw(c.ocm.Patch().
+		Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s", clusterID)).
+		Header("Content-Type", "application/json").
+		String(`{"expiration_timestamp": null}`), nil)
+}
+
+// IsExpiringWithin returns true if the cluster expires before the given duration has passed
+func IsExpiringWithin(cluster *cmv1.Cluster, duration time.Duration, now time.Time) bool {
+	expiration, ok := cluster.GetExpirationTimestamp()
+	return ok && !expiration.IsZero() && expiration.Before(now.Add(duration))
+}
+
 func (c *Client) DeleteCluster(clusterKey string, creator *aws.Creator) (*cmv1.Cluster, error) {
 	cluster, err := c.GetCluster(clusterKey, creator)
 	if err != nil {
@@ -0,0 +1,31 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Cluster expiration", func() {
+	now := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	buildCluster := func(builder *cmv1.ClusterBuilder) *cmv1.Cluster {
+		cluster, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	It("Doesn't consider clusters without expiration as expiring", func() {
+		cluster := buildCluster(cmv1.NewCluster())
+		Expect(IsExpiringWithin(cluster, 24*time.Hour, now)).To(BeFalse())
+	})
+
+	It("Considers clusters that expire within the duration as expiring", func() {
+		cluster := buildCluster(cmv1.NewCluster().ExpirationTimestamp(now.Add(12 * time.Hour)))
+		Expect(IsExpiringWithin(cluster, 24*time.Hour, now)).To(BeTrue())
+		Expect(IsExpiringWithin(cluster, 6*time.Hour, now)).To(BeFalse())
+	})
+})
@@ -36,6 +36,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
 
+	"github.com/openshift/rosa/pkg/cron"
 	"github.com/openshift/rosa/pkg/helper"
 )
 
@@ -107,6 +108,18 @@ func ValidateHTTPProxy(val interface{}) error {
 	return fmt.Errorf("can only validate strings, got %v", val)
 }
 
+// ValidateUpgradeSchedule checks that the value is a cron expression accepted by automatic upgrade policies
+func ValidateUpgradeSchedule(val interface{}) error {
+	if schedule, ok := val.(string); ok {
+		if schedule == "" {
+			return nil
+		}
+		_, err := cron.Parse(schedule)
+		return err
+	}
+	return fmt.Errorf("can only validate strings, got %v", val)
+}
+
 func ValidateAdditionalTrustBundle(val interface{}) error {
 	if additionalTrustBundleFile, ok := val.(string); ok {
 		if additionalTrustBundleFile == "" {
@@ -17,6 +17,7 @@ limitations under the License.
 package ocm
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -58,6 +59,20 @@ func (c *Client) CreateIdentityProvider(clusterID string, idp *cmv1.IdentityProv
 	return response.Body(), nil
 }
 
+func (c *Client) UpdateIdentityProvider(clusterID string, idpID string,
+	idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		Update().
+		Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
 func (c *Client) GetHTPasswdUserList(clusterID, htpasswdIDPId string) (*cmv1.HTPasswdUserList, error) {
 	listResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 		IdentityProviders().IdentityProvider(htpasswdIDPId).HtpasswdUsers().List().Send()
@@ -80,16 +95,119 @@ func (c *Client) AddHTPasswdUser(username, password, clusterID, idpID string) er
 	return nil
 }
 
+// HTPasswdUserSpec is an htpasswd user with either a plain text or a bcrypt hashed password. The SDK
+// doesn't support hashed passwords yet, so these users are sent directly.
+type HTPasswdUserSpec struct {
+	Username       string `json:"username"`
+	Password       string `json:"password,omitempty"`
+	HashedPassword string `json:"hashed_password,omitempty"`
+}
+
+func htpasswdUsersPath(clusterID, idpID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/identity_providers/%s/htpasswd_users", clusterID, idpID)
+}
+
+// CreateHTPasswdIDP creates an htpasswd identity provider with the given users
+func (c *Client) CreateHTPasswdIDP(clusterID, name string, users []*HTPasswdUserSpec) (
+	*cmv1.IdentityProvider, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"type": "HTPasswdIdentityProvider",
+		"name": name,
+		"htpasswd": map[string]interface{}{
+			"users": map[string]interface{}{
+				"items": users,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result json.RawMessage
+	err = c.sendRaw(c.ocm.Post().
+		Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/identity_providers", clusterID)).
+		Header("Content-Type", "application/json").
+		Bytes(body), &result)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalIdentityProvider([]byte(result))
+}
+
+// AddHTPasswdUserSpec adds a user with a plain text or hashed password to an htpasswd identity provider
+func (c *Client) AddHTPasswdUserSpec(clusterID, idpID string, user *HTPasswdUserSpec) error {
+	body, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	return c.sendRaw(c.ocm.Post().
+		Path(htpasswdUsersPath(clusterID, idpID)).
+		Header("Content-Type", "application/json").
+		Bytes(body), nil)
+}
+
+// UpdateHTPasswdUserSpec replaces the plain text or hashed password of a user of an htpasswd identity provider
+func (c *Client) UpdateHTPasswdUserSpec(clusterID string, idpID string, userID string,
+	user *HTPasswdUserSpec) error {
+	body, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	return c.sendRaw(c.ocm.Patch().
+		Path(htpasswdUsersPath(clusterID, idpID)+"/"+userID).
+		Header("Content-Type", "application/json").
+		Bytes(body), nil)
+}
+
+func (c *Client) UpdateHTPasswdUser(username, password, clusterID string, htpasswdIDP *cmv1.IdentityProvider) error {
+	userID, err := c.findHTPasswdUserID(username, clusterID, htpasswdIDP)
+	if err != nil {
+		return err
+	}
+	if userID == "" {
+		return fmt.Errorf("HTPasswd user named '%s' on cluster '%s' does not exist", username, clusterID)
+	}
+	htpasswdUser, _ := cmv1.NewHTPasswdUser().Username(username).Password(password).Build()
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(htpasswdIDP.ID()).HtpasswdUsers().
+		HtpasswdUser(userID).Update().Body(htpasswdUser).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) DeleteHTPasswdUser(username, clusterID string, htpasswdIDP *cmv1.IdentityProvider) error {
+	userID, err := c.findHTPasswdUserID(username, clusterID, htpasswdIDP)
+	if err != nil || userID == "" {
+		return err
+	}
+	return c.DeleteHTPasswdUserByID(clusterID, htpasswdIDP.ID(), userID)
+}
+
+// DeleteHTPasswdUserByID removes a user of an htpasswd identity provider whose identifier is already known
+func (c *Client) DeleteHTPasswdUserByID(clusterID string, idpID string, userID string) error {
+	deleteResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).HtpasswdUsers().
+		HtpasswdUser(userID).Delete().Send()
+	if err != nil {
+		return handleErr(deleteResponse.Error(), err)
+	}
+	return nil
+}
+
+// findHTPasswdUserID returns the identifier of an htpasswd user, or an empty string if the identity
+// provider doesn't exist
+func (c *Client) findHTPasswdUserID(username, clusterID string, htpasswdIDP *cmv1.IdentityProvider) (
+	string, error) {
 	var userID string
 
 	listResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 		IdentityProviders().IdentityProvider(htpasswdIDP.ID()).HtpasswdUsers().List().Send()
 	if err != nil {
 		if listResponse.Error().Status() == http.StatusNotFound {
-			return nil
+			return "", nil
 		}
-		return handleErr(listResponse.Error(), err)
+		return "", handleErr(listResponse.Error(), err)
 	}
 	listResponse.Items().Each(func(user *cmv1.HTPasswdUser) bool {
 		if user.Username() == username {
@@ -98,15 +216,9 @@ func (c *Client) DeleteHTPasswdUser(username, clusterID string, htpasswdIDP *cmv
 		return true
 	})
 	if userID == "" {
-		return fmt.Errorf("HTPasswd user named '%s' on cluster '%s' does not exist", username, clusterID)
+		return "", fmt.Errorf("HTPasswd user named '%s' on cluster '%s' does not exist", username, clusterID)
 	}
-	deleteResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
-		IdentityProviders().IdentityProvider(htpasswdIDP.ID()).HtpasswdUsers().
-		HtpasswdUser(userID).Delete().Send()
-	if err != nil {
-		return handleErr(deleteResponse.Error(), err)
-	}
-	return nil
+	return userID, nil
 }
 
 func (c *Client) DeleteIdentityProvider(clusterID string, idpID string) error {
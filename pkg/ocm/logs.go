@@ -17,7 +17,6 @@ limitations under the License.
 package ocm
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -66,55 +65,77 @@ func (c *Client) GetUninstallLogs(clusterID string, tail int) (logs *cmv1.Log, e
 	return response.Body(), nil
 }
 
-func (c *Client) PollInstallLogs(clusterID string, cb func(*cmv1.LogGetResponse) bool) (logs *cmv1.Log, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
-	defer func() {
-		cancel()
-	}()
+// GetInstallLogsFrom returns the lines of the install log of the cluster that follow the given line offset
+func (c *Client) GetInstallLogsFrom(clusterID string, offset int) (logs *cmv1.Log, err error) {
+	return getLogsFrom(c.installLogs(clusterID), clusterID, offset)
+}
 
-	logsClient := c.ocm.ClustersMgmt().V1().Clusters().
+// GetUninstallLogsFrom returns the lines of the uninstall log of the cluster that follow the given line offset
+func (c *Client) GetUninstallLogsFrom(clusterID string, offset int) (logs *cmv1.Log, err error) {
+	return getLogsFrom(c.uninstallLogs(clusterID), clusterID, offset)
+}
+
+// PollInstallLogs fetches the install log of the cluster periodically, starting at the given line offset, and
+// passes the new lines to the callback. The callback returns the offset of the next poll, and true to stop.
+func (c *Client) PollInstallLogs(clusterID string, offset int, cb func(*cmv1.Log) (int, bool)) error {
+	return pollLogs(c.installLogs(clusterID), clusterID, offset, cb)
+}
+
+// PollUninstallLogs is like PollInstallLogs for the uninstall log of the cluster
+func (c *Client) PollUninstallLogs(clusterID string, offset int, cb func(*cmv1.Log) (int, bool)) error {
+	return pollLogs(c.uninstallLogs(clusterID), clusterID, offset, cb)
+}
+
+func (c *Client) installLogs(clusterID string) *cmv1.LogClient {
+	return c.ocm.ClustersMgmt().V1().Clusters().
 		Cluster(clusterID).
 		Logs().
 		Install()
-	response, err := logsClient.Poll().
-		Parameter("tail", 100).
-		Interval(interval).
-		Predicate(cb).
-		StartContext(ctx)
-	if err != nil {
-		err = fmt.Errorf("Failed to poll logs for cluster '%s': %v", clusterID, err)
-		if response.Status() == http.StatusNotFound {
-			err = errors.NotFound.UserErrorf("Failed to poll logs for cluster '%s'", clusterID)
-		}
-		return
-	}
-
-	return response.Body(), nil
 }
 
-func (c *Client) PollUninstallLogs(clusterID string,
-	cb func(*cmv1.LogGetResponse) bool) (logs *cmv1.Log, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
-	defer func() {
-		cancel()
-	}()
-
-	logsClient := c.ocm.ClustersMgmt().V1().Clusters().
+func (c *Client) uninstallLogs(clusterID string) *cmv1.LogClient {
+	return c.ocm.ClustersMgmt().V1().Clusters().
 		Cluster(clusterID).
 		Logs().
 		Uninstall()
-	response, err := logsClient.Poll().
-		Parameter("tail", 100).
-		Interval(interval).
-		Predicate(cb).
-		StartContext(ctx)
+}
+
+func getLogsFrom(logsClient *cmv1.LogClient, clusterID string, offset int) (logs *cmv1.Log, err error) {
+	response, err := logsClient.Get().
+		Offset(offset).
+		Send()
 	if err != nil {
-		err = fmt.Errorf("Failed to poll logs for cluster '%s': %v", clusterID, err)
+		err = handleErr(response.Error(), err)
 		if response.Status() == http.StatusNotFound {
-			err = errors.NotFound.UserErrorf("Failed to poll logs for cluster '%s'", clusterID)
+			err = errors.NotFound.UserErrorf("Failed to get logs for cluster '%s'", clusterID)
 		}
 		return
 	}
 
 	return response.Body(), nil
 }
+
+func pollLogs(logsClient *cmv1.LogClient, clusterID string, offset int,
+	cb func(*cmv1.Log) (int, bool)) error {
+	timeout := time.After(time.Hour)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timeout:
+			return fmt.Errorf("Timed out polling logs for cluster '%s'", clusterID)
+		case <-ticker.C:
+		}
+		logs, err := getLogsFrom(logsClient, clusterID, offset)
+		// Logs aren't available until the cluster starts installing
+		if err != nil && errors.GetType(err) != errors.NotFound {
+			return fmt.Errorf("Failed to poll logs for cluster '%s': %v", clusterID, err)
+		}
+		var done bool
+		offset, done = cb(logs)
+		if done {
+			return nil
+		}
+	}
+}
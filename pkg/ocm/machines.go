@@ -28,6 +28,17 @@ import (
 
 const AcceleratedComputing = "accelerated_computing"
 
+// Instance types and root volume sizes used for the nodes of a cluster
+const (
+	DefaultComputeMachineType        = "m5.xlarge"
+	DefaultControlPlaneMachineType   = "m5.2xlarge"
+	DefaultInfraMachineType          = "r5.xlarge"
+	ControlPlaneReplicas             = 3
+	DefaultControlPlaneVolumeSizeGiB = 350
+	DefaultInfraVolumeSizeGiB        = 300
+	DefaultComputeVolumeSizeGiB      = 300
+)
+
 func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderData) (MachineTypeList, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().MachineTypes()
 	page := 1
@@ -97,7 +108,8 @@ func (c *Client) GetMachineTypes() (machineTypes MachineTypeList, err error) {
 	return
 }
 
-func getDefaultNodes(multiAZ bool) int {
+// GetDefaultNodes returns the default number of compute nodes, which is also the number of infra nodes
+func GetDefaultNodes(multiAZ bool) int {
 	minimumNodes := 2
 	if multiAZ {
 		minimumNodes = 3
@@ -112,7 +124,7 @@ type MachineType struct {
 }
 
 func (mt MachineType) HasQuota(multiAZ bool) bool {
-	return mt.MachineType.Category() != AcceleratedComputing || mt.availableQuota > getDefaultNodes(multiAZ)
+	return mt.MachineType.Category() != AcceleratedComputing || mt.availableQuota > GetDefaultNodes(multiAZ)
 }
 
 // GetAvailableMachineTypesInRegion get the supported machine type in the region.
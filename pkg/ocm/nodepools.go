@@ -1,6 +1,29 @@
 package ocm
 
-import cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ver "github.com/hashicorp/go-version"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// MaxNodePoolMinorVersionSkew is the number of minor versions that the nodes of a hosted cluster can be
+// behind its control plane
+const MaxNodePoolMinorVersionSkew = 2
+
+// nodePoolVersion contains the version of a node pool. The version isn't part of the node pool type of the
+// SDK yet, so it is read from the raw API.
+type nodePoolVersion struct {
+	ID      string `json:"id"`
+	Version struct {
+		ID    string `json:"id"`
+		RawID string `json:"raw_id"`
+	} `json:"version"`
+}
 
 func (c *Client) CreateNodePool(clusterID string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
@@ -62,3 +85,196 @@ func (c *Client) DeleteNodePool(clusterID string, nodePoolID string) error {
 	}
 	return nil
 }
+
+// NodePoolUpgradePolicy is an upgrade of the nodes of a node pool. Node pool upgrade policies aren't
+// supported by the SDK yet, so they are sent to the raw API.
+type NodePoolUpgradePolicy struct {
+	Kind         string                      `json:"kind,omitempty"`
+	ID           string                      `json:"id,omitempty"`
+	NodePoolID   string                      `json:"node_pool_id,omitempty"`
+	ScheduleType string                      `json:"schedule_type,omitempty"`
+	UpgradeType  string                      `json:"upgrade_type,omitempty"`
+	Version      string                      `json:"version,omitempty"`
+	NextRun      *time.Time                  `json:"next_run,omitempty"`
+	State        *NodePoolUpgradePolicyState `json:"state,omitempty"`
+}
+
+// NodePoolUpgradePolicyState is the state of an upgrade of the nodes of a node pool
+type NodePoolUpgradePolicyState struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewNodePoolUpgradePolicy creates a policy that upgrades the nodes of a node pool once at the given time
+func NewNodePoolUpgradePolicy(nodePoolID string, version string, nextRun time.Time) *NodePoolUpgradePolicy {
+	return &NodePoolUpgradePolicy{
+		Kind:         "NodePoolUpgradePolicy",
+		NodePoolID:   nodePoolID,
+		ScheduleType: "manual",
+		UpgradeType:  "NodePool",
+		Version:      version,
+		NextRun:      &nextRun,
+	}
+}
+
+func nodePoolUpgradePoliciesPath(clusterID string, nodePoolID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/node_pools/%s/upgrade_policies", clusterID, nodePoolID)
+}
+
+func (c *Client) GetNodePoolUpgradePolicies(clusterID string, nodePoolID string) ([]*NodePoolUpgradePolicy,
+	error) {
+	var list struct {
+		Items []*NodePoolUpgradePolicy `json:"items"`
+	}
+	err := c.sendRaw(c.ocm.Get().
+		Path(nodePoolUpgradePoliciesPath(clusterID, nodePoolID)).
+		Parameter("size", -1), &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetScheduledNodePoolUpgrade returns the upgrade of the node pool that hasn't completed, failed or been
+// cancelled yet, if any
+func (c *Client) GetScheduledNodePoolUpgrade(clusterID string, nodePoolID string) (*NodePoolUpgradePolicy,
+	error) {
+	upgradePolicies, err := c.GetNodePoolUpgradePolicies(clusterID, nodePoolID)
+	if err != nil {
+		return nil, err
+	}
+	for _, upgradePolicy := range upgradePolicies {
+		if isNodePoolUpgradeScheduled(upgradePolicy) {
+			return upgradePolicy, nil
+		}
+	}
+	return nil, nil
+}
+
+func isNodePoolUpgradeScheduled(upgradePolicy *NodePoolUpgradePolicy) bool {
+	if upgradePolicy.ScheduleType != "manual" || upgradePolicy.UpgradeType != "NodePool" {
+		return false
+	}
+	if upgradePolicy.State == nil {
+		return true
+	}
+	switch cmv1.UpgradePolicyStateValue(upgradePolicy.State.Value) {
+	case cmv1.UpgradePolicyStateValueCompleted,
+		cmv1.UpgradePolicyStateValueFailed,
+		cmv1.UpgradePolicyStateValueCancelled:
+		return false
+	}
+	return true
+}
+
+func (c *Client) ScheduleNodePoolUpgrade(clusterID string, nodePoolID string,
+	upgradePolicy *NodePoolUpgradePolicy) (*NodePoolUpgradePolicy, error) {
+	body, err := json.Marshal(upgradePolicy)
+	if err != nil {
+		return nil, err
+	}
+	var result NodePoolUpgradePolicy
+	err = c.sendRaw(c.ocm.Post().
+		Path(nodePoolUpgradePoliciesPath(clusterID, nodePoolID)).
+		Header("Content-Type", "application/json").
+		Bytes(body), &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetNodePoolVersions returns the versions of the node pools of a cluster, indexed by node pool identifier
+func (c *Client) GetNodePoolVersions(clusterID string, channelGroup string) (map[string]string, error) {
+	var list struct {
+		Items []*nodePoolVersion `json:"items"`
+	}
+	err := c.sendRaw(c.ocm.Get().
+		Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/node_pools", clusterID)).
+		Parameter("size", -1), &list)
+	if err != nil {
+		return nil, err
+	}
+	versions := make(map[string]string)
+	for _, item := range list.Items {
+		versions[item.ID] = item.rawVersion(channelGroup)
+	}
+	return versions, nil
+}
+
+// GetNodePoolVersion returns the version of a node pool
+func (c *Client) GetNodePoolVersion(clusterID string, nodePoolID string, channelGroup string) (string, error) {
+	var nodePool nodePoolVersion
+	err := c.sendRaw(c.ocm.Get().
+		Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/node_pools/%s", clusterID, nodePoolID)), &nodePool)
+	if err != nil {
+		return "", err
+	}
+	return nodePool.rawVersion(channelGroup), nil
+}
+
+func (v *nodePoolVersion) rawVersion(channelGroup string) string {
+	if v.Version.RawID != "" {
+		return v.Version.RawID
+	}
+	if v.Version.ID != "" {
+		return GetRawVersionID(v.Version.ID, channelGroup)
+	}
+	return ""
+}
+
+// ValidateNodePoolVersionSkew checks that the nodes of a node pool can run the given version with the
+// given version of the control plane
+func ValidateNodePoolVersionSkew(controlPlaneVersion string, nodePoolVersion string) error {
+	controlPlane, err := ver.NewVersion(controlPlaneVersion)
+	if err != nil {
+		return err
+	}
+	nodes, err := ver.NewVersion(nodePoolVersion)
+	if err != nil {
+		return err
+	}
+	if nodes.GreaterThan(controlPlane) {
+		return fmt.Errorf("Node pool version '%s' can't be newer than the control plane version '%s'",
+			nodePoolVersion, controlPlaneVersion)
+	}
+	controlPlaneSegments := controlPlane.Segments()
+	nodesSegments := nodes.Segments()
+	skew := controlPlaneSegments[1] - nodesSegments[1]
+	if controlPlaneSegments[0] != nodesSegments[0] || skew > MaxNodePoolMinorVersionSkew {
+		return fmt.Errorf("Node pool version '%s' can't be more than %d minor versions older than the "+
+			"control plane version '%s'", nodePoolVersion, MaxNodePoolMinorVersionSkew, controlPlaneVersion)
+	}
+	return nil
+}
+
+// FilterNodePoolUpgrades returns the versions that the nodes of a node pool can be upgraded to with the
+// given version of the control plane
+func FilterNodePoolUpgrades(availableUpgrades []string, controlPlaneVersion string) []string {
+	var versions []string
+	for _, version := range availableUpgrades {
+		if ValidateNodePoolVersionSkew(controlPlaneVersion, version) == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// sendRaw sends a request that the SDK doesn't support yet and unmarshals the body of the response
+func (c *Client) sendRaw(request *sdk.Request, body interface{}) error {
+	response, err := request.Send()
+	if err != nil {
+		return err
+	}
+	if response.Status() >= 400 {
+		ocmErr, err := ocmerrors.UnmarshalErrorStatus(response.Bytes(), response.Status())
+		if err != nil {
+			return fmt.Errorf("Failed to read the error of the response: %v", err)
+		}
+		return handleErr(ocmErr, ocmErr)
+	}
+	if body == nil || len(response.Bytes()) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Bytes(), body)
+}
@@ -0,0 +1,59 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Node pools", func() {
+	DescribeTable("Should validate the version skew between the control plane and the nodes",
+		func(controlPlaneVersion string, nodePoolVersion string, valid bool) {
+			err := ValidateNodePoolVersionSkew(controlPlaneVersion, nodePoolVersion)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("Same version", "4.12.5", "4.12.5", true),
+		Entry("Older patch version", "4.12.5", "4.12.1", true),
+		Entry("Two minor versions older", "4.14.0", "4.12.5", true),
+		Entry("Three minor versions older", "4.15.0", "4.12.5", false),
+		Entry("Newer than the control plane", "4.12.5", "4.12.6", false),
+		Entry("Different major version", "5.0.0", "4.12.5", false),
+	)
+
+	DescribeTable("Should get the raw version of a version identifier",
+		func(versionID string, channelGroup string, expected string) {
+			Expect(GetRawVersionID(versionID, channelGroup)).To(Equal(expected))
+			Expect(CreateVersionID(expected, channelGroup)).To(Equal(versionID))
+		},
+		Entry("Stable", "openshift-v4.12.5", DefaultChannelGroup, "4.12.5"),
+		Entry("Candidate", "openshift-v4.13.0-rc.1-candidate", "candidate", "4.13.0-rc.1"),
+	)
+
+	DescribeTable("Should only consider scheduled the upgrades that haven't finished",
+		func(state string, scheduled bool) {
+			upgradePolicy := NewNodePoolUpgradePolicy("workers", "4.12.6", time.Now())
+			if state != "" {
+				upgradePolicy.State = &NodePoolUpgradePolicyState{Value: state}
+			}
+			Expect(isNodePoolUpgradeScheduled(upgradePolicy)).To(Equal(scheduled))
+		},
+		Entry("Without state", "", true),
+		Entry("Pending", "pending", true),
+		Entry("Started", "started", true),
+		Entry("Completed", "completed", false),
+		Entry("Failed", "failed", false),
+		Entry("Cancelled", "cancelled", false),
+	)
+
+	It("Should only keep the upgrades that aren't newer than the control plane", func() {
+		Expect(FilterNodePoolUpgrades([]string{"4.12.8", "4.12.6", "4.12.5"}, "4.12.6")).
+			To(Equal([]string{"4.12.6", "4.12.5"}))
+		Expect(FilterNodePoolUpgrades([]string{"4.13.0"}, "4.12.6")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+)
+
+// ServiceLogSeverities are the severities of the entries of the service log, from the least to the most severe
+var ServiceLogSeverities = []string{
+	string(slv1.SeverityDebug),
+	string(slv1.SeverityInfo),
+	string(slv1.SeverityWarning),
+	string(slv1.SeverityError),
+	string(slv1.SeverityFatal),
+}
+
+// ParseServiceLogSeverity returns the severity with the given name, ignoring case
+func ParseServiceLogSeverity(value string) (slv1.Severity, error) {
+	for _, severity := range ServiceLogSeverities {
+		if strings.EqualFold(value, severity) {
+			return slv1.Severity(severity), nil
+		}
+	}
+	return "", fmt.Errorf("Invalid severity '%s', expected one of %s", value, strings.Join(ServiceLogSeverities, ", "))
+}
+
+// ServiceLogSearch returns the search query of the service log entries created after the given time with
+// the given severity. Zero values aren't used to filter the entries.
+func ServiceLogSearch(since time.Time, severity string) string {
+	var conditions []string
+	if !since.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("timestamp >= '%s'", since.UTC().Format(time.RFC3339)))
+	}
+	if severity != "" {
+		conditions = append(conditions, fmt.Sprintf("severity = '%s'", severity))
+	}
+	return strings.Join(conditions, " and ")
+}
+
+// GetClusterServiceLogs returns the entries of the service log of the cluster with the given external
+// identifier that match the search query, oldest first
+func (c *Client) GetClusterServiceLogs(clusterUUID string, search string) (entries []*slv1.LogEntry,
+	err error) {
+	collection := c.ocm.ServiceLogs().V1().
+		Clusters().
+		Cluster(clusterUUID).
+		ClusterLogs()
+	page := 1
+	size := 100
+	for {
+		request := collection.List().
+			Order("timestamp asc").
+			Page(page).
+			Size(size)
+		if search != "" {
+			request = request.Search(search)
+		}
+		response, err := request.Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		entries = append(entries, response.Items().Slice()...)
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return entries, nil
+}
+
+// AddClusterServiceLog adds an entry to the service log of the cluster given by the entry
+func (c *Client) AddClusterServiceLog(entry *slv1.LogEntry) (*slv1.LogEntry, error) {
+	response, err := c.ocm.ServiceLogs().V1().
+		ClusterLogs().
+		Add().
+		Body(entry).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
@@ -0,0 +1,29 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+)
+
+var _ = Describe("Service logs", func() {
+	It("Parses severities ignoring case", func() {
+		severity, err := ParseServiceLogSeverity("warning")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(severity).To(Equal(slv1.SeverityWarning))
+
+		_, err = ParseServiceLogSeverity("critical")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Builds the search query", func() {
+		since := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+		Expect(ServiceLogSearch(time.Time{}, "")).To(BeEmpty())
+		Expect(ServiceLogSearch(since, "")).To(Equal("timestamp >= '2022-11-01T10:00:00Z'"))
+		Expect(ServiceLogSearch(since, "Error")).To(Equal(
+			"timestamp >= '2022-11-01T10:00:00Z' and severity = 'Error'"))
+	})
+})
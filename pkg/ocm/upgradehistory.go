@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to rebuild the upgrade history of a cluster. The history is built
+// from the upgrade policies of the cluster and their states. Upgrade policies are deleted once the upgrade
+// completes, so past upgrades and the times of the steps of each upgrade are read from the service log of
+// the cluster.
+
+package ocm
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+)
+
+// UpgradeHistorySearch selects the entries of the service log about upgrades
+const UpgradeHistorySearch = "summary like '%pgrade%'"
+
+const (
+	UpgradeStateScheduled = "scheduled"
+	UpgradeStateStarted   = "started"
+	UpgradeStateCompleted = "completed"
+	UpgradeStateFailed    = "failed"
+	UpgradeStateCancelled = "cancelled"
+)
+
+const upgradeVersionPattern = `\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?\b`
+
+var (
+	upgradeVersionRE = regexp.MustCompile(`\b` + upgradeVersionPattern)
+	// The service log names the version that the cluster is upgraded to after 'to' and, in some messages,
+	// the version that it's upgraded from after 'from'
+	upgradeTargetVersionRE = regexp.MustCompile(`(?i)\bto (?:version )?'?(` + upgradeVersionPattern + `)`)
+	upgradeSourceVersionRE = regexp.MustCompile(`(?i)\bfrom (?:version )?'?(` + upgradeVersionPattern + `)`)
+)
+
+// UpgradeRecord is an upgrade of a cluster to a version
+type UpgradeRecord struct {
+	PolicyID     string
+	Version      string
+	ScheduleType string
+	State        string
+	ScheduledAt  time.Time
+	NextRun      time.Time
+	StartedAt    time.Time
+	FinishedAt   time.Time
+}
+
+// NewUpgradeHistory builds the upgrades of a cluster, oldest first. Each upgrade policy with a version is an
+// upgrade in the state given by states, indexed by policy identifier. The entries of the service log about
+// upgrades only add the times of the steps of those upgrades, and the past upgrades whose policies were
+// deleted.
+func NewUpgradeHistory(policies []*cmv1.UpgradePolicy, states map[string]*cmv1.UpgradePolicyState,
+	entries []*slv1.LogEntry) []*UpgradeRecord {
+	records := getServiceLogUpgrades(entries)
+	for _, policy := range policies {
+		// Automatic upgrade policies don't have a version until an upgrade is available
+		if policy.Version() == "" {
+			continue
+		}
+		// The service log entries of the last upgrade to the version that hasn't ended belong to the policy
+		var record *UpgradeRecord
+		for _, r := range records {
+			if r.PolicyID == "" && r.Version == policy.Version() &&
+				r.State != UpgradeStateCompleted && r.State != UpgradeStateCancelled {
+				record = r
+			}
+		}
+		if record == nil {
+			record = &UpgradeRecord{Version: policy.Version()}
+			records = append(records, record)
+		}
+		record.PolicyID = policy.ID()
+		record.ScheduleType = policy.ScheduleType()
+		record.NextRun = policy.NextRun()
+		record.State = ""
+		if state := states[policy.ID()]; state != nil {
+			record.State = string(state.Value())
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].time().Before(records[j].time())
+	})
+	return records
+}
+
+// getServiceLogUpgrades builds the upgrades mentioned by the entries of the service log, oldest first
+func getServiceLogUpgrades(entries []*slv1.LogEntry) []*UpgradeRecord {
+	var records []*UpgradeRecord
+	current := make(map[string]*UpgradeRecord)
+	for _, entry := range entries {
+		text := entry.Summary() + " " + entry.Description()
+		if !strings.Contains(strings.ToLower(entry.Summary()), "upgrade") {
+			continue
+		}
+		version := getUpgradeVersion(text)
+		// The summary names the state, while the description may mention others, as in 'started, the
+		// upgrade may take hours to complete'
+		state := getUpgradeState(entry.Summary())
+		if state == "" {
+			state = getUpgradeState(entry.Description())
+		}
+		if version == "" || state == "" {
+			continue
+		}
+
+		// An upgrade that ended starts a new record when the cluster is upgraded to the same version again
+		record, ok := current[version]
+		if !ok || record.State == UpgradeStateCompleted || record.State == UpgradeStateCancelled {
+			record = &UpgradeRecord{Version: version}
+			current[version] = record
+			records = append(records, record)
+		}
+		record.State = state
+		switch state {
+		case UpgradeStateScheduled:
+			record.ScheduledAt = entry.Timestamp()
+		case UpgradeStateStarted:
+			record.StartedAt = entry.Timestamp()
+		default:
+			record.FinishedAt = entry.Timestamp()
+		}
+	}
+	return records
+}
+
+// time returns the time used to sort the upgrades
+func (r *UpgradeRecord) time() time.Time {
+	for _, t := range []time.Time{r.ScheduledAt, r.StartedAt, r.NextRun, r.FinishedAt} {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// getUpgradeVersion returns the version that a service log message says the cluster is upgraded to
+func getUpgradeVersion(text string) string {
+	match := upgradeTargetVersionRE.FindStringSubmatch(text)
+	if match != nil {
+		return match[1]
+	}
+	// Messages without a target version name a single version, unless they also name the source one
+	source := ""
+	match = upgradeSourceVersionRE.FindStringSubmatch(text)
+	if match != nil {
+		source = match[1]
+	}
+	for _, version := range upgradeVersionRE.FindAllString(text, -1) {
+		if version != source {
+			return version
+		}
+	}
+	return ""
+}
+
+func getUpgradeState(text string) string {
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "cancel"):
+		return UpgradeStateCancelled
+	case strings.Contains(text, "fail"):
+		return UpgradeStateFailed
+	case strings.Contains(text, "complete"), strings.Contains(text, "finished"),
+		strings.Contains(text, "successfully upgraded"):
+		return UpgradeStateCompleted
+	case strings.Contains(text, "schedul"):
+		return UpgradeStateScheduled
+	case strings.Contains(text, "start"), strings.Contains(text, "began"), strings.Contains(text, "in progress"):
+		return UpgradeStateStarted
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+)
+
+func buildLogEntry(timestamp time.Time, username string, summary string, description string) *slv1.LogEntry {
+	entry, err := slv1.NewLogEntry().
+		Timestamp(timestamp).
+		Username(username).
+		Summary(summary).
+		Description(description).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+	return entry
+}
+
+var _ = Describe("Upgrade history", func() {
+	start := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	// The entries follow the upgrade notifications that OCM sends to the service log of the cluster
+	entries := []*slv1.LogEntry{
+		buildLogEntry(start, "alice", "Cluster upgrade scheduled",
+			"Your cluster 'mycluster' is scheduled for an upgrade from version 4.11.10 to version 4.11.13 "+
+				"on 2022-11-01 at 11:00 UTC."),
+		buildLogEntry(start.Add(time.Hour), "service-account", "Cluster upgrade started",
+			"Your cluster 'mycluster' is being upgraded from version 4.11.10 to version 4.11.13. "+
+				"The upgrade may take several hours to complete."),
+		buildLogEntry(start.Add(2*time.Hour), "service-account", "Cluster upgrade completed",
+			"Your cluster 'mycluster' has been successfully upgraded from version 4.11.10 to version 4.11.13."),
+		buildLogEntry(start.Add(3*time.Hour), "service-account", "Cluster is in Limited Support",
+			"Your cluster version 4.11.13 is in limited support."),
+		buildLogEntry(start.Add(24*time.Hour), "bob", "Cluster upgrade scheduled",
+			"Your cluster 'mycluster' is scheduled for an upgrade from version 4.11.13 to version 4.11.17 "+
+				"on 2022-11-03 at 10:00 UTC."),
+		buildLogEntry(start.Add(25*time.Hour), "bob", "Cluster upgrade cancelled",
+			"The scheduled upgrade of your cluster 'mycluster' from version 4.11.13 to version 4.11.17 "+
+				"has been cancelled."),
+	}
+
+	It("Builds the past upgrades from the service log", func() {
+		records := NewUpgradeHistory(nil, nil, entries)
+		Expect(records).To(HaveLen(2))
+
+		Expect(records[0].Version).To(Equal("4.11.13"))
+		Expect(records[0].State).To(Equal(UpgradeStateCompleted))
+		Expect(records[0].ScheduledAt).To(Equal(start))
+		Expect(records[0].StartedAt).To(Equal(start.Add(time.Hour)))
+		Expect(records[0].FinishedAt).To(Equal(start.Add(2 * time.Hour)))
+
+		Expect(records[1].Version).To(Equal("4.11.17"))
+		Expect(records[1].State).To(Equal(UpgradeStateCancelled))
+	})
+
+	It("Takes the state of the upgrades from their policies", func() {
+		nextRun := start.Add(48 * time.Hour)
+		policy, err := cmv1.NewUpgradePolicy().
+			ID("policy").
+			ScheduleType("manual").
+			Version("4.11.17").
+			NextRun(nextRun).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		state, err := cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueDelayed).Build()
+		Expect(err).ToNot(HaveOccurred())
+		states := map[string]*cmv1.UpgradePolicyState{"policy": state}
+
+		records := NewUpgradeHistory([]*cmv1.UpgradePolicy{policy}, states, entries)
+		Expect(records).To(HaveLen(3))
+		Expect(records[2].PolicyID).To(Equal("policy"))
+		Expect(records[2].Version).To(Equal("4.11.17"))
+		Expect(records[2].State).To(Equal("delayed"))
+		Expect(records[2].NextRun).To(Equal(nextRun))
+
+		// The service log only adds the times of the steps of the upgrade of the policy
+		rescheduled := append(entries, buildLogEntry(start.Add(26*time.Hour), "carol", "Cluster upgrade scheduled",
+			"Your cluster 'mycluster' is scheduled for an upgrade from version 4.11.13 to version 4.11.17 "+
+				"on 2022-11-03 at 10:00 UTC."))
+		records = NewUpgradeHistory([]*cmv1.UpgradePolicy{policy}, states, rescheduled)
+		Expect(records).To(HaveLen(3))
+		Expect(records[2].PolicyID).To(Equal("policy"))
+		Expect(records[2].State).To(Equal("delayed"))
+		Expect(records[2].ScheduledAt).To(Equal(start.Add(26 * time.Hour)))
+	})
+
+	It("Ignores the automatic upgrade policies without a version", func() {
+		policy, err := cmv1.NewUpgradePolicy().ID("automatic").ScheduleType("automatic").Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(NewUpgradeHistory([]*cmv1.UpgradePolicy{policy}, nil, nil)).To(BeEmpty())
+	})
+
+	It("Finds the version that the cluster is upgraded to", func() {
+		Expect(getUpgradeVersion("Upgrading from 4.11.10 to 4.11.13")).To(Equal("4.11.13"))
+		Expect(getUpgradeVersion("Upgrade to version '4.12.0-rc.1' started")).To(Equal("4.12.0-rc.1"))
+		Expect(getUpgradeVersion("Upgrade from version 4.11.10 failed: 4.11.13 is not available")).
+			To(Equal("4.11.13"))
+		Expect(getUpgradeVersion("Cluster upgraded: 4.11.13")).To(Equal("4.11.13"))
+		Expect(getUpgradeVersion("Cluster upgrade started")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// UpgradeGraph maps each version to the versions that it can be upgraded to directly
+type UpgradeGraph map[string][]string
+
+// NewUpgradeGraph builds the graph of the upgrades between the given versions. Upgrades to versions that
+// aren't in the list are ignored.
+func NewUpgradeGraph(versions []*cmv1.Version) UpgradeGraph {
+	known := make(map[string]bool)
+	for _, version := range versions {
+		known[version.RawID()] = true
+	}
+	graph := make(UpgradeGraph)
+	for _, version := range versions {
+		var targets []string
+		for _, target := range version.AvailableUpgrades() {
+			if known[target] {
+				targets = append(targets, target)
+			}
+		}
+		// Visit the latest versions first, so that they are preferred between paths of the same length
+		sortVersionsDescending(targets)
+		graph[version.RawID()] = targets
+	}
+	return graph
+}
+
+// FindUpgradePath returns the shortest sequence of upgrades from the version to the target, without the
+// starting version. The target can be a version, or a minor version like '4.12' or '4.12.x' to upgrade
+// to its latest reachable patch version.
+func (g UpgradeGraph) FindUpgradePath(from string, target string) ([]string, error) {
+	target = strings.TrimSuffix(target, ".x")
+	isMinor := strings.Count(target, ".") == 1
+
+	// Breadth first search from the starting version
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g[current] {
+			if _, visited := previous[next]; visited {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	destination := ""
+	if isMinor {
+		var candidates []string
+		for version := range previous {
+			if version != from && strings.HasPrefix(version, target+".") {
+				candidates = append(candidates, version)
+			}
+		}
+		if len(candidates) > 0 {
+			sortVersionsDescending(candidates)
+			destination = candidates[0]
+		}
+	} else if _, ok := previous[target]; ok && target != from {
+		destination = target
+	}
+	if destination == "" {
+		return nil, fmt.Errorf("There is no supported upgrade path from version '%s' to version '%s'",
+			from, target)
+	}
+
+	var path []string
+	for version := destination; version != from; version = previous[version] {
+		path = append([]string{version}, path...)
+	}
+	return path, nil
+}
+
+func sortVersionsDescending(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		a, erra := ver.NewVersion(versions[i])
+		b, errb := ver.NewVersion(versions[j])
+		if erra != nil || errb != nil {
+			return versions[i] > versions[j]
+		}
+		return a.GreaterThan(b)
+	})
+}
@@ -0,0 +1,75 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func buildVersion(rawID string, upgrades ...string) *cmv1.Version {
+	version, err := cmv1.NewVersion().
+		ID("openshift-v" + rawID).
+		RawID(rawID).
+		AvailableUpgrades(upgrades...).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+	return version
+}
+
+var _ = Describe("Upgrade path", func() {
+	var graph UpgradeGraph
+
+	BeforeEach(func() {
+		graph = NewUpgradeGraph([]*cmv1.Version{
+			buildVersion("4.10.20", "4.10.30", "4.11.5"),
+			buildVersion("4.10.30", "4.11.5", "4.11.10"),
+			buildVersion("4.11.5", "4.11.10", "4.12.1"),
+			buildVersion("4.11.10", "4.12.1", "4.12.3"),
+			buildVersion("4.12.1", "4.12.3", "4.13.0"),
+			buildVersion("4.12.3"),
+			buildVersion("4.13.0", "4.14.0"),
+		})
+	})
+
+	It("Finds a direct upgrade", func() {
+		path, err := graph.FindUpgradePath("4.10.20", "4.10.30")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.10.30"}))
+	})
+
+	It("Finds the shortest path across minor versions", func() {
+		path, err := graph.FindUpgradePath("4.10.20", "4.12.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.11.5", "4.12.1"}))
+	})
+
+	It("Prefers the latest versions between paths of the same length", func() {
+		path, err := graph.FindUpgradePath("4.10.30", "4.12.3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.11.10", "4.12.3"}))
+	})
+
+	It("Upgrades to the latest reachable patch of a minor version", func() {
+		path, err := graph.FindUpgradePath("4.10.20", "4.12.x")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.11.5", "4.12.1", "4.12.3"}))
+
+		path, err = graph.FindUpgradePath("4.10.20", "4.13")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.11.5", "4.12.1", "4.13.0"}))
+	})
+
+	It("Ignores upgrades to unknown versions", func() {
+		_, err := graph.FindUpgradePath("4.13.0", "4.14.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Fails when the target isn't reachable", func() {
+		_, err := graph.FindUpgradePath("4.12.3", "4.13.x")
+		Expect(err).To(HaveOccurred())
+
+		_, err = graph.FindUpgradePath("4.12.3", "4.12.3")
+		Expect(err).To(HaveOccurred())
+	})
+})
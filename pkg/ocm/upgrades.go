@@ -18,9 +18,28 @@ package ocm
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
+
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
+// UpgradeCheckStatus is the result of one of the checks of the readiness of a cluster to be upgraded
+type UpgradeCheckStatus string
+
+const (
+	UpgradeCheckPass UpgradeCheckStatus = "pass"
+	UpgradeCheckWarn UpgradeCheckStatus = "warn"
+	UpgradeCheckFail UpgradeCheckStatus = "fail"
+)
+
+// UpgradeCheck is a check of the readiness of a cluster to be upgraded to a version
+type UpgradeCheck struct {
+	Name    string             `json:"name"`
+	Status  UpgradeCheckStatus `json:"status"`
+	Details []string           `json:"details,omitempty"`
+}
+
 func (c *Client) GetUpgradePolicies(clusterID string) (upgradePolicies []*cmv1.UpgradePolicy, err error) {
 	collection := c.ocm.ClustersMgmt().V1().
 		Clusters().
@@ -69,6 +88,79 @@ func (c *Client) GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cm
 	return nil, nil, nil
 }
 
+// GetAutomaticUpgradePolicy returns the policy that upgrades the cluster on a recurring schedule, or nil
+// when the cluster doesn't have one
+func (c *Client) GetAutomaticUpgradePolicy(clusterID string) (*cmv1.UpgradePolicy, error) {
+	upgradePolicies, err := c.GetUpgradePolicies(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, upgradePolicy := range upgradePolicies {
+		if upgradePolicy.ScheduleType() == "automatic" && upgradePolicy.UpgradeType() == "OSD" {
+			return upgradePolicy, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsUpgradeInProgress returns true when any of the upgrade policies of the cluster, manual or automatic,
+// has started upgrading it
+func (c *Client) IsUpgradeInProgress(clusterID string) (bool, error) {
+	upgradePolicies, err := c.GetUpgradePolicies(clusterID)
+	if err != nil {
+		return false, err
+	}
+	for _, upgradePolicy := range upgradePolicies {
+		state, err := c.GetUpgradePolicyState(clusterID, upgradePolicy.ID())
+		if err != nil {
+			return false, err
+		}
+		if state.Value() == cmv1.UpgradePolicyStateValueStarted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Client) GetUpgradePolicyState(clusterID string, upgradePolicyID string) (*cmv1.UpgradePolicyState,
+	error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(upgradePolicyID).
+		State().
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+func (c *Client) UpdateUpgradePolicy(clusterID string, upgradePolicyID string,
+	upgradePolicy *cmv1.UpgradePolicy) (*cmv1.UpgradePolicy, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(upgradePolicyID).
+		Update().Body(upgradePolicy).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+func (c *Client) DeleteUpgradePolicy(clusterID string, upgradePolicyID string) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(upgradePolicyID).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) ScheduleUpgrade(clusterID string, upgradePolicy *cmv1.UpgradePolicy) error {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -81,6 +173,45 @@ func (c *Client) ScheduleUpgrade(clusterID string, upgradePolicy *cmv1.UpgradePo
 	return nil
 }
 
+// ScheduleManualUpgrade schedules a manual upgrade of the cluster to the version at the given time. The
+// version gates that the cluster hasn't agreed to yet are passed to the acknowledge function before they
+// are agreed to, an error returned by it stops the upgrade from being scheduled.
+func (c *Client) ScheduleManualUpgrade(clusterID string, version string, nextRun time.Time,
+	acknowledge func(gates []*cmv1.VersionGate) error) error {
+	upgradePolicy, err := cmv1.NewUpgradePolicy().
+		ScheduleType("manual").
+		Version(version).
+		Build()
+	if err != nil {
+		return err
+	}
+	gates, err := c.GetMissingGateAgreements(clusterID, upgradePolicy)
+	if err != nil {
+		return fmt.Errorf("failed to check for missing gate agreements: %v", err)
+	}
+	if len(gates) > 0 {
+		err = acknowledge(gates)
+		if err != nil {
+			return err
+		}
+	}
+	for _, gate := range gates {
+		err = c.AckVersionGate(clusterID, gate.ID())
+		if err != nil {
+			return fmt.Errorf("failed to acknowledge version gate '%s': %v", gate.ID(), err)
+		}
+	}
+	upgradePolicy, err = cmv1.NewUpgradePolicy().
+		ScheduleType("manual").
+		Version(version).
+		NextRun(nextRun).
+		Build()
+	if err != nil {
+		return err
+	}
+	return c.ScheduleUpgrade(clusterID, upgradePolicy)
+}
+
 func (c *Client) CancelUpgrade(clusterID string) (bool, error) {
 	scheduledUpgrade, _, err := c.GetScheduledUpgrade(clusterID)
 	if err != nil || scheduledUpgrade == nil {
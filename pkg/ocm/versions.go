@@ -162,6 +162,15 @@ func CreateVersionID(version string, channelGroup string) string {
 	return versionID
 }
 
+// GetRawVersionID returns the version of a version identifier created by CreateVersionID
+func GetRawVersionID(versionID string, channelGroup string) string {
+	rawID := strings.TrimPrefix(versionID, "openshift-v")
+	if channelGroup != DefaultChannelGroup {
+		rawID = strings.TrimSuffix(rawID, fmt.Sprintf("-%s", channelGroup))
+	}
+	return rawID
+}
+
 // Get a list of all STS-supported minor versions
 func GetVersionMinorList(ocmClient *Client) (versionList []string, err error) {
 	vs, err := ocmClient.GetVersions("")
@@ -27,7 +27,10 @@ import (
 
 	"github.com/ghodss/yaml"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cost"
+	"github.com/openshift/rosa/pkg/ocm"
 	"gitlab.com/c0b/go-ordered-json"
 )
 
@@ -52,6 +55,10 @@ func Print(resource interface{}) error {
 		if clusters, ok := resource.([]*cmv1.Cluster); ok {
 			cmv1.MarshalClusterList(clusters, &b)
 		}
+	case "[]*v1.HTPasswdUser":
+		if users, ok := resource.([]*cmv1.HTPasswdUser); ok {
+			cmv1.MarshalHTPasswdUserList(users, &b)
+		}
 	case "[]*v1.IdentityProvider":
 		if idps, ok := resource.([]*cmv1.IdentityProvider); ok {
 			cmv1.MarshalIdentityProviderList(idps, &b)
@@ -60,10 +67,22 @@ func Print(resource interface{}) error {
 		if ingresses, ok := resource.([]*cmv1.Ingress); ok {
 			cmv1.MarshalIngressList(ingresses, &b)
 		}
+	case "[]*v1.LimitedSupportReason":
+		if reasons, ok := resource.([]*cmv1.LimitedSupportReason); ok {
+			cmv1.MarshalLimitedSupportReasonList(reasons, &b)
+		}
+	case "[]*v1.LogEntry":
+		if entries, ok := resource.([]*slv1.LogEntry); ok {
+			slv1.MarshalLogEntryList(entries, &b)
+		}
 	case "[]*v1.MachinePool":
 		if machinePools, ok := resource.([]*cmv1.MachinePool); ok {
 			cmv1.MarshalMachinePoolList(machinePools, &b)
 		}
+	case "[]*v1.NodePool":
+		if nodePools, ok := resource.([]*cmv1.NodePool); ok {
+			cmv1.MarshalNodePoolList(nodePools, &b)
+		}
 	case "[]*v1.MachineType":
 		if machineTypes, ok := resource.([]*cmv1.MachineType); ok {
 			cmv1.MarshalMachineTypeList(machineTypes, &b)
@@ -85,6 +104,42 @@ func Print(resource interface{}) error {
 				}
 			}
 		}
+	case "[]*aws.QuotaStatus":
+		{
+			if statuses, ok := resource.([]*aws.QuotaStatus); ok {
+				err := encodeJSON(statuses, &b)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case "[]*aws.SpotPriceSummary":
+		{
+			if summaries, ok := resource.([]*aws.SpotPriceSummary); ok {
+				err := encodeJSON(summaries, &b)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case "[]*ocm.UpgradeCheck":
+		{
+			if checks, ok := resource.([]*ocm.UpgradeCheck); ok {
+				err := encodeJSON(checks, &b)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case "*cost.Estimate":
+		{
+			if estimate, ok := resource.(*cost.Estimate); ok {
+				err := encodeJSON(estimate, &b)
+				if err != nil {
+					return err
+				}
+			}
+		}
 	case "object.Object", "map[string]interface {}":
 		{
 			reqBodyBytes := new(bytes.Buffer)
@@ -108,6 +163,15 @@ func Print(resource interface{}) error {
 	return nil
 }
 
+func encodeJSON(resource interface{}, b *bytes.Buffer) error {
+	reqBodyBytes := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyBytes).Encode(resource)
+	if err != nil {
+		return err
+	}
+	return json.Indent(b, reqBodyBytes.Bytes(), "", "  ")
+}
+
 func parseResource(body bytes.Buffer) (string, error) {
 	switch o {
 	case "json":
@@ -28,6 +28,24 @@ const CreatorARN = prefix + "creator_arn"
 
 const CLIVersion = prefix + "cli_version"
 
+// HibernateSchedule, ResumeSchedule and ScheduleTimezone contain the cron expressions and the time zone of
+// the hibernation schedule of the cluster:
+const HibernateSchedule = prefix + "hibernate_schedule"
+const ResumeSchedule = prefix + "resume_schedule"
+const ScheduleTimezone = prefix + "schedule_timezone"
+
+// ScheduleLastRun contains the last run of the hibernation schedule that was applied to the cluster, so that
+// the clusters hibernated or resumed by hand since then are left alone:
+const ScheduleLastRun = prefix + "schedule_last_run"
+
+// AdminExpirationPrefix is the prefix of the properties that contain when the admin user named by the rest
+// of the property name expires:
+const AdminExpirationPrefix = prefix + "admin_expiration_"
+
+// TrustBundleExpiration contains when the first certificate of the additional trust bundle expires, as OCM
+// doesn't return the content of the bundle:
+const TrustBundleExpiration = prefix + "trust_bundle_expiration"
+
 const FakeCluster = "fake_cluster"
 
 // nolint:gosec // Linter thinks there are hardcoded credentials here...
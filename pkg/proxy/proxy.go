@@ -0,0 +1,263 @@
+/*
+Copyright (c) 2022 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to verify the proxy configuration of a cluster: the
+// certificates of the additional trust bundle and the tunnels to the endpoints required by the cluster.
+
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+// DefaultExpirationWarning is how long before their expiration certificates are reported as expiring
+const DefaultExpirationWarning = 30 * 24 * time.Hour
+
+// Certificate statuses
+const (
+	StatusValid       = "Valid"
+	StatusExpiring    = "Expiring"
+	StatusExpired     = "Expired"
+	StatusNotYetValid = "NotYetValid"
+)
+
+// CertificateInfo describes one of the certificates of a trust bundle
+type CertificateInfo struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+	IsCA      bool      `json:"is_ca"`
+	Status    string    `json:"status"`
+}
+
+// ParseCertificates parses all the PEM encoded certificates of the trust bundle. Blocks that aren't
+// certificates are ignored.
+func ParseCertificates(bundle []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := bundle
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse certificate %d of the trust bundle: %v", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("Trust bundle doesn't contain any PEM encoded certificate")
+	}
+	return certs, nil
+}
+
+// DescribeCertificate returns the details of the certificate and whether it is valid at the given time.
+// Certificates that expire within the warning period are reported as expiring.
+func DescribeCertificate(cert *x509.Certificate, now time.Time, warning time.Duration) *CertificateInfo {
+	status := StatusValid
+	switch {
+	case now.Before(cert.NotBefore):
+		status = StatusNotYetValid
+	case now.After(cert.NotAfter):
+		status = StatusExpired
+	case now.Add(warning).After(cert.NotAfter):
+		status = StatusExpiring
+	}
+	return &CertificateInfo{
+		Subject:   cert.Subject.String(),
+		Issuer:    cert.Issuer.String(),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+		IsCA:      cert.IsCA,
+		Status:    status,
+	}
+}
+
+// TrustBundleProperties returns a copy of the cluster properties with the expiration of the first
+// certificate of the PEM encoded bundle to expire. The property is removed when the bundle is empty or
+// doesn't contain certificates.
+func TrustBundleProperties(clusterProperties map[string]string, bundle string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range clusterProperties {
+		result[key] = value
+	}
+	delete(result, properties.TrustBundleExpiration)
+
+	certs, err := ParseCertificates([]byte(bundle))
+	if err != nil {
+		return result
+	}
+	var expiration time.Time
+	for _, cert := range certs {
+		if expiration.IsZero() || cert.NotAfter.Before(expiration) {
+			expiration = cert.NotAfter
+		}
+	}
+	if !expiration.IsZero() {
+		result[properties.TrustBundleExpiration] = expiration.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// GetTrustBundleExpiration returns when the first certificate of the additional trust bundle of the
+// cluster expires, if it was recorded in the cluster properties
+func GetTrustBundleExpiration(clusterProperties map[string]string) (time.Time, bool) {
+	expiration, err := time.Parse(time.RFC3339, clusterProperties[properties.TrustBundleExpiration])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiration, true
+}
+
+// VerifyChain checks that a chain can be built from the certificate to a root of the bundle or of the
+// system, using the other certificates of the bundle as intermediates
+func VerifyChain(cert *x509.Certificate, bundle []*x509.Certificate, now time.Time) error {
+	roots := getSystemCertPool()
+	intermediates := x509.NewCertPool()
+	for _, c := range bundle {
+		if isSelfSigned(c) {
+			roots.AddCert(c)
+		} else {
+			intermediates.AddCert(c)
+		}
+	}
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// GetCertPool returns the certificates trusted by the system together with the certificates of the
+// bundle
+func GetCertPool(bundle []*x509.Certificate) *x509.CertPool {
+	pool := getSystemCertPool()
+	for _, cert := range bundle {
+		pool.AddCert(cert)
+	}
+	return pool
+}
+
+// TestTunnel opens a CONNECT tunnel through the proxy to the endpoint and completes a TLS handshake with
+// the endpoint through it, trusting the given certificates. Proxies that intercept TLS traffic must
+// present certificates signed by one of the trusted certificates.
+func TestTunnel(proxyURL *url.URL, endpoint string, rootCAs *x509.CertPool, timeout time.Duration) error {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return fmt.Errorf("Invalid endpoint '%s': %v", endpoint, err)
+	}
+	proxyAddress := proxyURL.Host
+	if proxyURL.Port() == "" {
+		port := "80"
+		if proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddress = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+
+	deadline := time.Now().Add(timeout)
+	conn, err := net.DialTimeout("tcp", proxyAddress, timeout)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to proxy '%s': %v", proxyAddress, err)
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return err
+	}
+	if proxyURL.Scheme == "https" {
+		proxyConn := tls.Client(conn, &tls.Config{
+			ServerName: proxyURL.Hostname(),
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		})
+		err = proxyConn.Handshake()
+		if err != nil {
+			return fmt.Errorf("Failed TLS handshake with proxy '%s': %v", proxyAddress, err)
+		}
+		conn = proxyConn
+	}
+
+	var request bytes.Buffer
+	fmt.Fprintf(&request, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n", endpoint, endpoint)
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := fmt.Sprintf("%s:%s", proxyURL.User.Username(), password)
+		fmt.Fprintf(&request, "Proxy-Authorization: Basic %s\r\n",
+			base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+	request.WriteString("\r\n")
+	_, err = conn.Write(request.Bytes())
+	if err != nil {
+		return fmt.Errorf("Failed to send CONNECT request to proxy: %v", err)
+	}
+	reader := bufio.NewReader(conn)
+	// Successful responses to CONNECT requests have no body
+	response, err := http.ReadResponse(reader, &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return fmt.Errorf("Failed to read CONNECT response from proxy: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Proxy refused tunnel: %s", response.Status)
+	}
+	if reader.Buffered() > 0 {
+		return fmt.Errorf("Proxy sent unexpected data after the CONNECT response")
+	}
+
+	endpointConn := tls.Client(conn, &tls.Config{
+		ServerName: host,
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	})
+	err = endpointConn.Handshake()
+	if err != nil {
+		return fmt.Errorf("Failed TLS handshake through the tunnel: %v", err)
+	}
+	return nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func getSystemCertPool() *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		return x509.NewCertPool()
+	}
+	return pool
+}
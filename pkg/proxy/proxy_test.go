@@ -0,0 +1,193 @@
+package proxy_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/proxy"
+)
+
+var now = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newCertificate(name string, isCA bool, notAfter time.Time,
+	parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+	return cert, key
+}
+
+func encode(certs ...*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+var _ = Describe("Proxy", func() {
+	Context("Trust bundle", func() {
+		var (
+			root         *x509.Certificate
+			intermediate *x509.Certificate
+			leaf         *x509.Certificate
+		)
+
+		BeforeEach(func() {
+			var rootKey, intermediateKey *ecdsa.PrivateKey
+			root, rootKey = newCertificate("root", true, now.AddDate(5, 0, 0), nil, nil)
+			intermediate, intermediateKey = newCertificate("intermediate", true, now.AddDate(0, 0, 10),
+				root, rootKey)
+			leaf, _ = newCertificate("leaf", false, now.AddDate(1, 0, 0), intermediate, intermediateKey)
+		})
+
+		It("Parses all the certificates of the bundle", func() {
+			data := append([]byte("# comment\n"), encode(root, intermediate)...)
+			certs, err := proxy.ParseCertificates(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certs).To(HaveLen(2))
+		})
+
+		It("Fails when the bundle has no certificates", func() {
+			_, err := proxy.ParseCertificates([]byte("not a certificate"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Reports the status of the certificates", func() {
+			info := proxy.DescribeCertificate(root, now, proxy.DefaultExpirationWarning)
+			Expect(info.Subject).To(Equal("CN=root"))
+			Expect(info.IsCA).To(BeTrue())
+			Expect(info.Status).To(Equal(proxy.StatusValid))
+
+			info = proxy.DescribeCertificate(intermediate, now, proxy.DefaultExpirationWarning)
+			Expect(info.Issuer).To(Equal("CN=root"))
+			Expect(info.Status).To(Equal(proxy.StatusExpiring))
+
+			info = proxy.DescribeCertificate(intermediate, now.AddDate(0, 1, 0), proxy.DefaultExpirationWarning)
+			Expect(info.Status).To(Equal(proxy.StatusExpired))
+		})
+
+		It("Records the expiration of the first certificate to expire", func() {
+			props := proxy.TrustBundleProperties(map[string]string{"key": "value"},
+				string(encode(root, intermediate, leaf)))
+			Expect(props).To(HaveKeyWithValue("key", "value"))
+			expiration, ok := proxy.GetTrustBundleExpiration(props)
+			Expect(ok).To(BeTrue())
+			Expect(expiration).To(BeTemporally("==", intermediate.NotAfter))
+
+			props = proxy.TrustBundleProperties(props, "")
+			Expect(props).To(Equal(map[string]string{"key": "value"}))
+			_, ok = proxy.GetTrustBundleExpiration(props)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("Builds chains through the intermediates of the bundle", func() {
+			bundle := []*x509.Certificate{root, intermediate, leaf}
+			Expect(proxy.VerifyChain(leaf, bundle, now)).To(Succeed())
+			Expect(proxy.VerifyChain(intermediate, bundle, now)).To(Succeed())
+		})
+
+		It("Fails to build chains without the intermediate", func() {
+			bundle := []*x509.Certificate{root, leaf}
+			Expect(proxy.VerifyChain(leaf, bundle, now)).NotTo(Succeed())
+		})
+	})
+
+	Context("Tunnel", func() {
+		var (
+			endpoint *httptest.Server
+			proxyURL *url.URL
+			refuse   bool
+		)
+
+		BeforeEach(func() {
+			refuse = false
+			endpoint = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodConnect || refuse {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				target, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				client, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					target.Close()
+					return
+				}
+				_, err = client.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+				if err != nil {
+					target.Close()
+					client.Close()
+					return
+				}
+				go func() {
+					defer target.Close()
+					defer client.Close()
+					go io.Copy(target, client) // nolint:errcheck
+					io.Copy(client, target)    // nolint:errcheck
+				}()
+			}))
+			DeferCleanup(func() {
+				proxyServer.Close()
+				endpoint.Close()
+			})
+			var err error
+			proxyURL, err = url.Parse(proxyServer.URL)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Completes a TLS handshake through the tunnel", func() {
+			err := proxy.TestTunnel(proxyURL, endpoint.Listener.Addr().String(),
+				proxy.GetCertPool([]*x509.Certificate{endpoint.Certificate()}), 5*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Fails when the endpoint certificate isn't trusted", func() {
+			err := proxy.TestTunnel(proxyURL, endpoint.Listener.Addr().String(), x509.NewCertPool(), 5*time.Second)
+			Expect(err).To(MatchError(ContainSubstring("TLS handshake")))
+		})
+
+		It("Fails when the proxy refuses the tunnel", func() {
+			refuse = true
+			err := proxy.TestTunnel(proxyURL, endpoint.Listener.Addr().String(),
+				proxy.GetCertPool([]*x509.Certificate{endpoint.Certificate()}), 5*time.Second)
+			Expect(err).To(MatchError(ContainSubstring("403")))
+		})
+	})
+})
@@ -94,5 +94,17 @@ func (r *Runtime) FetchCluster() *cmv1.Cluster {
 		os.Exit(1)
 	}
 	r.Cluster = cluster
+	r.warnExpiration(cluster)
 	return cluster
 }
+
+// warnExpiration warns that the cluster will be deleted automatically when it has an expiration timestamp
+func (r *Runtime) warnExpiration(cluster *cmv1.Cluster) {
+	expiration, ok := cluster.GetExpirationTimestamp()
+	if !ok || expiration.IsZero() {
+		return
+	}
+	r.Reporter.Warnf("Cluster '%s' expires on %s and will then be deleted automatically. To change it, run "+
+		"'rosa edit cluster -c %s' with '--expiration' or '--clear-expiration'",
+		r.ClusterKey, expiration.Format("Jan _2 2006 15:04:05 MST"), r.ClusterKey)
+}